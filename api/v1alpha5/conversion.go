@@ -39,6 +39,13 @@ func (src *Metal3Cluster) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.FailureDomains = restored.Status.FailureDomains
+	dst.Spec.FailureDomainLabel = restored.Spec.FailureDomainLabel
+	dst.Spec.FailureDomains = restored.Spec.FailureDomains
+	dst.Spec.RemediationBudget = restored.Spec.RemediationBudget
+	dst.Spec.LabelSync = restored.Spec.LabelSync
+	dst.Status.RemediationStartTimes = restored.Status.RemediationStartTimes
+	dst.Status.RemediationsInFlight = restored.Status.RemediationsInFlight
 	return nil
 }
 
@@ -59,6 +66,11 @@ func Convert_v1beta1_Metal3ClusterStatus_To_v1alpha5_Metal3ClusterStatus(in *v1b
 	return autoConvert_v1beta1_Metal3ClusterStatus_To_v1alpha5_Metal3ClusterStatus(in, out, s)
 }
 
+// Spec.FailureDomainLabel, Spec.FailureDomains, Spec.RemediationBudget and Spec.LabelSync were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(in *v1beta1.Metal3ClusterSpec, out *Metal3ClusterSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(in, out, s)
+}
+
 func (src *Metal3ClusterList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Metal3ClusterList)
 	return Convert_v1alpha5_Metal3ClusterList_To_v1beta1_Metal3ClusterList(src, dst, nil)
@@ -80,6 +92,9 @@ func (src *Metal3Machine) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Spec.HostSelectionPolicy = restored.Spec.HostSelectionPolicy
+	dst.Spec.FailureDomain = restored.Spec.FailureDomain
+	dst.Spec.ImageUpgradeStrategy = restored.Spec.ImageUpgradeStrategy
 	return nil
 }
 
@@ -100,6 +115,11 @@ func Convert_v1beta1_Metal3MachineStatus_To_v1alpha5_Metal3MachineStatus(in *v1b
 	return autoConvert_v1beta1_Metal3MachineStatus_To_v1alpha5_Metal3MachineStatus(in, out, s)
 }
 
+// Spec.HostSelectionPolicy, Spec.FailureDomain and Spec.ImageUpgradeStrategy were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(in *v1beta1.Metal3MachineSpec, out *Metal3MachineSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(in, out, s)
+}
+
 func (src *Metal3MachineList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Metal3MachineList)
 	return Convert_v1alpha5_Metal3MachineList_To_v1beta1_Metal3MachineList(src, dst, nil)
@@ -115,7 +135,14 @@ func (src *Metal3MachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if err := Convert_v1alpha5_Metal3MachineTemplate_To_v1beta1_Metal3MachineTemplate(src, dst, nil); err != nil {
 		return err
 	}
-
+	// Manually restore data.
+	restored := &v1beta1.Metal3MachineTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.Template.Spec.HostSelectionPolicy = restored.Spec.Template.Spec.HostSelectionPolicy
+	dst.Spec.Template.Spec.FailureDomain = restored.Spec.Template.Spec.FailureDomain
+	dst.Spec.Template.Spec.ImageUpgradeStrategy = restored.Spec.Template.Spec.ImageUpgradeStrategy
 	return nil
 }
 
@@ -124,7 +151,10 @@ func (dst *Metal3MachineTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	if err := Convert_v1beta1_Metal3MachineTemplate_To_v1alpha5_Metal3MachineTemplate(src, dst, nil); err != nil {
 		return err
 	}
-
+	// Preserve Hub data on down-conversion except for metadata
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -143,6 +173,12 @@ func (src *Metal3Data) ConvertTo(dstRaw conversion.Hub) error {
 	if err := Convert_v1alpha5_Metal3Data_To_v1beta1_Metal3Data(src, dst, nil); err != nil {
 		return err
 	}
+	// Manually restore data.
+	restored := &v1beta1.Metal3Data{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Status.TemplateGeneration = restored.Status.TemplateGeneration
 
 	return nil
 }
@@ -152,6 +188,10 @@ func (dst *Metal3Data) ConvertFrom(srcRaw conversion.Hub) error {
 	if err := Convert_v1beta1_Metal3Data_To_v1alpha5_Metal3Data(src, dst, nil); err != nil {
 		return err
 	}
+	// Preserve Hub data on down-conversion except for metadata
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -171,19 +211,156 @@ func (src *Metal3DataTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if err := Convert_v1alpha5_Metal3DataTemplate_To_v1beta1_Metal3DataTemplate(src, dst, nil); err != nil {
 		return err
 	}
+	// Manually restore data.
+	restored := &v1beta1.Metal3DataTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	dst.Spec.Mutable = restored.Spec.Mutable
+	dst.Spec.RerenderBatchSize = restored.Spec.RerenderBatchSize
+	dst.Spec.IndexAllocation = restored.Spec.IndexAllocation
+	dst.Status.HostIndexes = restored.Status.HostIndexes
+	if dst.Spec.MetaData != nil && restored.Spec.MetaData != nil {
+		dst.Spec.MetaData.Templates = restored.Spec.MetaData.Templates
+		dst.Spec.MetaData.FromHardwareDetails = restored.Spec.MetaData.FromHardwareDetails
+		dst.Spec.MetaData.FromConfigMaps = restored.Spec.MetaData.FromConfigMaps
+		dst.Spec.MetaData.FromSecrets = restored.Spec.MetaData.FromSecrets
+		dst.Spec.MetaData.SourcesUpdatePolicy = restored.Spec.MetaData.SourcesUpdatePolicy
+	}
+	if dst.Spec.NetworkData != nil && restored.Spec.NetworkData != nil {
+		dst.Spec.NetworkData.Format = restored.Spec.NetworkData.Format
+		restoreNetworkDataNetworks(&dst.Spec.NetworkData.Networks, &restored.Spec.NetworkData.Networks)
+		dst.Spec.NetworkData.Links.EthernetsFromHost = restored.Spec.NetworkData.Links.EthernetsFromHost
+		if len(dst.Spec.NetworkData.Links.Bonds) == len(restored.Spec.NetworkData.Links.Bonds) {
+			for i := range dst.Spec.NetworkData.Links.Bonds {
+				dst.Spec.NetworkData.Links.Bonds[i].BondOptions = restored.Spec.NetworkData.Links.Bonds[i].BondOptions
+			}
+		}
+	}
 
 	return nil
 }
 
+// restoreNetworkDataNetworks restores the routing policy rules and the route
+// metrics and tables, that were introduced in v1beta1.
+func restoreNetworkDataNetworks(dst, restored *v1beta1.NetworkDataNetwork) {
+	if len(dst.IPv4) == len(restored.IPv4) {
+		for i := range dst.IPv4 {
+			dst.IPv4[i].Rules = restored.IPv4[i].Rules
+			restoreRoutesv4(dst.IPv4[i].Routes, restored.IPv4[i].Routes)
+		}
+	}
+	if len(dst.IPv6) == len(restored.IPv6) {
+		for i := range dst.IPv6 {
+			dst.IPv6[i].Rules = restored.IPv6[i].Rules
+			restoreRoutesv6(dst.IPv6[i].Routes, restored.IPv6[i].Routes)
+		}
+	}
+	if len(dst.IPv4DHCP) == len(restored.IPv4DHCP) {
+		for i := range dst.IPv4DHCP {
+			restoreRoutesv4(dst.IPv4DHCP[i].Routes, restored.IPv4DHCP[i].Routes)
+		}
+	}
+	if len(dst.IPv6DHCP) == len(restored.IPv6DHCP) {
+		for i := range dst.IPv6DHCP {
+			restoreRoutesv6(dst.IPv6DHCP[i].Routes, restored.IPv6DHCP[i].Routes)
+		}
+	}
+	if len(dst.IPv6SLAAC) == len(restored.IPv6SLAAC) {
+		for i := range dst.IPv6SLAAC {
+			restoreRoutesv6(dst.IPv6SLAAC[i].Routes, restored.IPv6SLAAC[i].Routes)
+		}
+	}
+}
+
+func restoreRoutesv4(dst, restored []v1beta1.NetworkDataRoutev4) {
+	if len(dst) != len(restored) {
+		return
+	}
+	for i := range dst {
+		dst[i].Metric = restored[i].Metric
+		dst[i].Table = restored[i].Table
+	}
+}
+
+func restoreRoutesv6(dst, restored []v1beta1.NetworkDataRoutev6) {
+	if len(dst) != len(restored) {
+		return
+	}
+	for i := range dst {
+		dst[i].Metric = restored[i].Metric
+		dst[i].Table = restored[i].Table
+	}
+}
+
 func (dst *Metal3DataTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.Metal3DataTemplate)
 	if err := Convert_v1beta1_Metal3DataTemplate_To_v1alpha5_Metal3DataTemplate(src, dst, nil); err != nil {
 		return err
 	}
+	// Preserve Hub data on down-conversion except for metadata
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// Spec.Mutable, Spec.RerenderBatchSize and Spec.IndexAllocation were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(in *v1beta1.Metal3DataTemplateSpec, out *Metal3DataTemplateSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(in, out, s)
+}
+
+// Status.HostIndexes was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(in *v1beta1.Metal3DataTemplateStatus, out *Metal3DataTemplateStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(in, out, s)
+}
+
+// Status.TemplateGeneration was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(in *v1beta1.Metal3DataStatus, out *Metal3DataStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(in, out, s)
+}
+
+// Spec.MetaData.Templates, FromHardwareDetails, FromConfigMaps, FromSecrets and SourcesUpdatePolicy were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_MetaData_To_v1alpha5_MetaData(in *v1beta1.MetaData, out *MetaData, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_MetaData_To_v1alpha5_MetaData(in, out, s)
+}
+
+// Spec.NetworkData.Format was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in *v1beta1.NetworkData, out *NetworkData, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in, out, s)
+}
+
+// Spec.NetworkData.Links.EthernetsFromHost was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(in *v1beta1.NetworkDataLink, out *NetworkDataLink, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(in, out, s)
+}
+
+// Spec.NetworkData.Links.Bonds.BondOptions was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(in *v1beta1.NetworkDataLinkBond, out *NetworkDataLinkBond, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(in, out, s)
+}
+
+// Spec.NetworkData.Networks.IPv4.Rules was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(in *v1beta1.NetworkDataIPv4, out *NetworkDataIPv4, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(in, out, s)
+}
+
+// Spec.NetworkData.Networks.IPv6.Rules was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(in *v1beta1.NetworkDataIPv6, out *NetworkDataIPv6, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(in, out, s)
+}
+
+// Metric and Table were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(in *v1beta1.NetworkDataRoutev4, out *NetworkDataRoutev4, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(in, out, s)
+}
+
+// Metric and Table were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(in *v1beta1.NetworkDataRoutev6, out *NetworkDataRoutev6, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(in, out, s)
+}
+
 func (src *Metal3DataTemplateList) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Metal3DataTemplateList)
 	return Convert_v1alpha5_Metal3DataTemplateList_To_v1beta1_Metal3DataTemplateList(src, dst, nil)
@@ -224,12 +401,49 @@ func (dst *Metal3DataClaimList) ConvertFrom(srcRaw conversion.Hub) error {
 
 func (src *Metal3Remediation) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Metal3Remediation)
-	return Convert_v1alpha5_Metal3Remediation_To_v1beta1_Metal3Remediation(src, dst, nil)
+	if err := Convert_v1alpha5_Metal3Remediation_To_v1beta1_Metal3Remediation(src, dst, nil); err != nil {
+		return err
+	}
+	// Manually restore data.
+	restored := &v1beta1.Metal3Remediation{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	if dst.Spec.Strategy != nil && restored.Spec.Strategy != nil {
+		dst.Spec.Strategy.Steps = restored.Spec.Strategy.Steps
+	}
+	dst.Spec.NodeDrain = restored.Spec.NodeDrain
+	dst.Status.CurrentStep = restored.Status.CurrentStep
+	dst.Status.History = restored.Status.History
+	dst.Status.NodeDrainStartTime = restored.Status.NodeDrainStartTime
+	return nil
 }
 
 func (dst *Metal3Remediation) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.Metal3Remediation)
-	return Convert_v1beta1_Metal3Remediation_To_v1alpha5_Metal3Remediation(src, dst, nil)
+	if err := Convert_v1beta1_Metal3Remediation_To_v1alpha5_Metal3Remediation(src, dst, nil); err != nil {
+		return err
+	}
+	// Preserve Hub data on down-conversion except for metadata
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Status.CurrentStep, Status.History and Status.NodeDrainStartTime were introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(in *v1beta1.Metal3RemediationStatus, out *Metal3RemediationStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(in, out, s)
+}
+
+// Spec.NodeDrain was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(in *v1beta1.Metal3RemediationSpec, out *Metal3RemediationSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(in, out, s)
+}
+
+// Steps was introduced in v1beta1, thus requiring a custom conversion function; the values is going to be preserved in an annotation thus allowing roundtrip without losing information.
+func Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(in *v1beta1.RemediationStrategy, out *RemediationStrategy, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(in, out, s)
 }
 
 func (src *Metal3RemediationList) ConvertTo(dstRaw conversion.Hub) error {
@@ -244,12 +458,34 @@ func (dst *Metal3RemediationList) ConvertFrom(srcRaw conversion.Hub) error {
 
 func (src *Metal3RemediationTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Metal3RemediationTemplate)
-	return Convert_v1alpha5_Metal3RemediationTemplate_To_v1beta1_Metal3RemediationTemplate(src, dst, nil)
+	if err := Convert_v1alpha5_Metal3RemediationTemplate_To_v1beta1_Metal3RemediationTemplate(src, dst, nil); err != nil {
+		return err
+	}
+	// Manually restore data.
+	restored := &v1beta1.Metal3RemediationTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+	if dst.Spec.Template.Spec.Strategy != nil && restored.Spec.Template.Spec.Strategy != nil {
+		dst.Spec.Template.Spec.Strategy.Steps = restored.Spec.Template.Spec.Strategy.Steps
+	}
+	dst.Spec.Template.Spec.NodeDrain = restored.Spec.Template.Spec.NodeDrain
+	dst.Status.Status.CurrentStep = restored.Status.Status.CurrentStep
+	dst.Status.Status.History = restored.Status.Status.History
+	dst.Status.Status.NodeDrainStartTime = restored.Status.Status.NodeDrainStartTime
+	return nil
 }
 
 func (dst *Metal3RemediationTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.Metal3RemediationTemplate)
-	return Convert_v1beta1_Metal3RemediationTemplate_To_v1alpha5_Metal3RemediationTemplate(src, dst, nil)
+	if err := Convert_v1beta1_Metal3RemediationTemplate_To_v1alpha5_Metal3RemediationTemplate(src, dst, nil); err != nil {
+		return err
+	}
+	// Preserve Hub data on down-conversion except for metadata
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
+	return nil
 }
 
 func (src *Metal3RemediationTemplateList) ConvertTo(dstRaw conversion.Hub) error {
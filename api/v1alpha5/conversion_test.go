@@ -88,4 +88,16 @@ func TestFuzzyConversion(t *testing.T) {
 		Hub:    &v1beta1.Metal3DataClaim{},
 		Spoke:  &Metal3DataClaim{},
 	}))
+
+	t.Run("for Metal3Remediation", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &v1beta1.Metal3Remediation{},
+		Spoke:  &Metal3Remediation{},
+	}))
+
+	t.Run("for Metal3RemediationTemplate", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &v1beta1.Metal3RemediationTemplate{},
+		Spoke:  &Metal3RemediationTemplate{},
+	}))
 }
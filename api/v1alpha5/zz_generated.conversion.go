@@ -98,11 +98,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.MetaData)(nil), (*MetaData)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_MetaData_To_v1alpha5_MetaData(a.(*v1beta1.MetaData), b.(*MetaData), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*MetaDataFromAnnotation)(nil), (*v1beta1.MetaDataFromAnnotation)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_MetaDataFromAnnotation_To_v1beta1_MetaDataFromAnnotation(a.(*MetaDataFromAnnotation), b.(*v1beta1.MetaDataFromAnnotation), scope)
 	}); err != nil {
@@ -208,11 +203,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3ClusterSpec)(nil), (*Metal3ClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(a.(*v1beta1.Metal3ClusterSpec), b.(*Metal3ClusterSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3ClusterStatus)(nil), (*v1beta1.Metal3ClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3ClusterStatus_To_v1beta1_Metal3ClusterStatus(a.(*Metal3ClusterStatus), b.(*v1beta1.Metal3ClusterStatus), scope)
 	}); err != nil {
@@ -293,11 +283,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3DataStatus)(nil), (*Metal3DataStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(a.(*v1beta1.Metal3DataStatus), b.(*Metal3DataStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3DataTemplate)(nil), (*v1beta1.Metal3DataTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3DataTemplate_To_v1beta1_Metal3DataTemplate(a.(*Metal3DataTemplate), b.(*v1beta1.Metal3DataTemplate), scope)
 	}); err != nil {
@@ -323,21 +308,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3DataTemplateSpec)(nil), (*Metal3DataTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(a.(*v1beta1.Metal3DataTemplateSpec), b.(*Metal3DataTemplateSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3DataTemplateStatus)(nil), (*v1beta1.Metal3DataTemplateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3DataTemplateStatus_To_v1beta1_Metal3DataTemplateStatus(a.(*Metal3DataTemplateStatus), b.(*v1beta1.Metal3DataTemplateStatus), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3DataTemplateStatus)(nil), (*Metal3DataTemplateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(a.(*v1beta1.Metal3DataTemplateStatus), b.(*Metal3DataTemplateStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3Machine)(nil), (*v1beta1.Metal3Machine)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3Machine_To_v1beta1_Metal3Machine(a.(*Metal3Machine), b.(*v1beta1.Metal3Machine), scope)
 	}); err != nil {
@@ -363,11 +338,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3MachineSpec)(nil), (*Metal3MachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(a.(*v1beta1.Metal3MachineSpec), b.(*Metal3MachineSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3MachineStatus)(nil), (*v1beta1.Metal3MachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3MachineStatus_To_v1beta1_Metal3MachineStatus(a.(*Metal3MachineStatus), b.(*v1beta1.Metal3MachineStatus), scope)
 	}); err != nil {
@@ -438,21 +408,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3RemediationSpec)(nil), (*Metal3RemediationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(a.(*v1beta1.Metal3RemediationSpec), b.(*Metal3RemediationSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3RemediationStatus)(nil), (*v1beta1.Metal3RemediationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3RemediationStatus_To_v1beta1_Metal3RemediationStatus(a.(*Metal3RemediationStatus), b.(*v1beta1.Metal3RemediationStatus), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.Metal3RemediationStatus)(nil), (*Metal3RemediationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(a.(*v1beta1.Metal3RemediationStatus), b.(*Metal3RemediationStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*Metal3RemediationTemplate)(nil), (*v1beta1.Metal3RemediationTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_Metal3RemediationTemplate_To_v1beta1_Metal3RemediationTemplate(a.(*Metal3RemediationTemplate), b.(*v1beta1.Metal3RemediationTemplate), scope)
 	}); err != nil {
@@ -508,21 +468,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkData)(nil), (*NetworkData)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData(a.(*v1beta1.NetworkData), b.(*NetworkData), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataIPv4)(nil), (*v1beta1.NetworkDataIPv4)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataIPv4_To_v1beta1_NetworkDataIPv4(a.(*NetworkDataIPv4), b.(*v1beta1.NetworkDataIPv4), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataIPv4)(nil), (*NetworkDataIPv4)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(a.(*v1beta1.NetworkDataIPv4), b.(*NetworkDataIPv4), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataIPv4DHCP)(nil), (*v1beta1.NetworkDataIPv4DHCP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataIPv4DHCP_To_v1beta1_NetworkDataIPv4DHCP(a.(*NetworkDataIPv4DHCP), b.(*v1beta1.NetworkDataIPv4DHCP), scope)
 	}); err != nil {
@@ -538,11 +488,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataIPv6)(nil), (*NetworkDataIPv6)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(a.(*v1beta1.NetworkDataIPv6), b.(*NetworkDataIPv6), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataIPv6DHCP)(nil), (*v1beta1.NetworkDataIPv6DHCP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataIPv6DHCP_To_v1beta1_NetworkDataIPv6DHCP(a.(*NetworkDataIPv6DHCP), b.(*v1beta1.NetworkDataIPv6DHCP), scope)
 	}); err != nil {
@@ -558,21 +503,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataLink)(nil), (*NetworkDataLink)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(a.(*v1beta1.NetworkDataLink), b.(*NetworkDataLink), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataLinkBond)(nil), (*v1beta1.NetworkDataLinkBond)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataLinkBond_To_v1beta1_NetworkDataLinkBond(a.(*NetworkDataLinkBond), b.(*v1beta1.NetworkDataLinkBond), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataLinkBond)(nil), (*NetworkDataLinkBond)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(a.(*v1beta1.NetworkDataLinkBond), b.(*NetworkDataLinkBond), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataLinkEthernet)(nil), (*v1beta1.NetworkDataLinkEthernet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataLinkEthernet_To_v1beta1_NetworkDataLinkEthernet(a.(*NetworkDataLinkEthernet), b.(*v1beta1.NetworkDataLinkEthernet), scope)
 	}); err != nil {
@@ -608,21 +543,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataRoutev4)(nil), (*NetworkDataRoutev4)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(a.(*v1beta1.NetworkDataRoutev4), b.(*NetworkDataRoutev4), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataRoutev6)(nil), (*v1beta1.NetworkDataRoutev6)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataRoutev6_To_v1beta1_NetworkDataRoutev6(a.(*NetworkDataRoutev6), b.(*v1beta1.NetworkDataRoutev6), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.NetworkDataRoutev6)(nil), (*NetworkDataRoutev6)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(a.(*v1beta1.NetworkDataRoutev6), b.(*NetworkDataRoutev6), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkDataService)(nil), (*v1beta1.NetworkDataService)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha5_NetworkDataService_To_v1beta1_NetworkDataService(a.(*NetworkDataService), b.(*v1beta1.NetworkDataService), scope)
 	}); err != nil {
@@ -688,8 +613,13 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.RemediationStrategy)(nil), (*RemediationStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(a.(*v1beta1.RemediationStrategy), b.(*RemediationStrategy), scope)
+	if err := s.AddConversionFunc((*v1beta1.MetaData)(nil), (*MetaData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_MetaData_To_v1alpha5_MetaData(a.(*v1beta1.MetaData), b.(*MetaData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3ClusterSpec)(nil), (*Metal3ClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(a.(*v1beta1.Metal3ClusterSpec), b.(*Metal3ClusterSpec), scope)
 	}); err != nil {
 		return err
 	}
@@ -698,11 +628,81 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3DataStatus)(nil), (*Metal3DataStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(a.(*v1beta1.Metal3DataStatus), b.(*Metal3DataStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3DataTemplateSpec)(nil), (*Metal3DataTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(a.(*v1beta1.Metal3DataTemplateSpec), b.(*Metal3DataTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3DataTemplateStatus)(nil), (*Metal3DataTemplateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(a.(*v1beta1.Metal3DataTemplateStatus), b.(*Metal3DataTemplateStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3MachineSpec)(nil), (*Metal3MachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(a.(*v1beta1.Metal3MachineSpec), b.(*Metal3MachineSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.Metal3MachineStatus)(nil), (*Metal3MachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Metal3MachineStatus_To_v1alpha5_Metal3MachineStatus(a.(*v1beta1.Metal3MachineStatus), b.(*Metal3MachineStatus), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3RemediationSpec)(nil), (*Metal3RemediationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(a.(*v1beta1.Metal3RemediationSpec), b.(*Metal3RemediationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.Metal3RemediationStatus)(nil), (*Metal3RemediationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(a.(*v1beta1.Metal3RemediationStatus), b.(*Metal3RemediationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataIPv4)(nil), (*NetworkDataIPv4)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(a.(*v1beta1.NetworkDataIPv4), b.(*NetworkDataIPv4), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataIPv6)(nil), (*NetworkDataIPv6)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(a.(*v1beta1.NetworkDataIPv6), b.(*NetworkDataIPv6), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataLinkBond)(nil), (*NetworkDataLinkBond)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(a.(*v1beta1.NetworkDataLinkBond), b.(*NetworkDataLinkBond), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataLink)(nil), (*NetworkDataLink)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(a.(*v1beta1.NetworkDataLink), b.(*NetworkDataLink), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataRoutev4)(nil), (*NetworkDataRoutev4)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(a.(*v1beta1.NetworkDataRoutev4), b.(*NetworkDataRoutev4), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkDataRoutev6)(nil), (*NetworkDataRoutev6)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(a.(*v1beta1.NetworkDataRoutev6), b.(*NetworkDataRoutev6), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.NetworkData)(nil), (*NetworkData)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData(a.(*v1beta1.NetworkData), b.(*NetworkData), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.RemediationStrategy)(nil), (*RemediationStrategy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(a.(*v1beta1.RemediationStrategy), b.(*RemediationStrategy), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -854,14 +854,14 @@ func autoConvert_v1beta1_MetaData_To_v1alpha5_MetaData(in *v1beta1.MetaData, out
 	out.FromHostInterfaces = *(*[]MetaDataHostInterface)(unsafe.Pointer(&in.FromHostInterfaces))
 	out.FromLabels = *(*[]MetaDataFromLabel)(unsafe.Pointer(&in.FromLabels))
 	out.FromAnnotations = *(*[]MetaDataFromAnnotation)(unsafe.Pointer(&in.FromAnnotations))
+	// WARNING: in.Templates requires manual conversion: does not exist in peer-type
+	// WARNING: in.FromHardwareDetails requires manual conversion: does not exist in peer-type
+	// WARNING: in.FromConfigMaps requires manual conversion: does not exist in peer-type
+	// WARNING: in.FromSecrets requires manual conversion: does not exist in peer-type
+	// WARNING: in.SourcesUpdatePolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_MetaData_To_v1alpha5_MetaData is an autogenerated conversion function.
-func Convert_v1beta1_MetaData_To_v1alpha5_MetaData(in *v1beta1.MetaData, out *MetaData, s conversion.Scope) error {
-	return autoConvert_v1beta1_MetaData_To_v1alpha5_MetaData(in, out, s)
-}
-
 func autoConvert_v1alpha5_MetaDataFromAnnotation_To_v1beta1_MetaDataFromAnnotation(in *MetaDataFromAnnotation, out *v1beta1.MetaDataFromAnnotation, s conversion.Scope) error {
 	out.Key = in.Key
 	out.Object = in.Object
@@ -1144,14 +1144,13 @@ func autoConvert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(in *v1b
 		return err
 	}
 	out.NoCloudProvider = in.NoCloudProvider
+	// WARNING: in.FailureDomainLabel requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
+	// WARNING: in.RemediationBudget requires manual conversion: does not exist in peer-type
+	// WARNING: in.LabelSync requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec is an autogenerated conversion function.
-func Convert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(in *v1beta1.Metal3ClusterSpec, out *Metal3ClusterSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3ClusterSpec_To_v1alpha5_Metal3ClusterSpec(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3ClusterStatus_To_v1beta1_Metal3ClusterStatus(in *Metal3ClusterStatus, out *v1beta1.Metal3ClusterStatus, s conversion.Scope) error {
 	out.LastUpdated = (*v1.Time)(unsafe.Pointer(in.LastUpdated))
 	out.FailureReason = (*errors.ClusterStatusError)(unsafe.Pointer(in.FailureReason))
@@ -1170,6 +1169,9 @@ func autoConvert_v1beta1_Metal3ClusterStatus_To_v1alpha5_Metal3ClusterStatus(in
 	out.FailureReason = (*errors.ClusterStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	out.Ready = in.Ready
+	// WARNING: in.FailureDomains requires manual conversion: does not exist in peer-type
+	// WARNING: in.RemediationStartTimes requires manual conversion: does not exist in peer-type
+	// WARNING: in.RemediationsInFlight requires manual conversion: does not exist in peer-type
 	// WARNING: in.Conditions requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1304,7 +1306,17 @@ func Convert_v1beta1_Metal3DataClaimStatus_To_v1alpha5_Metal3DataClaimStatus(in
 
 func autoConvert_v1alpha5_Metal3DataList_To_v1beta1_Metal3DataList(in *Metal3DataList, out *v1beta1.Metal3DataList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta1.Metal3Data)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.Metal3Data, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_Metal3Data_To_v1beta1_Metal3Data(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1315,7 +1327,17 @@ func Convert_v1alpha5_Metal3DataList_To_v1beta1_Metal3DataList(in *Metal3DataLis
 
 func autoConvert_v1beta1_Metal3DataList_To_v1alpha5_Metal3DataList(in *v1beta1.Metal3DataList, out *Metal3DataList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]Metal3Data)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metal3Data, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Metal3Data_To_v1alpha5_Metal3Data(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1368,14 +1390,10 @@ func Convert_v1alpha5_Metal3DataStatus_To_v1beta1_Metal3DataStatus(in *Metal3Dat
 func autoConvert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(in *v1beta1.Metal3DataStatus, out *Metal3DataStatus, s conversion.Scope) error {
 	out.Ready = in.Ready
 	out.ErrorMessage = (*string)(unsafe.Pointer(in.ErrorMessage))
+	// WARNING: in.TemplateGeneration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus is an autogenerated conversion function.
-func Convert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(in *v1beta1.Metal3DataStatus, out *Metal3DataStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3DataStatus_To_v1alpha5_Metal3DataStatus(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3DataTemplate_To_v1beta1_Metal3DataTemplate(in *Metal3DataTemplate, out *v1beta1.Metal3DataTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha5_Metal3DataTemplateSpec_To_v1beta1_Metal3DataTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1410,7 +1428,17 @@ func Convert_v1beta1_Metal3DataTemplate_To_v1alpha5_Metal3DataTemplate(in *v1bet
 
 func autoConvert_v1alpha5_Metal3DataTemplateList_To_v1beta1_Metal3DataTemplateList(in *Metal3DataTemplateList, out *v1beta1.Metal3DataTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta1.Metal3DataTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.Metal3DataTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_Metal3DataTemplate_To_v1beta1_Metal3DataTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1421,7 +1449,17 @@ func Convert_v1alpha5_Metal3DataTemplateList_To_v1beta1_Metal3DataTemplateList(i
 
 func autoConvert_v1beta1_Metal3DataTemplateList_To_v1alpha5_Metal3DataTemplateList(in *v1beta1.Metal3DataTemplateList, out *Metal3DataTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]Metal3DataTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metal3DataTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Metal3DataTemplate_To_v1alpha5_Metal3DataTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1433,8 +1471,24 @@ func Convert_v1beta1_Metal3DataTemplateList_To_v1alpha5_Metal3DataTemplateList(i
 func autoConvert_v1alpha5_Metal3DataTemplateSpec_To_v1beta1_Metal3DataTemplateSpec(in *Metal3DataTemplateSpec, out *v1beta1.Metal3DataTemplateSpec, s conversion.Scope) error {
 	out.ClusterName = in.ClusterName
 	out.TemplateReference = in.TemplateReference
-	out.MetaData = (*v1beta1.MetaData)(unsafe.Pointer(in.MetaData))
-	out.NetworkData = (*v1beta1.NetworkData)(unsafe.Pointer(in.NetworkData))
+	if in.MetaData != nil {
+		in, out := &in.MetaData, &out.MetaData
+		*out = new(v1beta1.MetaData)
+		if err := Convert_v1alpha5_MetaData_To_v1beta1_MetaData(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MetaData = nil
+	}
+	if in.NetworkData != nil {
+		in, out := &in.NetworkData, &out.NetworkData
+		*out = new(v1beta1.NetworkData)
+		if err := Convert_v1alpha5_NetworkData_To_v1beta1_NetworkData(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkData = nil
+	}
 	return nil
 }
 
@@ -1446,16 +1500,30 @@ func Convert_v1alpha5_Metal3DataTemplateSpec_To_v1beta1_Metal3DataTemplateSpec(i
 func autoConvert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(in *v1beta1.Metal3DataTemplateSpec, out *Metal3DataTemplateSpec, s conversion.Scope) error {
 	out.ClusterName = in.ClusterName
 	out.TemplateReference = in.TemplateReference
-	out.MetaData = (*MetaData)(unsafe.Pointer(in.MetaData))
-	out.NetworkData = (*NetworkData)(unsafe.Pointer(in.NetworkData))
+	if in.MetaData != nil {
+		in, out := &in.MetaData, &out.MetaData
+		*out = new(MetaData)
+		if err := Convert_v1beta1_MetaData_To_v1alpha5_MetaData(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MetaData = nil
+	}
+	if in.NetworkData != nil {
+		in, out := &in.NetworkData, &out.NetworkData
+		*out = new(NetworkData)
+		if err := Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NetworkData = nil
+	}
+	// WARNING: in.Mutable requires manual conversion: does not exist in peer-type
+	// WARNING: in.RerenderBatchSize requires manual conversion: does not exist in peer-type
+	// WARNING: in.IndexAllocation requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec is an autogenerated conversion function.
-func Convert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(in *v1beta1.Metal3DataTemplateSpec, out *Metal3DataTemplateSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3DataTemplateSpec_To_v1alpha5_Metal3DataTemplateSpec(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3DataTemplateStatus_To_v1beta1_Metal3DataTemplateStatus(in *Metal3DataTemplateStatus, out *v1beta1.Metal3DataTemplateStatus, s conversion.Scope) error {
 	out.LastUpdated = (*v1.Time)(unsafe.Pointer(in.LastUpdated))
 	out.Indexes = *(*map[string]int)(unsafe.Pointer(&in.Indexes))
@@ -1470,14 +1538,10 @@ func Convert_v1alpha5_Metal3DataTemplateStatus_To_v1beta1_Metal3DataTemplateStat
 func autoConvert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(in *v1beta1.Metal3DataTemplateStatus, out *Metal3DataTemplateStatus, s conversion.Scope) error {
 	out.LastUpdated = (*v1.Time)(unsafe.Pointer(in.LastUpdated))
 	out.Indexes = *(*map[string]int)(unsafe.Pointer(&in.Indexes))
+	// WARNING: in.HostIndexes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus is an autogenerated conversion function.
-func Convert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(in *v1beta1.Metal3DataTemplateStatus, out *Metal3DataTemplateStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3DataTemplateStatus_To_v1alpha5_Metal3DataTemplateStatus(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3Machine_To_v1beta1_Metal3Machine(in *Metal3Machine, out *v1beta1.Metal3Machine, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha5_Metal3MachineSpec_To_v1beta1_Metal3MachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1582,18 +1646,16 @@ func autoConvert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(in *v1b
 	if err := Convert_v1beta1_HostSelector_To_v1alpha5_HostSelector(&in.HostSelector, &out.HostSelector, s); err != nil {
 		return err
 	}
+	// WARNING: in.HostSelectionPolicy requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureDomain requires manual conversion: does not exist in peer-type
 	out.DataTemplate = (*corev1.ObjectReference)(unsafe.Pointer(in.DataTemplate))
 	out.MetaData = (*corev1.SecretReference)(unsafe.Pointer(in.MetaData))
 	out.NetworkData = (*corev1.SecretReference)(unsafe.Pointer(in.NetworkData))
 	out.AutomatedCleaningMode = (*string)(unsafe.Pointer(in.AutomatedCleaningMode))
+	// WARNING: in.ImageUpgradeStrategy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec is an autogenerated conversion function.
-func Convert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(in *v1beta1.Metal3MachineSpec, out *Metal3MachineSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3MachineSpec_To_v1alpha5_Metal3MachineSpec(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3MachineStatus_To_v1beta1_Metal3MachineStatus(in *Metal3MachineStatus, out *v1beta1.Metal3MachineStatus, s conversion.Scope) error {
 	out.LastUpdated = (*v1.Time)(unsafe.Pointer(in.LastUpdated))
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
@@ -1656,7 +1718,17 @@ func Convert_v1beta1_Metal3MachineTemplate_To_v1alpha5_Metal3MachineTemplate(in
 
 func autoConvert_v1alpha5_Metal3MachineTemplateList_To_v1beta1_Metal3MachineTemplateList(in *Metal3MachineTemplateList, out *v1beta1.Metal3MachineTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta1.Metal3MachineTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.Metal3MachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_Metal3MachineTemplate_To_v1beta1_Metal3MachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1667,7 +1739,17 @@ func Convert_v1alpha5_Metal3MachineTemplateList_To_v1beta1_Metal3MachineTemplate
 
 func autoConvert_v1beta1_Metal3MachineTemplateList_To_v1alpha5_Metal3MachineTemplateList(in *v1beta1.Metal3MachineTemplateList, out *Metal3MachineTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]Metal3MachineTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metal3MachineTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Metal3MachineTemplate_To_v1alpha5_Metal3MachineTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1760,7 +1842,17 @@ func Convert_v1beta1_Metal3Remediation_To_v1alpha5_Metal3Remediation(in *v1beta1
 
 func autoConvert_v1alpha5_Metal3RemediationList_To_v1beta1_Metal3RemediationList(in *Metal3RemediationList, out *v1beta1.Metal3RemediationList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta1.Metal3Remediation)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.Metal3Remediation, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_Metal3Remediation_To_v1beta1_Metal3Remediation(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1771,7 +1863,17 @@ func Convert_v1alpha5_Metal3RemediationList_To_v1beta1_Metal3RemediationList(in
 
 func autoConvert_v1beta1_Metal3RemediationList_To_v1alpha5_Metal3RemediationList(in *v1beta1.Metal3RemediationList, out *Metal3RemediationList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]Metal3Remediation)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metal3Remediation, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Metal3Remediation_To_v1alpha5_Metal3Remediation(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1781,7 +1883,15 @@ func Convert_v1beta1_Metal3RemediationList_To_v1alpha5_Metal3RemediationList(in
 }
 
 func autoConvert_v1alpha5_Metal3RemediationSpec_To_v1beta1_Metal3RemediationSpec(in *Metal3RemediationSpec, out *v1beta1.Metal3RemediationSpec, s conversion.Scope) error {
-	out.Strategy = (*v1beta1.RemediationStrategy)(unsafe.Pointer(in.Strategy))
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(v1beta1.RemediationStrategy)
+		if err := Convert_v1alpha5_RemediationStrategy_To_v1beta1_RemediationStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Strategy = nil
+	}
 	return nil
 }
 
@@ -1791,15 +1901,19 @@ func Convert_v1alpha5_Metal3RemediationSpec_To_v1beta1_Metal3RemediationSpec(in
 }
 
 func autoConvert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(in *v1beta1.Metal3RemediationSpec, out *Metal3RemediationSpec, s conversion.Scope) error {
-	out.Strategy = (*RemediationStrategy)(unsafe.Pointer(in.Strategy))
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(RemediationStrategy)
+		if err := Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Strategy = nil
+	}
+	// WARNING: in.NodeDrain requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec is an autogenerated conversion function.
-func Convert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(in *v1beta1.Metal3RemediationSpec, out *Metal3RemediationSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3RemediationSpec_To_v1alpha5_Metal3RemediationSpec(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3RemediationStatus_To_v1beta1_Metal3RemediationStatus(in *Metal3RemediationStatus, out *v1beta1.Metal3RemediationStatus, s conversion.Scope) error {
 	out.Phase = in.Phase
 	out.RetryCount = in.RetryCount
@@ -1816,14 +1930,12 @@ func autoConvert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationSt
 	out.Phase = in.Phase
 	out.RetryCount = in.RetryCount
 	out.LastRemediated = (*v1.Time)(unsafe.Pointer(in.LastRemediated))
+	// WARNING: in.CurrentStep requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeDrainStartTime requires manual conversion: does not exist in peer-type
+	// WARNING: in.History requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus is an autogenerated conversion function.
-func Convert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(in *v1beta1.Metal3RemediationStatus, out *Metal3RemediationStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_Metal3RemediationStatus_To_v1alpha5_Metal3RemediationStatus(in, out, s)
-}
-
 func autoConvert_v1alpha5_Metal3RemediationTemplate_To_v1beta1_Metal3RemediationTemplate(in *Metal3RemediationTemplate, out *v1beta1.Metal3RemediationTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha5_Metal3RemediationTemplateSpec_To_v1beta1_Metal3RemediationTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1858,7 +1970,17 @@ func Convert_v1beta1_Metal3RemediationTemplate_To_v1alpha5_Metal3RemediationTemp
 
 func autoConvert_v1alpha5_Metal3RemediationTemplateList_To_v1beta1_Metal3RemediationTemplateList(in *Metal3RemediationTemplateList, out *v1beta1.Metal3RemediationTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1beta1.Metal3RemediationTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1beta1.Metal3RemediationTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_Metal3RemediationTemplate_To_v1beta1_Metal3RemediationTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1869,7 +1991,17 @@ func Convert_v1alpha5_Metal3RemediationTemplateList_To_v1beta1_Metal3Remediation
 
 func autoConvert_v1beta1_Metal3RemediationTemplateList_To_v1alpha5_Metal3RemediationTemplateList(in *v1beta1.Metal3RemediationTemplateList, out *Metal3RemediationTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]Metal3RemediationTemplate)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Metal3RemediationTemplate, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Metal3RemediationTemplate_To_v1alpha5_Metal3RemediationTemplate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1969,6 +2101,7 @@ func Convert_v1alpha5_NetworkData_To_v1beta1_NetworkData(in *NetworkData, out *v
 }
 
 func autoConvert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in *v1beta1.NetworkData, out *NetworkData, s conversion.Scope) error {
+	// WARNING: in.Format requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(&in.Links, &out.Links, s); err != nil {
 		return err
 	}
@@ -1981,16 +2114,21 @@ func autoConvert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in *v1beta1.Network
 	return nil
 }
 
-// Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData is an autogenerated conversion function.
-func Convert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in *v1beta1.NetworkData, out *NetworkData, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkData_To_v1alpha5_NetworkData(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataIPv4_To_v1beta1_NetworkDataIPv4(in *NetworkDataIPv4, out *v1beta1.NetworkDataIPv4, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Link = in.Link
 	out.IPAddressFromIPPool = in.IPAddressFromIPPool
-	out.Routes = *(*[]v1beta1.NetworkDataRoutev4)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1beta1.NetworkDataRoutev4, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataRoutev4_To_v1beta1_NetworkDataRoutev4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2003,19 +2141,35 @@ func autoConvert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(in *v1beta1
 	out.ID = in.ID
 	out.Link = in.Link
 	out.IPAddressFromIPPool = in.IPAddressFromIPPool
-	out.Routes = *(*[]NetworkDataRoutev4)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NetworkDataRoutev4, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	// WARNING: in.Rules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4 is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(in *v1beta1.NetworkDataIPv4, out *NetworkDataIPv4, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataIPv4DHCP_To_v1beta1_NetworkDataIPv4DHCP(in *NetworkDataIPv4DHCP, out *v1beta1.NetworkDataIPv4DHCP, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Link = in.Link
-	out.Routes = *(*[]v1beta1.NetworkDataRoutev4)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1beta1.NetworkDataRoutev4, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataRoutev4_To_v1beta1_NetworkDataRoutev4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2027,7 +2181,17 @@ func Convert_v1alpha5_NetworkDataIPv4DHCP_To_v1beta1_NetworkDataIPv4DHCP(in *Net
 func autoConvert_v1beta1_NetworkDataIPv4DHCP_To_v1alpha5_NetworkDataIPv4DHCP(in *v1beta1.NetworkDataIPv4DHCP, out *NetworkDataIPv4DHCP, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Link = in.Link
-	out.Routes = *(*[]NetworkDataRoutev4)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NetworkDataRoutev4, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2040,7 +2204,17 @@ func autoConvert_v1alpha5_NetworkDataIPv6_To_v1beta1_NetworkDataIPv6(in *Network
 	out.ID = in.ID
 	out.Link = in.Link
 	out.IPAddressFromIPPool = in.IPAddressFromIPPool
-	out.Routes = *(*[]v1beta1.NetworkDataRoutev6)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1beta1.NetworkDataRoutev6, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataRoutev6_To_v1beta1_NetworkDataRoutev6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2053,19 +2227,35 @@ func autoConvert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(in *v1beta1
 	out.ID = in.ID
 	out.Link = in.Link
 	out.IPAddressFromIPPool = in.IPAddressFromIPPool
-	out.Routes = *(*[]NetworkDataRoutev6)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NetworkDataRoutev6, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
+	// WARNING: in.Rules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6 is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(in *v1beta1.NetworkDataIPv6, out *NetworkDataIPv6, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataIPv6DHCP_To_v1beta1_NetworkDataIPv6DHCP(in *NetworkDataIPv6DHCP, out *v1beta1.NetworkDataIPv6DHCP, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Link = in.Link
-	out.Routes = *(*[]v1beta1.NetworkDataRoutev6)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]v1beta1.NetworkDataRoutev6, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataRoutev6_To_v1beta1_NetworkDataRoutev6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2077,7 +2267,17 @@ func Convert_v1alpha5_NetworkDataIPv6DHCP_To_v1beta1_NetworkDataIPv6DHCP(in *Net
 func autoConvert_v1beta1_NetworkDataIPv6DHCP_To_v1alpha5_NetworkDataIPv6DHCP(in *v1beta1.NetworkDataIPv6DHCP, out *NetworkDataIPv6DHCP, s conversion.Scope) error {
 	out.ID = in.ID
 	out.Link = in.Link
-	out.Routes = *(*[]NetworkDataRoutev6)(unsafe.Pointer(&in.Routes))
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]NetworkDataRoutev6, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Routes = nil
+	}
 	return nil
 }
 
@@ -2088,7 +2288,17 @@ func Convert_v1beta1_NetworkDataIPv6DHCP_To_v1alpha5_NetworkDataIPv6DHCP(in *v1b
 
 func autoConvert_v1alpha5_NetworkDataLink_To_v1beta1_NetworkDataLink(in *NetworkDataLink, out *v1beta1.NetworkDataLink, s conversion.Scope) error {
 	out.Ethernets = *(*[]v1beta1.NetworkDataLinkEthernet)(unsafe.Pointer(&in.Ethernets))
-	out.Bonds = *(*[]v1beta1.NetworkDataLinkBond)(unsafe.Pointer(&in.Bonds))
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]v1beta1.NetworkDataLinkBond, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataLinkBond_To_v1beta1_NetworkDataLinkBond(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Bonds = nil
+	}
 	out.Vlans = *(*[]v1beta1.NetworkDataLinkVlan)(unsafe.Pointer(&in.Vlans))
 	return nil
 }
@@ -2100,16 +2310,22 @@ func Convert_v1alpha5_NetworkDataLink_To_v1beta1_NetworkDataLink(in *NetworkData
 
 func autoConvert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(in *v1beta1.NetworkDataLink, out *NetworkDataLink, s conversion.Scope) error {
 	out.Ethernets = *(*[]NetworkDataLinkEthernet)(unsafe.Pointer(&in.Ethernets))
-	out.Bonds = *(*[]NetworkDataLinkBond)(unsafe.Pointer(&in.Bonds))
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]NetworkDataLinkBond, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Bonds = nil
+	}
 	out.Vlans = *(*[]NetworkDataLinkVlan)(unsafe.Pointer(&in.Vlans))
+	// WARNING: in.EthernetsFromHost requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(in *v1beta1.NetworkDataLink, out *NetworkDataLink, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataLink_To_v1alpha5_NetworkDataLink(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataLinkBond_To_v1beta1_NetworkDataLinkBond(in *NetworkDataLinkBond, out *v1beta1.NetworkDataLinkBond, s conversion.Scope) error {
 	out.BondMode = in.BondMode
 	out.Id = in.Id
@@ -2130,14 +2346,10 @@ func autoConvert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(in
 	out.MTU = in.MTU
 	out.MACAddress = (*NetworkLinkEthernetMac)(unsafe.Pointer(in.MACAddress))
 	out.BondLinks = *(*[]string)(unsafe.Pointer(&in.BondLinks))
+	// WARNING: in.BondOptions requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(in *v1beta1.NetworkDataLinkBond, out *NetworkDataLinkBond, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataLinkBond_To_v1alpha5_NetworkDataLinkBond(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataLinkEthernet_To_v1beta1_NetworkDataLinkEthernet(in *NetworkDataLinkEthernet, out *v1beta1.NetworkDataLinkEthernet, s conversion.Scope) error {
 	out.Type = in.Type
 	out.Id = in.Id
@@ -2193,11 +2405,61 @@ func Convert_v1beta1_NetworkDataLinkVlan_To_v1alpha5_NetworkDataLinkVlan(in *v1b
 }
 
 func autoConvert_v1alpha5_NetworkDataNetwork_To_v1beta1_NetworkDataNetwork(in *NetworkDataNetwork, out *v1beta1.NetworkDataNetwork, s conversion.Scope) error {
-	out.IPv4 = *(*[]v1beta1.NetworkDataIPv4)(unsafe.Pointer(&in.IPv4))
-	out.IPv6 = *(*[]v1beta1.NetworkDataIPv6)(unsafe.Pointer(&in.IPv6))
-	out.IPv4DHCP = *(*[]v1beta1.NetworkDataIPv4DHCP)(unsafe.Pointer(&in.IPv4DHCP))
-	out.IPv6DHCP = *(*[]v1beta1.NetworkDataIPv6DHCP)(unsafe.Pointer(&in.IPv6DHCP))
-	out.IPv6SLAAC = *(*[]v1beta1.NetworkDataIPv6DHCP)(unsafe.Pointer(&in.IPv6SLAAC))
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = make([]v1beta1.NetworkDataIPv4, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataIPv4_To_v1beta1_NetworkDataIPv4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv4 = nil
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = make([]v1beta1.NetworkDataIPv6, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataIPv6_To_v1beta1_NetworkDataIPv6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6 = nil
+	}
+	if in.IPv4DHCP != nil {
+		in, out := &in.IPv4DHCP, &out.IPv4DHCP
+		*out = make([]v1beta1.NetworkDataIPv4DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataIPv4DHCP_To_v1beta1_NetworkDataIPv4DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv4DHCP = nil
+	}
+	if in.IPv6DHCP != nil {
+		in, out := &in.IPv6DHCP, &out.IPv6DHCP
+		*out = make([]v1beta1.NetworkDataIPv6DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataIPv6DHCP_To_v1beta1_NetworkDataIPv6DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6DHCP = nil
+	}
+	if in.IPv6SLAAC != nil {
+		in, out := &in.IPv6SLAAC, &out.IPv6SLAAC
+		*out = make([]v1beta1.NetworkDataIPv6DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha5_NetworkDataIPv6DHCP_To_v1beta1_NetworkDataIPv6DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6SLAAC = nil
+	}
 	return nil
 }
 
@@ -2207,11 +2469,61 @@ func Convert_v1alpha5_NetworkDataNetwork_To_v1beta1_NetworkDataNetwork(in *Netwo
 }
 
 func autoConvert_v1beta1_NetworkDataNetwork_To_v1alpha5_NetworkDataNetwork(in *v1beta1.NetworkDataNetwork, out *NetworkDataNetwork, s conversion.Scope) error {
-	out.IPv4 = *(*[]NetworkDataIPv4)(unsafe.Pointer(&in.IPv4))
-	out.IPv6 = *(*[]NetworkDataIPv6)(unsafe.Pointer(&in.IPv6))
-	out.IPv4DHCP = *(*[]NetworkDataIPv4DHCP)(unsafe.Pointer(&in.IPv4DHCP))
-	out.IPv6DHCP = *(*[]NetworkDataIPv6DHCP)(unsafe.Pointer(&in.IPv6DHCP))
-	out.IPv6SLAAC = *(*[]NetworkDataIPv6DHCP)(unsafe.Pointer(&in.IPv6SLAAC))
+	if in.IPv4 != nil {
+		in, out := &in.IPv4, &out.IPv4
+		*out = make([]NetworkDataIPv4, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataIPv4_To_v1alpha5_NetworkDataIPv4(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv4 = nil
+	}
+	if in.IPv6 != nil {
+		in, out := &in.IPv6, &out.IPv6
+		*out = make([]NetworkDataIPv6, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataIPv6_To_v1alpha5_NetworkDataIPv6(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6 = nil
+	}
+	if in.IPv4DHCP != nil {
+		in, out := &in.IPv4DHCP, &out.IPv4DHCP
+		*out = make([]NetworkDataIPv4DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataIPv4DHCP_To_v1alpha5_NetworkDataIPv4DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv4DHCP = nil
+	}
+	if in.IPv6DHCP != nil {
+		in, out := &in.IPv6DHCP, &out.IPv6DHCP
+		*out = make([]NetworkDataIPv6DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataIPv6DHCP_To_v1alpha5_NetworkDataIPv6DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6DHCP = nil
+	}
+	if in.IPv6SLAAC != nil {
+		in, out := &in.IPv6SLAAC, &out.IPv6SLAAC
+		*out = make([]NetworkDataIPv6DHCP, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_NetworkDataIPv6DHCP_To_v1alpha5_NetworkDataIPv6DHCP(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.IPv6SLAAC = nil
+	}
 	return nil
 }
 
@@ -2246,14 +2558,11 @@ func autoConvert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(in *v
 	if err := Convert_v1beta1_NetworkDataServicev4_To_v1alpha5_NetworkDataServicev4(&in.Services, &out.Services, s); err != nil {
 		return err
 	}
+	// WARNING: in.Metric requires manual conversion: does not exist in peer-type
+	// WARNING: in.Table requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4 is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(in *v1beta1.NetworkDataRoutev4, out *NetworkDataRoutev4, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataRoutev4_To_v1alpha5_NetworkDataRoutev4(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataRoutev6_To_v1beta1_NetworkDataRoutev6(in *NetworkDataRoutev6, out *v1beta1.NetworkDataRoutev6, s conversion.Scope) error {
 	out.Network = v1alpha1.IPAddressv6Str(in.Network)
 	out.Prefix = in.Prefix
@@ -2280,14 +2589,11 @@ func autoConvert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(in *v
 	if err := Convert_v1beta1_NetworkDataServicev6_To_v1alpha5_NetworkDataServicev6(&in.Services, &out.Services, s); err != nil {
 		return err
 	}
+	// WARNING: in.Metric requires manual conversion: does not exist in peer-type
+	// WARNING: in.Table requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6 is an autogenerated conversion function.
-func Convert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(in *v1beta1.NetworkDataRoutev6, out *NetworkDataRoutev6, s conversion.Scope) error {
-	return autoConvert_v1beta1_NetworkDataRoutev6_To_v1alpha5_NetworkDataRoutev6(in, out, s)
-}
-
 func autoConvert_v1alpha5_NetworkDataService_To_v1beta1_NetworkDataService(in *NetworkDataService, out *v1beta1.NetworkDataService, s conversion.Scope) error {
 	out.DNS = *(*[]v1alpha1.IPAddressStr)(unsafe.Pointer(&in.DNS))
 	out.DNSFromIPPool = (*string)(unsafe.Pointer(in.DNSFromIPPool))
@@ -2436,10 +2742,6 @@ func autoConvert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(in
 	out.Type = RemediationType(in.Type)
 	out.RetryLimit = in.RetryLimit
 	out.Timeout = (*v1.Duration)(unsafe.Pointer(in.Timeout))
+	// WARNING: in.Steps requires manual conversion: does not exist in peer-type
 	return nil
 }
-
-// Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy is an autogenerated conversion function.
-func Convert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(in *v1beta1.RemediationStrategy, out *RemediationStrategy, s conversion.Scope) error {
-	return autoConvert_v1beta1_RemediationStrategy_To_v1alpha5_RemediationStrategy(in, out, s)
-}
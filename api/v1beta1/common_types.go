@@ -59,6 +59,55 @@ type HostSelectorRequirement struct {
 	Values   []string           `json:"values"`
 }
 
+// HostSelectionStrategy is the name of a policy used to pick one
+// BareMetalHost among all the hosts matching the HostSelector.
+type HostSelectionStrategy string
+
+const (
+	// HostSelectionStrategyRandom picks a random host. This is the default.
+	HostSelectionStrategyRandom HostSelectionStrategy = "Random"
+	// HostSelectionStrategySpread prefers hosts whose TopologyKey label value
+	// is the least used by the machines of the cluster.
+	HostSelectionStrategySpread HostSelectionStrategy = "Spread"
+	// HostSelectionStrategyBinPack prefers hosts whose hardware profile is
+	// the most used by the machines of the cluster.
+	HostSelectionStrategyBinPack HostSelectionStrategy = "BinPack"
+	// HostSelectionStrategyMostMemory prefers hosts with the most RAM.
+	HostSelectionStrategyMostMemory HostSelectionStrategy = "MostMemory"
+	// HostSelectionStrategyMostCPU prefers hosts with the most CPUs.
+	HostSelectionStrategyMostCPU HostSelectionStrategy = "MostCPU"
+	// HostSelectionStrategyLeastRecentlyUsed prefers hosts that were
+	// deprovisioned the longest time ago, or never provisioned.
+	HostSelectionStrategyLeastRecentlyUsed HostSelectionStrategy = "LeastRecentlyUsed"
+)
+
+// HostSelectionPolicy defines how a BareMetalHost is picked among the
+// available hosts matching the HostSelector.
+type HostSelectionPolicy struct {
+	// Strategy is the policy used to rank the available hosts. All strategies
+	// except Random are deterministic, ties being broken by host name.
+	// +kubebuilder:validation:Enum=Random;Spread;BinPack;MostMemory;MostCPU;LeastRecentlyUsed
+	// +kubebuilder:default:=Random
+	// +optional
+	Strategy HostSelectionStrategy `json:"strategy,omitempty"`
+
+	// TopologyKey is the BareMetalHost label key used by the Spread
+	// strategy, for example a rack or room label.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+}
+
+// Validate performs validation on [HostSelectionPolicy], returning a list of field errors using the provided base path.
+// It is intended to be used in the validation webhooks of resources containing [HostSelectionPolicy].
+func (p *HostSelectionPolicy) Validate(base field.Path) field.ErrorList {
+	var errors field.ErrorList
+
+	if p.Strategy == HostSelectionStrategySpread && p.TopologyKey == "" {
+		errors = append(errors, field.Required(base.Child("TopologyKey"), "cannot be empty with the Spread strategy"))
+	}
+	return errors
+}
+
 // Image holds the details of an image to use during provisioning.
 type Image struct {
 	// URL is a location of an image to deploy.
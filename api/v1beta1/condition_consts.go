@@ -30,6 +30,14 @@ const (
 	// occurred. The `Message` field of the Condition should be consluted for
 	// details on the failure.
 	InternalFailureReason = "InternalFailureOccured"
+
+	// RemediationAllowedCondition reports whether the remediation budget of
+	// the cluster allows the Metal3Remediation controller to start
+	// remediating more Machines.
+	RemediationAllowedCondition clusterv1.ConditionType = "RemediationAllowed"
+	// RemediationBudgetExceededReason is used when new remediations wait
+	// because the remediation budget of the cluster is exceeded.
+	RemediationBudgetExceededReason = "RemediationBudgetExceeded"
 )
 
 // Metal3Machine Conditions and Reasons.
@@ -51,4 +59,24 @@ const (
 	MissingBMHReason = "MissingBMH"
 	// Could not set the ProviderID on the target cluster's Node object.
 	SettingProviderIDOnNodeFailedReason = "SettingProviderIDOnNodeFailed"
+
+	// ImageUpgradedCondition documents the reprovisioning of the BaremetalHost
+	// of a Metal3Machine with the new image of the Metal3Machine.
+	ImageUpgradedCondition clusterv1.ConditionType = "ImageUpgraded"
+	// DeprovisioningForImageUpgradeReason used while the BaremetalHost is
+	// deprovisioned before being provisioned with the new image.
+	DeprovisioningForImageUpgradeReason = "DeprovisioningForImageUpgrade"
+	// ProvisioningNewImageReason used while the BaremetalHost is provisioned
+	// with the new image.
+	ProvisioningNewImageReason = "ProvisioningNewImage"
+
+	// LabelSyncedCondition documents the synchronization of the labels,
+	// annotations and taints between the BaremetalHost of a Metal3Machine and
+	// its Node, as configured in the Metal3Cluster.
+	LabelSyncedCondition clusterv1.ConditionType = "LabelSynced"
+	// InvalidLabelSyncReason used when the label sync configuration of the
+	// Metal3Cluster is invalid.
+	InvalidLabelSyncReason = "InvalidLabelSync"
+	// LabelSyncFailedReason used when the synchronization with the Node failed.
+	LabelSyncFailedReason = "LabelSyncFailed"
 )
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -39,6 +40,104 @@ type Metal3ClusterSpec struct {
 	// If set to false, providerID is set on nodes by other entities and CAPM3 uses the value of the providerID on the m3m resource.
 	// +optional
 	NoCloudProvider bool `json:"noCloudProvider,omitempty"`
+	// FailureDomainLabel is the key of the BareMetalHost label holding the
+	// failure domain of the host, for example a rack or a room. When set, the
+	// failure domains of the cluster are populated from the values of this
+	// label and the machines are placed on hosts of their failure domain.
+	// +optional
+	FailureDomainLabel string `json:"failureDomainLabel,omitempty"`
+	// FailureDomains restricts the failure domains of the cluster to the given
+	// values of the FailureDomainLabel. If empty, all the values of the label
+	// found on the BareMetalHosts of the namespace are used, and all of them
+	// are suitable for control plane machines.
+	// +optional
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+	// RemediationBudget limits the number of Machines of the cluster
+	// remediated by the Metal3Remediation controller, so that a shared
+	// failure, for example of a switch or a PDU, does not lead to power
+	// cycling many hosts at once. When the budget is exceeded, new
+	// remediations wait and the RemediationAllowed condition is set to false.
+	// +optional
+	RemediationBudget *RemediationBudget `json:"remediationBudget,omitempty"`
+	// LabelSync defines which labels are synchronized between the
+	// BareMetalHosts and the Nodes of the workload cluster, and which
+	// BareMetalHost annotations and labels are synchronized onto the Nodes.
+	// +optional
+	LabelSync *LabelSync `json:"labelSync,omitempty"`
+}
+
+// LabelSyncDirection is the direction in which the labels are synchronized.
+type LabelSyncDirection string
+
+const (
+	// LabelSyncDirectionHostToNode copies the BareMetalHost labels to the
+	// Node. This is the default.
+	LabelSyncDirectionHostToNode LabelSyncDirection = "HostToNode"
+	// LabelSyncDirectionNodeToHost copies the Node labels to the
+	// BareMetalHost.
+	LabelSyncDirectionNodeToHost LabelSyncDirection = "NodeToHost"
+	// LabelSyncDirectionBoth copies the labels changed on either side since
+	// the last synchronization to the other side, the BareMetalHost winning
+	// when both sides changed.
+	LabelSyncDirectionBoth LabelSyncDirection = "Both"
+)
+
+// LabelSync defines how the labels are synchronized between the
+// BareMetalHosts and the Nodes, and how the BareMetalHost annotations and
+// labels are synchronized onto the Nodes.
+type LabelSync struct {
+	// Prefixes are the prefixes of the labels synchronized between the
+	// BareMetalHosts and the Nodes, in addition to the prefixes listed in the
+	// deprecated metal3.io/metal3-label-sync-prefixes annotation.
+	// +optional
+	Prefixes []string `json:"prefixes,omitempty"`
+	// Direction is the direction in which the labels with the Prefixes are
+	// synchronized.
+	// +kubebuilder:validation:Enum=HostToNode;NodeToHost;Both
+	// +kubebuilder:default:=HostToNode
+	// +optional
+	Direction LabelSyncDirection `json:"direction,omitempty"`
+	// Exclude lists the keys of the labels that are not synchronized, even
+	// though their prefix is.
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+	// AnnotationPrefixes are the prefixes of the BareMetalHost annotations
+	// copied to the Node. The Node annotations with those prefixes that the
+	// BareMetalHost does not have are removed.
+	// +optional
+	AnnotationPrefixes []string `json:"annotationPrefixes,omitempty"`
+	// Taints turn the BareMetalHost labels with a prefix into Node taints.
+	// +optional
+	Taints []TaintSync `json:"taints,omitempty"`
+}
+
+// TaintSync turns the BareMetalHost labels with a prefix into Node taints,
+// with the key and the value of the label. The Node taints with the prefix
+// and the effect that the BareMetalHost does not have are removed.
+type TaintSync struct {
+	// Prefix is the prefix of the BareMetalHost labels.
+	Prefix string `json:"prefix"`
+	// Effect is the effect of the taints.
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	Effect corev1.TaintEffect `json:"effect"`
+}
+
+// RemediationBudget limits the number of Machines of a cluster being
+// remediated.
+type RemediationBudget struct {
+	// MaxInFlight is the maximum number of remediations in progress at the
+	// same time.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
+	// MaxPerWindow is the maximum number of remediations started within
+	// Window.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPerWindow *int `json:"maxPerWindow,omitempty"`
+	// Window is the sliding time window used with MaxPerWindow.
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
 }
 
 // IsValid returns an error if the object is not valid, otherwise nil. The
@@ -56,6 +155,10 @@ func (s *Metal3ClusterSpec) IsValid() error {
 	if len(missing) > 0 {
 		return errors.Errorf("Missing fields from Spec: %v", missing)
 	}
+
+	if len(s.FailureDomains) > 0 && s.FailureDomainLabel == "" {
+		return errors.New("FailureDomainLabel must be set when FailureDomains are given")
+	}
 	return nil
 }
 
@@ -82,6 +185,19 @@ type Metal3ClusterStatus struct {
 	// metal3Cluster controller after creation.
 	// +optional
 	Ready bool `json:"ready"`
+	// FailureDomains is a list of failure domain objects synced from the
+	// BareMetalHosts labels.
+	// +optional
+	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
+	// RemediationStartTimes lists when the remediations of the cluster
+	// started within the window of the remediation budget.
+	// +optional
+	RemediationStartTimes []metav1.Time `json:"remediationStartTimes,omitempty"`
+	// RemediationsInFlight lists the Metal3Remediations of the cluster in
+	// progress, counted against the maximum in flight of the remediation
+	// budget.
+	// +optional
+	RemediationsInFlight []string `json:"remediationsInFlight,omitempty"`
 	// Conditions defines current service state of the Metal3Cluster.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
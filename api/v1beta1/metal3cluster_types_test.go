@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"testing"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestClusterSpecIsValid(t *testing.T) {
@@ -71,6 +73,19 @@ func TestClusterSpecIsValid(t *testing.T) {
 			ErrorExpected: true,
 			Name:          "Incorrect spec, no port",
 		},
+		{
+			Spec: Metal3ClusterSpec{
+				ControlPlaneEndpoint: APIEndpoint{
+					Host: "foo.bar",
+					Port: 6443,
+				},
+				FailureDomains: clusterv1.FailureDomains{
+					"rack-1": clusterv1.FailureDomainSpec{},
+				},
+			},
+			ErrorExpected: true,
+			Name:          "Incorrect spec, failure domains without label",
+		},
 	}
 
 	for _, tc := range cases {
@@ -16,6 +16,7 @@ package v1beta1
 import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -67,6 +68,125 @@ func (c *Metal3Cluster) validate() error {
 		)
 	}
 
+	if c.Spec.FailureDomainLabel != "" {
+		for _, msg := range validation.IsQualifiedName(c.Spec.FailureDomainLabel) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "failureDomainLabel"),
+					c.Spec.FailureDomainLabel,
+					msg,
+				),
+			)
+		}
+	} else if len(c.Spec.FailureDomains) > 0 {
+		allErrs = append(
+			allErrs,
+			field.Required(
+				field.NewPath("spec", "failureDomainLabel"),
+				"is required when failureDomains are set",
+			),
+		)
+	}
+
+	for name := range c.Spec.FailureDomains {
+		for _, msg := range validation.IsValidLabelValue(name) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "failureDomains").Key(name),
+					name,
+					msg,
+				),
+			)
+		}
+	}
+
+	if budget := c.Spec.RemediationBudget; budget != nil {
+		budgetPath := field.NewPath("spec", "remediationBudget")
+		if budget.MaxInFlight != nil && *budget.MaxInFlight < 1 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					budgetPath.Child("maxInFlight"),
+					*budget.MaxInFlight,
+					"must be greater than 0",
+				),
+			)
+		}
+		if budget.MaxPerWindow != nil && *budget.MaxPerWindow < 1 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					budgetPath.Child("maxPerWindow"),
+					*budget.MaxPerWindow,
+					"must be greater than 0",
+				),
+			)
+		}
+		if budget.MaxPerWindow != nil && (budget.Window == nil || budget.Window.Duration <= 0) {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					budgetPath.Child("window"),
+					"is required when maxPerWindow is set",
+				),
+			)
+		}
+	}
+
+	if labelSync := c.Spec.LabelSync; labelSync != nil {
+		labelSyncPath := field.NewPath("spec", "labelSync")
+		for i, prefix := range labelSync.Prefixes {
+			for _, msg := range validation.IsDNS1123Subdomain(prefix) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						labelSyncPath.Child("prefixes").Index(i),
+						prefix,
+						msg,
+					),
+				)
+			}
+		}
+		for i, key := range labelSync.Exclude {
+			for _, msg := range validation.IsQualifiedName(key) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						labelSyncPath.Child("exclude").Index(i),
+						key,
+						msg,
+					),
+				)
+			}
+		}
+		for i, prefix := range labelSync.AnnotationPrefixes {
+			for _, msg := range validation.IsDNS1123Subdomain(prefix) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						labelSyncPath.Child("annotationPrefixes").Index(i),
+						prefix,
+						msg,
+					),
+				)
+			}
+		}
+		for i, taint := range labelSync.Taints {
+			for _, msg := range validation.IsDNS1123Subdomain(taint.Prefix) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						labelSyncPath.Child("taints").Index(i).Child("prefix"),
+						taint.Prefix,
+						msg,
+					),
+				)
+			}
+		}
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -15,9 +15,12 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestMetal3ClusterDefault(t *testing.T) {
@@ -51,6 +54,63 @@ func TestMetal3ClusterValidation(t *testing.T) {
 	invalidHost := valid.DeepCopy()
 	invalidHost.Spec.ControlPlaneEndpoint.Host = ""
 
+	validFailureDomains := valid.DeepCopy()
+	validFailureDomains.Spec.FailureDomainLabel = "example.com/rack"
+	validFailureDomains.Spec.FailureDomains = clusterv1.FailureDomains{
+		"rack-1": clusterv1.FailureDomainSpec{ControlPlane: true},
+	}
+
+	missingFailureDomainLabel := validFailureDomains.DeepCopy()
+	missingFailureDomainLabel.Spec.FailureDomainLabel = ""
+
+	invalidFailureDomainLabel := validFailureDomains.DeepCopy()
+	invalidFailureDomainLabel.Spec.FailureDomainLabel = "-rack"
+
+	invalidFailureDomain := validFailureDomains.DeepCopy()
+	invalidFailureDomain.Spec.FailureDomains["rack 2"] = clusterv1.FailureDomainSpec{}
+
+	two := 2
+	zero := 0
+	validRemediationBudget := valid.DeepCopy()
+	validRemediationBudget.Spec.RemediationBudget = &RemediationBudget{
+		MaxInFlight:  &two,
+		MaxPerWindow: &two,
+		Window:       &metav1.Duration{Duration: time.Hour},
+	}
+
+	invalidMaxInFlight := validRemediationBudget.DeepCopy()
+	invalidMaxInFlight.Spec.RemediationBudget.MaxInFlight = &zero
+
+	invalidMaxPerWindow := validRemediationBudget.DeepCopy()
+	invalidMaxPerWindow.Spec.RemediationBudget.MaxPerWindow = &zero
+
+	missingWindow := validRemediationBudget.DeepCopy()
+	missingWindow.Spec.RemediationBudget.Window = nil
+
+	validLabelSync := valid.DeepCopy()
+	validLabelSync.Spec.LabelSync = &LabelSync{
+		Prefixes:           []string{"metal3.io"},
+		Direction:          LabelSyncDirectionBoth,
+		Exclude:            []string{"metal3.io/excluded"},
+		AnnotationPrefixes: []string{"hardware.example.com"},
+		Taints: []TaintSync{{
+			Prefix: "degraded.example.com",
+			Effect: corev1.TaintEffectNoSchedule,
+		}},
+	}
+
+	invalidPrefix := validLabelSync.DeepCopy()
+	invalidPrefix.Spec.LabelSync.Prefixes = []string{"metal3.io", "-metal3.io"}
+
+	invalidExclude := validLabelSync.DeepCopy()
+	invalidExclude.Spec.LabelSync.Exclude = []string{"metal3.io/"}
+
+	invalidAnnotationPrefix := validLabelSync.DeepCopy()
+	invalidAnnotationPrefix.Spec.LabelSync.AnnotationPrefixes = []string{"Hardware"}
+
+	invalidTaintPrefix := validLabelSync.DeepCopy()
+	invalidTaintPrefix.Spec.LabelSync.Taints[0].Prefix = "example.com/"
+
 	tests := []struct {
 		name      string
 		expectErr bool
@@ -66,6 +126,71 @@ func TestMetal3ClusterValidation(t *testing.T) {
 			expectErr: false,
 			c:         valid,
 		},
+		{
+			name:      "should succeed when failure domains are correct",
+			expectErr: false,
+			c:         validFailureDomains,
+		},
+		{
+			name:      "should return error when failure domains are set without label",
+			expectErr: true,
+			c:         missingFailureDomainLabel,
+		},
+		{
+			name:      "should return error when failure domain label is invalid",
+			expectErr: true,
+			c:         invalidFailureDomainLabel,
+		},
+		{
+			name:      "should return error when failure domain name is invalid",
+			expectErr: true,
+			c:         invalidFailureDomain,
+		},
+		{
+			name:      "should succeed when remediation budget is correct",
+			expectErr: false,
+			c:         validRemediationBudget,
+		},
+		{
+			name:      "should return error when remediation budget maxInFlight is 0",
+			expectErr: true,
+			c:         invalidMaxInFlight,
+		},
+		{
+			name:      "should return error when remediation budget maxPerWindow is 0",
+			expectErr: true,
+			c:         invalidMaxPerWindow,
+		},
+		{
+			name:      "should return error when remediation budget window is missing",
+			expectErr: true,
+			c:         missingWindow,
+		},
+		{
+			name:      "should succeed when label sync is correct",
+			expectErr: false,
+			c:         validLabelSync,
+		},
+		{
+			name:      "should return error when a label sync prefix is invalid",
+			expectErr: true,
+			c:         invalidPrefix,
+		},
+		{
+			name:      "should return error when an excluded label key is invalid",
+			expectErr: true,
+			c:         invalidExclude,
+		},
+		{
+			name:      "should return error when an annotation prefix is invalid",
+			expectErr: true,
+			c:         invalidAnnotationPrefix,
+		},
+		{
+			name:      "should return error when a taint prefix is invalid",
+			expectErr: true,
+			c:         invalidTaintPrefix,
+		},
 	}
 
 	for _, tt := range tests {
@@ -63,6 +63,11 @@ type Metal3DataStatus struct {
 	// ErrorMessage contains the error message
 	// +optional
 	ErrorMessage *string `json:"errorMessage,omitempty"`
+
+	// TemplateGeneration is the generation of the Metal3DataTemplate the
+	// secrets were last rendered from
+	// +optional
+	TemplateGeneration int64 `json:"templateGeneration,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
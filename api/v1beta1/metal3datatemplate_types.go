@@ -25,6 +25,55 @@ const (
 	// DataTemplateFinalizer allows Metal3DataTemplateReconciler to clean up resources
 	// associated with Metal3DataTemplate before removing it from the apiserver.
 	DataTemplateFinalizer = "metal3datatemplate.infrastructure.cluster.x-k8s.io"
+	// NetworkDataFormatOpenStack renders the network data as OpenStack
+	// network_data.json.
+	NetworkDataFormatOpenStack = "OpenStack"
+	// NetworkDataFormatNetplan renders the network data as netplan version 2
+	// YAML.
+	NetworkDataFormatNetplan = "Netplan"
+	// NetworkDataFormatNMState renders the network data as NMState YAML.
+	NetworkDataFormatNMState = "NMState"
+	// NetworkDataFormatIgnition renders the network data and the hostname as
+	// an Ignition v3 config, merged into the user data of the host.
+	NetworkDataFormatIgnition = "Ignition"
+	// HardwareDetailsCPUArch is the CPU architecture of the BareMetalHost.
+	HardwareDetailsCPUArch = "cpuArch"
+	// HardwareDetailsCPUModel is the CPU model of the BareMetalHost.
+	HardwareDetailsCPUModel = "cpuModel"
+	// HardwareDetailsCPUCount is the number of CPUs of the BareMetalHost.
+	HardwareDetailsCPUCount = "cpuCount"
+	// HardwareDetailsCPUClockMegahertz is the CPU clock speed of the
+	// BareMetalHost.
+	HardwareDetailsCPUClockMegahertz = "cpuClockMegahertz"
+	// HardwareDetailsRAMMebibytes is the memory size of the BareMetalHost.
+	HardwareDetailsRAMMebibytes = "ramMebibytes"
+	// HardwareDetailsSystemManufacturer is the system vendor of the
+	// BareMetalHost.
+	HardwareDetailsSystemManufacturer = "systemManufacturer"
+	// HardwareDetailsSystemProductName is the product name of the
+	// BareMetalHost.
+	HardwareDetailsSystemProductName = "systemProductName"
+	// HardwareDetailsSystemSerialNumber is the serial number of the
+	// BareMetalHost.
+	HardwareDetailsSystemSerialNumber = "systemSerialNumber"
+	// HardwareDetailsFirmwareVendor is the BIOS vendor of the BareMetalHost.
+	HardwareDetailsFirmwareVendor = "firmwareVendor"
+	// HardwareDetailsFirmwareVersion is the BIOS version of the BareMetalHost.
+	HardwareDetailsFirmwareVersion = "firmwareVersion"
+	// HardwareDetailsFirmwareDate is the BIOS release date of the
+	// BareMetalHost.
+	HardwareDetailsFirmwareDate = "firmwareDate"
+	// HardwareDetailsDisks are the disks of the BareMetalHost.
+	HardwareDetailsDisks = "disks"
+	// HardwareDetailsRootDeviceHints are the root device hints of the
+	// BareMetalHost.
+	HardwareDetailsRootDeviceHints = "rootDeviceHints"
+	// MetaDataSourcesUpdatePolicyImmutable keeps the rendered metadata secret
+	// when a ConfigMap or a Secret it is fetched from changes.
+	MetaDataSourcesUpdatePolicyImmutable = "Immutable"
+	// MetaDataSourcesUpdatePolicyRerender renders the metadata secret again
+	// when a ConfigMap or a Secret it is fetched from changes.
+	MetaDataSourcesUpdatePolicyRerender = "Rerender"
 )
 
 // MetaDataIndex contains the information to render the index.
@@ -78,6 +127,69 @@ type MetaDataString struct {
 	Value string `json:"value"`
 }
 
+// MetaDataFromHardwareDetails contains the information to render a value from
+// the hardware details collected during the inspection of the BareMetalHost.
+type MetaDataFromHardwareDetails struct {
+	// Key will be used as the key to set in the metadata map for cloud-init
+	Key string `json:"key"`
+
+	// +kubebuilder:validation:Enum=cpuArch;cpuModel;cpuCount;cpuClockMegahertz;ramMebibytes;systemManufacturer;systemProductName;systemSerialNumber;firmwareVendor;firmwareVersion;firmwareDate;disks;rootDeviceHints
+	// Field is the hardware detail to render. The disks are rendered as a list
+	// of maps with the name, model, serial number, size in bytes, type,
+	// rotational, WWN and HCTL of each disk, and the root device hints of the
+	// BareMetalHost as a map. The other fields are rendered as strings.
+	Field string `json:"field"`
+}
+
+// MetaDataFromConfigMap contains the information to fetch a value from the
+// data of a ConfigMap.
+type MetaDataFromConfigMap struct {
+	// Key will be used as the key to set in the metadata map for cloud-init
+	Key string `json:"key"`
+
+	// Name is the name of the ConfigMap, in the namespace of the
+	// Metal3DataTemplate.
+	Name string `json:"name"`
+
+	// DataKey is the key of the ConfigMap data to fetch.
+	DataKey string `json:"dataKey"`
+}
+
+// MetaDataFromSecret contains the information to fetch a value from the data
+// of a Secret.
+type MetaDataFromSecret struct {
+	// Key will be used as the key to set in the metadata map for cloud-init
+	Key string `json:"key"`
+
+	// Name is the name of the Secret, in the namespace of the
+	// Metal3DataTemplate.
+	Name string `json:"name"`
+
+	// DataKey is the key of the Secret data to fetch.
+	DataKey string `json:"dataKey"`
+}
+
+// MetaDataTemplate contains the information to render a value from a Go
+// text/template. The template is executed with the Metal3Machine
+// (.metal3Machine), the Machine (.machine), the BareMetalHost (.bareMetalHost),
+// the Cluster (.cluster), the hardware details of the BareMetalHost
+// (.hardwareDetails), the addresses from the IP pools, by pool name
+// (.ipAddresses), the index of the Metal3Data (.index) and its namespace
+// (.namespace). The objects are given as maps, using the field names of their
+// JSON serialization.
+type MetaDataTemplate struct {
+	// Key will be used as the key to set in the metadata map for cloud-init
+	Key string `json:"key"`
+
+	// Template is the Go text/template to render.
+	Template string `json:"template"`
+
+	// YAML defines whether the output of the template is parsed as YAML, to
+	// set a nested value in the metadata map.
+	// +optional
+	YAML bool `json:"yaml,omitempty"`
+}
+
 // MetaDataNamespace contains the information to render the namespace.
 type MetaDataNamespace struct {
 	// Key will be used as the key to set in the metadata map for cloud-init
@@ -182,6 +294,35 @@ type MetaData struct {
 	// Annotations
 	// +optional
 	FromAnnotations []MetaDataFromAnnotation `json:"fromAnnotations,omitempty"`
+
+	// Templates is the list of metadata items to be rendered from Go templates
+	// +optional
+	Templates []MetaDataTemplate `json:"templates,omitempty"`
+
+	// FromHardwareDetails is the list of metadata items to be rendered from
+	// the hardware details of the BareMetalHost.
+	// +optional
+	FromHardwareDetails []MetaDataFromHardwareDetails `json:"fromHardwareDetails,omitempty"`
+
+	// FromConfigMaps is the list of metadata items to be fetched from the data
+	// of ConfigMaps
+	// +optional
+	FromConfigMaps []MetaDataFromConfigMap `json:"fromConfigMaps,omitempty"`
+
+	// FromSecrets is the list of metadata items to be fetched from the data of
+	// Secrets
+	// +optional
+	FromSecrets []MetaDataFromSecret `json:"fromSecrets,omitempty"`
+
+	// SourcesUpdatePolicy defines whether the metadata secret is rendered
+	// again when a ConfigMap or a Secret it is fetched from changes. With
+	// Immutable, the default, the rendered secret is never modified, to be able
+	// to reprovision a node in the exact same state. With Rerender, the secret
+	// is rendered again and updated, and the new values are used the next time
+	// the BareMetalHost is provisioned.
+	// +kubebuilder:validation:Enum=Immutable;Rerender
+	// +optional
+	SourcesUpdatePolicy *string `json:"sourcesUpdatePolicy,omitempty"`
 }
 
 // NetworkLinkEthernetMac represents the Mac address content.
@@ -239,6 +380,44 @@ type NetworkDataLinkBond struct {
 
 	// BondLinks is the list of links that are part of the bond.
 	BondLinks []string `json:"bondLinks"`
+
+	// BondOptions contains the options of the bond.
+	// +optional
+	BondOptions *NetworkDataBondOptions `json:"bondOptions,omitempty"`
+}
+
+// NetworkDataBondOptions represents the options of a bond. The options only
+// apply to some of the bond modes.
+type NetworkDataBondOptions struct {
+	// +kubebuilder:validation:Minimum=0
+	// MIIMon is the MII link monitoring frequency in milliseconds
+	// +optional
+	MIIMon *int `json:"miimon,omitempty"`
+
+	// +kubebuilder:validation:Enum="layer2";"layer2+3";"layer3+4";"encap2+3";"encap3+4"
+	// XmitHashPolicy is the transmit hash policy, for the balance-xor,
+	// 802.3ad and balance-tlb modes. It can be one of
+	// layer2, layer2+3, layer3+4, encap2+3, encap3+4
+	// +optional
+	XmitHashPolicy *string `json:"xmitHashPolicy,omitempty"`
+
+	// +kubebuilder:validation:Enum="slow";"fast"
+	// LACPRate is the rate of the LACPDU requested to the link partner, for
+	// the 802.3ad mode. It can be one of slow, fast
+	// +optional
+	LACPRate *string `json:"lacpRate,omitempty"`
+
+	// Primary is the link of the bond that is active whenever it is
+	// available, for the active-backup, balance-tlb and balance-alb modes.
+	// It must be one of the BondLinks
+	// +optional
+	Primary *string `json:"primary,omitempty"`
+
+	// +kubebuilder:validation:Enum="none";"active";"follow"
+	// FailOverMac is the policy selecting the MAC address of the bond, for
+	// the active-backup mode. It can be one of none, active, follow
+	// +optional
+	FailOverMac *string `json:"failOverMac,omitempty"`
 }
 
 // NetworkDataLinkVlan represents a vlan link object.
@@ -278,6 +457,66 @@ type NetworkDataLink struct {
 	// Vlans contains a list of Vlan links
 	// +optional
 	Vlans []NetworkDataLinkVlan `json:"vlans,omitempty"`
+
+	// EthernetsFromHost contains a list of selectors generating Ethernet links
+	// from the NICs of the BareMetalHost hardware details
+	// +optional
+	EthernetsFromHost []NetworkDataLinkEthernetsFromHost `json:"ethernetsFromHost,omitempty"`
+}
+
+// NetworkDataLinkEthernetsFromHost generates an ethernet link for each NIC of
+// the BareMetalHost hardware details matching the selector. The NICs are
+// sorted by name and the links are named with the IDPrefix followed by the
+// index of the NIC, so that the IDs are the same for hosts with the same
+// number of matching NICs, whatever their names.
+type NetworkDataLinkEthernetsFromHost struct {
+	// +kubebuilder:validation:MinLength=1
+	// IDPrefix is the prefix of the IDs of the generated links
+	IDPrefix string `json:"idPrefix"`
+
+	// +kubebuilder:default=phy
+	// +kubebuilder:validation:Enum=bridge;dvs;hw_veb;hyperv;ovs;tap;vhostuser;vif;phy
+	// Type is the type of the generated ethernet links. It can be one of:
+	// bridge, dvs, hw_veb, hyperv, ovs, tap, vhostuser, vif, phy
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// +kubebuilder:default=1500
+	// +kubebuilder:validation:Maximum=9000
+	// MTU is the MTU of the generated links
+	// +optional
+	MTU int `json:"mtu,omitempty"`
+
+	// NICSelector selects the NICs of the BareMetalHost. All the NICs are
+	// selected if empty
+	// +optional
+	NICSelector NetworkDataNICSelector `json:"nicSelector,omitempty"`
+}
+
+// NetworkDataNICSelector selects NICs from the BareMetalHost hardware
+// details. A NIC is selected if it matches all the set fields.
+type NetworkDataNICSelector struct {
+	// NameRegex is a regular expression matching the name of the NIC
+	// +optional
+	NameRegex *string `json:"nameRegex,omitempty"`
+
+	// Vendor is the vendor ID of the NIC, e.g. "0x8086"
+	// +optional
+	Vendor *string `json:"vendor,omitempty"`
+
+	// Model is the vendor and product IDs of the NIC, e.g. "0x8086 0x1572"
+	// +optional
+	Model *string `json:"model,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// MinSpeedGbps is the minimum speed of the NIC in Gigabits per second
+	// +optional
+	MinSpeedGbps *int `json:"minSpeedGbps,omitempty"`
+
+	// PXE selects the NICs that are PXE bootable if true, or not PXE
+	// bootable if false
+	// +optional
+	PXE *bool `json:"pxe,omitempty"`
 }
 
 // NetworkDataService represents a service object.
@@ -356,6 +595,16 @@ type NetworkDataRoutev4 struct {
 	// Services is a list of IPv4 services
 	// +optional
 	Services NetworkDataServicev4 `json:"services,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// Metric is the metric of the route
+	// +optional
+	Metric *int `json:"metric,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// Table is the routing table of the route, the main table if unset
+	// +optional
+	Table *int `json:"table,omitempty"`
 }
 
 // NetworkDataRoutev6 represents an ipv6 route object.
@@ -374,6 +623,34 @@ type NetworkDataRoutev6 struct {
 	// Services is a list of IPv6 services
 	// +optional
 	Services NetworkDataServicev6 `json:"services,omitempty"`
+
+	// +kubebuilder:validation:Minimum=0
+	// Metric is the metric of the route
+	// +optional
+	Metric *int `json:"metric,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// Table is the routing table of the route, the main table if unset
+	// +optional
+	Table *int `json:"table,omitempty"`
+}
+
+// NetworkDataRule represents a routing policy rule, selecting the routing
+// table used for the packets from a source prefix.
+type NetworkDataRule struct {
+	// From is the source prefix of the packets in CIDR notation, the address
+	// rendered for the network as a /32 or /128 prefix if unset
+	// +optional
+	From *string `json:"from,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// Table is the routing table used for the packets matching the rule
+	Table int `json:"table"`
+
+	// +kubebuilder:validation:Minimum=0
+	// Priority is the priority of the rule, the lowest first
+	// +optional
+	Priority *int `json:"priority,omitempty"`
 }
 
 // NetworkDataIPv4 represents an ipv4 static network object.
@@ -391,6 +668,10 @@ type NetworkDataIPv4 struct {
 	// Routes contains a list of IPv4 routes
 	// +optional
 	Routes []NetworkDataRoutev4 `json:"routes,omitempty"`
+
+	// Rules contains a list of routing policy rules
+	// +optional
+	Rules []NetworkDataRule `json:"rules,omitempty"`
 }
 
 // NetworkDataIPv6 represents an ipv6 static network object.
@@ -408,6 +689,10 @@ type NetworkDataIPv6 struct {
 	// Routes contains a list of IPv6 routes
 	// +optional
 	Routes []NetworkDataRoutev6 `json:"routes,omitempty"`
+
+	// Rules contains a list of routing policy rules
+	// +optional
+	Rules []NetworkDataRule `json:"rules,omitempty"`
 }
 
 // NetworkDataIPv4DHCP represents an ipv4 DHCP network object.
@@ -464,6 +749,15 @@ type NetworkDataNetwork struct {
 
 // NetworkData represents a networkData object.
 type NetworkData struct {
+	// Format is the format of the rendered network data. OpenStack renders
+	// the network_data.json format, Netplan the netplan version 2 format and
+	// NMState the NMState format. Ignition renders an Ignition v3 config
+	// containing the systemd-networkd units and the hostname, that is merged
+	// into the user data of the host. Defaults to OpenStack.
+	// +kubebuilder:validation:Enum:=OpenStack;Netplan;NMState;Ignition
+	// +optional
+	Format *string `json:"format,omitempty"`
+
 	// Links is a structure containing lists of different types objects
 	// +optional
 	Links NetworkDataLink `json:"links,omitempty"`
@@ -498,6 +792,84 @@ type Metal3DataTemplateSpec struct {
 	// secret
 	// +optional
 	NetworkData *NetworkData `json:"networkData,omitempty"`
+
+	// Mutable allows the modification of the MetaData and NetworkData. When
+	// they change, the secrets of the existing Metal3Data are rendered again,
+	// RerenderBatchSize at a time, and the BareMetalHosts use them the next
+	// time they are provisioned.
+	// +optional
+	Mutable bool `json:"mutable,omitempty"`
+
+	// RerenderBatchSize is the maximum number of Metal3Data rendered again at
+	// a time when the template is mutable. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RerenderBatchSize *int `json:"rerenderBatchSize,omitempty"`
+
+	// IndexAllocation defines how the indexes of the Metal3Data are
+	// allocated. By default, the lowest free index is allocated.
+	// +optional
+	IndexAllocation *IndexAllocation `json:"indexAllocation,omitempty"`
+}
+
+// IndexAllocationPolicy is the name of a policy used to allocate the index of
+// a new Metal3Data.
+type IndexAllocationPolicy string
+
+const (
+	// IndexAllocationPolicyLowestFree allocates the lowest free index. This is
+	// the default.
+	IndexAllocationPolicyLowestFree IndexAllocationPolicy = "LowestFree"
+	// IndexAllocationPolicyHostSticky allocates to a BareMetalHost the index
+	// it was last allocated, if free. The indexes last allocated to the other
+	// BareMetalHosts are not allocated to a new BareMetalHost.
+	IndexAllocationPolicyHostSticky IndexAllocationPolicy = "HostSticky"
+)
+
+// IndexAllocation defines how the indexes of the Metal3Data are allocated.
+type IndexAllocation struct {
+	// Policy is the policy used to allocate the indexes that are not reserved.
+	// +kubebuilder:validation:Enum=LowestFree;HostSticky
+	// +kubebuilder:default:=LowestFree
+	// +optional
+	Policy IndexAllocationPolicy `json:"policy,omitempty"`
+
+	// Reservations pins indexes to BareMetalHosts. A reserved index is only
+	// allocated to its BareMetalHost, whatever the policy.
+	// +optional
+	Reservations []IndexReservation `json:"reservations,omitempty"`
+
+	// ReservedRanges are the ranges of indexes that are not allocated by the
+	// policy. They can only be allocated through Reservations.
+	// +optional
+	ReservedRanges []IndexRange `json:"reservedRanges,omitempty"`
+}
+
+// IndexReservation pins an index to a BareMetalHost.
+type IndexReservation struct {
+	// HostName is the name of the BareMetalHost.
+	// +kubebuilder:validation:MinLength=1
+	HostName string `json:"hostName"`
+
+	// Index is the index allocated to the BareMetalHost.
+	// +kubebuilder:validation:Minimum=0
+	Index int `json:"index"`
+}
+
+// IndexRange is a range of indexes.
+type IndexRange struct {
+	// Start is the first index of the range.
+	// +kubebuilder:validation:Minimum=0
+	Start int `json:"start"`
+
+	// End is the last index of the range, included.
+	// +kubebuilder:validation:Minimum=0
+	End int `json:"end"`
+}
+
+// Contains returns whether the index is in the range.
+func (r IndexRange) Contains(index int) bool {
+	return index >= r.Start && index <= r.End
 }
 
 // Metal3DataTemplateStatus defines the observed state of Metal3DataTemplate.
@@ -509,6 +881,11 @@ type Metal3DataTemplateStatus struct {
 	// Indexes contains the map of Metal3Machine and index used
 	// +optional
 	Indexes map[string]int `json:"indexes,omitempty"`
+
+	// HostIndexes contains the map of BareMetalHost name and the index last
+	// allocated to it, with the HostSticky index allocation policy
+	// +optional
+	HostIndexes map[string]int `json:"hostIndexes,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
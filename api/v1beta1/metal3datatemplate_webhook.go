@@ -14,7 +14,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"net"
 	"reflect"
+	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -52,6 +56,13 @@ func (c *Metal3DataTemplate) ValidateUpdate(old runtime.Object) error {
 		return apierrors.NewInternalError(errors.New("unable to convert existing object"))
 	}
 
+	// The templates of a mutable Metal3DataTemplate can be modified, the
+	// secrets being rendered again. The templates of an immutable
+	// Metal3DataTemplate cannot be modified in the update making it mutable.
+	if oldM3dt.Spec.Mutable {
+		return c.validate()
+	}
+
 	if !reflect.DeepEqual(c.Spec.MetaData, oldM3dt.Spec.MetaData) {
 		allErrs = append(allErrs,
 			field.Invalid(
@@ -73,7 +84,7 @@ func (c *Metal3DataTemplate) ValidateUpdate(old runtime.Object) error {
 	}
 
 	if len(allErrs) == 0 {
-		return nil
+		return c.validate()
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("Metal3Data").GroupKind(), c.Name, allErrs)
 }
@@ -86,8 +97,152 @@ func (c *Metal3DataTemplate) ValidateDelete() error {
 func (c *Metal3DataTemplate) validate() error {
 	var allErrs field.ErrorList
 
+	if c.Spec.NetworkData != nil {
+		ethernetsFromHostPath := field.NewPath("spec", "networkData", "links", "ethernetsFromHost")
+		for i, ethernets := range c.Spec.NetworkData.Links.EthernetsFromHost {
+			nameRegex := ethernets.NICSelector.NameRegex
+			if nameRegex == nil {
+				continue
+			}
+			if _, err := regexp.Compile(*nameRegex); err != nil {
+				allErrs = append(allErrs,
+					field.Invalid(
+						ethernetsFromHostPath.Index(i).Child("nicSelector", "nameRegex"),
+						*nameRegex,
+						err.Error(),
+					),
+				)
+			}
+		}
+		bondsPath := field.NewPath("spec", "networkData", "links", "bonds")
+		for i, bond := range c.Spec.NetworkData.Links.Bonds {
+			allErrs = append(allErrs, validateBondOptions(bond,
+				bondsPath.Index(i).Child("bondOptions"),
+			)...)
+		}
+		networksPath := field.NewPath("spec", "networkData", "networks")
+		for i, network := range c.Spec.NetworkData.Networks.IPv4 {
+			allErrs = append(allErrs, validateRules(network.Rules, false,
+				networksPath.Child("ipv4").Index(i).Child("rules"),
+			)...)
+		}
+		for i, network := range c.Spec.NetworkData.Networks.IPv6 {
+			allErrs = append(allErrs, validateRules(network.Rules, true,
+				networksPath.Child("ipv6").Index(i).Child("rules"),
+			)...)
+		}
+	}
+
+	if c.Spec.IndexAllocation != nil {
+		allErrs = append(allErrs, validateIndexAllocation(c.Spec.IndexAllocation,
+			field.NewPath("spec", "indexAllocation"),
+		)...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("Metal3DataTemplate").GroupKind(), c.Name, allErrs)
 }
+
+// validateIndexAllocation checks that an index is reserved at most once, to at
+// most one BareMetalHost, and that the reserved ranges are not empty.
+func validateIndexAllocation(allocation *IndexAllocation, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	hostNames := map[string]bool{}
+	indexes := map[int]bool{}
+	for i, reservation := range allocation.Reservations {
+		if hostNames[reservation.HostName] {
+			allErrs = append(allErrs, field.Duplicate(
+				fldPath.Child("reservations").Index(i).Child("hostName"), reservation.HostName,
+			))
+		}
+		if indexes[reservation.Index] {
+			allErrs = append(allErrs, field.Duplicate(
+				fldPath.Child("reservations").Index(i).Child("index"), reservation.Index,
+			))
+		}
+		hostNames[reservation.HostName] = true
+		indexes[reservation.Index] = true
+	}
+	for i, indexRange := range allocation.ReservedRanges {
+		if indexRange.End < indexRange.Start {
+			allErrs = append(allErrs, field.Invalid(
+				fldPath.Child("reservedRanges").Index(i).Child("end"), indexRange.End,
+				"cannot be lower than the start",
+			))
+		}
+	}
+	return allErrs
+}
+
+// validateRules checks that the source prefixes of the rules are in CIDR
+// notation and of the family of the network.
+func validateRules(rules []NetworkDataRule, ipv6 bool, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, rule := range rules {
+		if rule.From == nil {
+			continue
+		}
+		ip, _, err := net.ParseCIDR(*rule.From)
+		if err != nil || (ip.To4() == nil) != ipv6 {
+			allErrs = append(allErrs,
+				field.Invalid(
+					fldPath.Index(i).Child("from"),
+					*rule.From,
+					"must be a prefix in CIDR notation of the family of the network",
+				),
+			)
+		}
+	}
+	return allErrs
+}
+
+// validateBondOptions checks that the options of the bond apply to its mode,
+// and that the primary link is part of the bond.
+func validateBondOptions(bond NetworkDataLinkBond, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	options := bond.BondOptions
+	if options == nil {
+		return allErrs
+	}
+	modeOnly := func(set bool, name string, modes ...string) {
+		if !set {
+			return
+		}
+		for _, mode := range modes {
+			if bond.BondMode == mode {
+				return
+			}
+		}
+		allErrs = append(allErrs,
+			field.Forbidden(
+				fldPath.Child(name),
+				fmt.Sprintf("only applies to the bond modes %s", strings.Join(modes, ", ")),
+			),
+		)
+	}
+	modeOnly(options.XmitHashPolicy != nil, "xmitHashPolicy", "balance-xor", "802.3ad", "balance-tlb")
+	modeOnly(options.LACPRate != nil, "lacpRate", "802.3ad")
+	modeOnly(options.Primary != nil, "primary", "active-backup", "balance-tlb", "balance-alb")
+	modeOnly(options.FailOverMac != nil, "failOverMac", "active-backup")
+
+	if options.Primary != nil {
+		found := false
+		for _, link := range bond.BondLinks {
+			if link == *options.Primary {
+				found = true
+			}
+		}
+		if !found {
+			allErrs = append(allErrs,
+				field.Invalid(
+					fldPath.Child("primary"),
+					*options.Primary,
+					"must be one of the bondLinks",
+				),
+			)
+		}
+	}
+	return allErrs
+}
@@ -19,6 +19,7 @@ import (
 	ipamv1 "github.com/metal3-io/ip-address-manager/api/v1alpha1"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestMetal3DataTemplateDefault(t *testing.T) {
@@ -52,6 +53,255 @@ func TestMetal3DataTemplateValidation(t *testing.T) {
 				Spec: Metal3DataTemplateSpec{},
 			},
 		},
+		{
+			name:      "should succeed when rules are correct",
+			expectErr: false,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Networks: NetworkDataNetwork{
+							IPv4: []NetworkDataIPv4{{
+								Rules: []NetworkDataRule{
+									{Table: 100},
+									{From: pointer.String("192.168.0.0/24"), Table: 100},
+								},
+							}},
+							IPv6: []NetworkDataIPv6{{
+								Rules: []NetworkDataRule{
+									{From: pointer.String("2001:db8::/64"), Table: 100},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should succeed when bond options apply to the mode",
+			expectErr: false,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Links: NetworkDataLink{
+							Bonds: []NetworkDataLinkBond{{
+								BondMode:  "802.3ad",
+								Id:        "bond0",
+								BondLinks: []string{"eth0", "eth1"},
+								BondOptions: &NetworkDataBondOptions{
+									MIIMon:         pointer.Int(100),
+									XmitHashPolicy: pointer.String("layer3+4"),
+									LACPRate:       pointer.String("fast"),
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should succeed when the bond primary is a bond link",
+			expectErr: false,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Links: NetworkDataLink{
+							Bonds: []NetworkDataLinkBond{{
+								BondMode:  "active-backup",
+								Id:        "bond0",
+								BondLinks: []string{"eth0", "eth1"},
+								BondOptions: &NetworkDataBondOptions{
+									Primary:     pointer.String("eth1"),
+									FailOverMac: pointer.String("active"),
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when a bond option does not apply to the mode",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Links: NetworkDataLink{
+							Bonds: []NetworkDataLinkBond{{
+								BondMode:  "active-backup",
+								Id:        "bond0",
+								BondLinks: []string{"eth0", "eth1"},
+								BondOptions: &NetworkDataBondOptions{
+									LACPRate: pointer.String("fast"),
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when the bond primary is not a bond link",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Links: NetworkDataLink{
+							Bonds: []NetworkDataLinkBond{{
+								BondMode:  "active-backup",
+								Id:        "bond0",
+								BondLinks: []string{"eth0", "eth1"},
+								BondOptions: &NetworkDataBondOptions{
+									Primary: pointer.String("eth2"),
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when the NIC name regex is invalid",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Links: NetworkDataLink{
+							EthernetsFromHost: []NetworkDataLinkEthernetsFromHost{{
+								IDPrefix: "nic",
+								NICSelector: NetworkDataNICSelector{
+									NameRegex: pointer.String("eno[0-9"),
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when a rule source is not a prefix",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Networks: NetworkDataNetwork{
+							IPv4: []NetworkDataIPv4{{
+								Rules: []NetworkDataRule{
+									{From: pointer.String("192.168.0.1"), Table: 100},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when a rule source is of the wrong family",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					NetworkData: &NetworkData{
+						Networks: NetworkDataNetwork{
+							IPv6: []NetworkDataIPv6{{
+								Rules: []NetworkDataRule{
+									{From: pointer.String("192.168.0.0/24"), Table: 100},
+								},
+							}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should succeed when the index reservations are correct",
+			expectErr: false,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					IndexAllocation: &IndexAllocation{
+						Policy: IndexAllocationPolicyHostSticky,
+						Reservations: []IndexReservation{
+							{HostName: "host-0", Index: 0},
+							{HostName: "host-1", Index: 1},
+						},
+						ReservedRanges: []IndexRange{{Start: 0, End: 9}},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when a host has two reserved indexes",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					IndexAllocation: &IndexAllocation{
+						Reservations: []IndexReservation{
+							{HostName: "host-0", Index: 0},
+							{HostName: "host-0", Index: 1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when an index is reserved for two hosts",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					IndexAllocation: &IndexAllocation{
+						Reservations: []IndexReservation{
+							{HostName: "host-0", Index: 0},
+							{HostName: "host-1", Index: 0},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "should fail when a reserved range is empty",
+			expectErr: true,
+			c: &Metal3DataTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "foo",
+				},
+				Spec: Metal3DataTemplateSpec{
+					IndexAllocation: &IndexAllocation{
+						ReservedRanges: []IndexRange{{Start: 10, End: 9}},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -175,6 +425,95 @@ func TestMetal3DataTemplateUpdateValidation(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "should succeed when Metadata value changes in a mutable template",
+			expectErr: false,
+			new: &Metal3DataTemplateSpec{
+				Mutable: true,
+				MetaData: &MetaData{
+					Strings: []MetaDataString{{
+						Key:   "abc",
+						Value: "def",
+					}},
+				},
+			},
+			old: &Metal3DataTemplateSpec{
+				Mutable: true,
+				MetaData: &MetaData{
+					Strings: []MetaDataString{{
+						Key:   "abc",
+						Value: "defg",
+					}},
+				},
+			},
+		},
+		{
+			name:      "should succeed when an immutable template becomes mutable",
+			expectErr: false,
+			new: &Metal3DataTemplateSpec{
+				Mutable: true,
+			},
+			old: &Metal3DataTemplateSpec{},
+		},
+		{
+			name:      "should fail when Metadata value changes while the template becomes mutable",
+			expectErr: true,
+			new: &Metal3DataTemplateSpec{
+				Mutable: true,
+				MetaData: &MetaData{
+					Strings: []MetaDataString{{
+						Key:   "abc",
+						Value: "def",
+					}},
+				},
+			},
+			old: &Metal3DataTemplateSpec{
+				MetaData: &MetaData{
+					Strings: []MetaDataString{{
+						Key:   "abc",
+						Value: "defg",
+					}},
+				},
+			},
+		},
+		{
+			name:      "should fail when a mutable template is invalid",
+			expectErr: true,
+			new: &Metal3DataTemplateSpec{
+				Mutable: true,
+				NetworkData: &NetworkData{
+					Links: NetworkDataLink{
+						EthernetsFromHost: []NetworkDataLinkEthernetsFromHost{{
+							IDPrefix:    "eth",
+							NICSelector: NetworkDataNICSelector{NameRegex: pointer.String("(")},
+						}},
+					},
+				},
+			},
+			old: &Metal3DataTemplateSpec{
+				Mutable: true,
+			},
+		},
+		{
+			name:      "should succeed when the index reservations change",
+			expectErr: false,
+			new: &Metal3DataTemplateSpec{
+				IndexAllocation: &IndexAllocation{
+					Reservations: []IndexReservation{{HostName: "host-0", Index: 0}},
+				},
+			},
+			old: &Metal3DataTemplateSpec{},
+		},
+		{
+			name:      "should fail when the index reservations are invalid",
+			expectErr: true,
+			new: &Metal3DataTemplateSpec{
+				IndexAllocation: &IndexAllocation{
+					ReservedRanges: []IndexRange{{Start: 10, End: 9}},
+				},
+			},
+			old: &Metal3DataTemplateSpec{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -31,6 +31,12 @@ const (
 	CleaningModeMetadata = "metadata"
 	ClonedFromGroupKind  = "Metal3MachineTemplate.infrastructure.cluster.x-k8s.io"
 	LiveIsoDiskFormat    = "live-iso"
+	// ImageUpgradeStrategyNone keeps the image of a provisioned host when the
+	// image of the Metal3Machine changes.
+	ImageUpgradeStrategyNone = "None"
+	// ImageUpgradeStrategyReprovision deprovisions and provisions again the
+	// host when the image of the Metal3Machine changes.
+	ImageUpgradeStrategyReprovision = "Reprovision"
 )
 
 // Metal3MachineSpec defines the desired state of Metal3Machine.
@@ -55,6 +61,18 @@ type Metal3MachineSpec struct {
 	// +optional
 	HostSelector HostSelector `json:"hostSelector,omitempty"`
 
+	// HostSelectionPolicy defines how a BareMetalHost is picked among the
+	// hosts matching the HostSelector. Defaults to picking a random host.
+	// +optional
+	HostSelectionPolicy *HostSelectionPolicy `json:"hostSelectionPolicy,omitempty"`
+
+	// FailureDomain is the failure domain of the BareMetalHost associated
+	// with the Metal3Machine, based on the FailureDomainLabel of the
+	// Metal3Cluster. It is set by the controller when the Machine does not
+	// request a failure domain.
+	// +optional
+	FailureDomain *string `json:"failureDomain,omitempty"`
+
 	// MetadataTemplate is a reference to a Metal3DataTemplate object containing
 	// a template of metadata to be rendered. Metadata keys defined in the
 	// metadataTemplate take precedence over keys defined in metadata field.
@@ -76,6 +94,16 @@ type Metal3MachineSpec struct {
 	// +kubebuilder:validation:Enum:=metadata;disabled
 	// +optional
 	AutomatedCleaningMode *string `json:"automatedCleaningMode,omitempty"`
+
+	// ImageUpgradeStrategy defines what happens when the Image of an already
+	// provisioned Metal3Machine changes. When set to None, the default, the
+	// new image is not applied to the host. When set to Reprovision, the
+	// BareMetalHost is deprovisioned and provisioned again with the new
+	// image, keeping its association with the Metal3Machine, its user data,
+	// its IP address claims and its Metal3Data.
+	// +kubebuilder:validation:Enum:=None;Reprovision
+	// +optional
+	ImageUpgradeStrategy *string `json:"imageUpgradeStrategy,omitempty"`
 }
 
 // Metal3MachineStatus defines the observed state of Metal3Machine.
@@ -56,6 +56,10 @@ func (c *Metal3Machine) validate() error {
 
 	allErrs = append(allErrs, c.Spec.Image.Validate(*field.NewPath("Spec", "Image"))...)
 
+	if c.Spec.HostSelectionPolicy != nil {
+		allErrs = append(allErrs, c.Spec.HostSelectionPolicy.Validate(*field.NewPath("Spec", "HostSelectionPolicy"))...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
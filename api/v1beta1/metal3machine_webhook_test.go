@@ -54,6 +54,17 @@ func TestMetal3MachineValidation(t *testing.T) {
 	validIso.Spec.Image.Checksum = ""
 	validIso.Spec.Image.DiskFormat = pointer.StringPtr(LiveISODiskFormat)
 
+	validSpread := valid.DeepCopy()
+	validSpread.Spec.HostSelectionPolicy = &HostSelectionPolicy{
+		Strategy:    HostSelectionStrategySpread,
+		TopologyKey: "example.com/rack",
+	}
+
+	invalidSpread := valid.DeepCopy()
+	invalidSpread.Spec.HostSelectionPolicy = &HostSelectionPolicy{
+		Strategy: HostSelectionStrategySpread,
+	}
+
 	tests := []struct {
 		name      string
 		expectErr bool
@@ -79,6 +90,16 @@ func TestMetal3MachineValidation(t *testing.T) {
 			expectErr: false,
 			c:         validIso,
 		},
+		{
+			name:      "should succeed when spread strategy has a topology key",
+			expectErr: false,
+			c:         validSpread,
+		},
+		{
+			name:      "should return error when spread strategy has no topology key",
+			expectErr: true,
+			c:         invalidSpread,
+		},
 	}
 
 	for _, tt := range tests {
@@ -56,6 +56,10 @@ func (c *Metal3MachineTemplate) validate() error {
 
 	allErrs = append(allErrs, c.Spec.Template.Spec.Image.Validate(*field.NewPath("Spec", "Template", "Spec", "Image"))...)
 
+	if c.Spec.Template.Spec.HostSelectionPolicy != nil {
+		allErrs = append(allErrs, c.Spec.Template.Spec.HostSelectionPolicy.Validate(*field.NewPath("Spec", "Template", "Spec", "HostSelectionPolicy"))...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
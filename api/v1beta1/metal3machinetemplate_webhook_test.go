@@ -58,6 +58,11 @@ func TestMetal3MachineTemplateValidation(t *testing.T) {
 	validIso.Spec.Template.Spec.Image.Checksum = ""
 	validIso.Spec.Template.Spec.Image.DiskFormat = pointer.StringPtr(LiveISODiskFormat)
 
+	invalidSpread := valid.DeepCopy()
+	invalidSpread.Spec.Template.Spec.HostSelectionPolicy = &HostSelectionPolicy{
+		Strategy: HostSelectionStrategySpread,
+	}
+
 	tests := []struct {
 		name      string
 		expectErr bool
@@ -83,6 +88,11 @@ func TestMetal3MachineTemplateValidation(t *testing.T) {
 			expectErr: false,
 			c:         validIso,
 		},
+		{
+			name:      "should return error when spread strategy has no topology key",
+			expectErr: true,
+			c:         invalidSpread,
+		},
 	}
 
 	for _, tt := range tests {
@@ -29,6 +29,15 @@ const (
 
 	// RebootRemediationStrategy sets RemediationType to Reboot.
 	RebootRemediationStrategy RemediationType = "Reboot"
+
+	// SoftRebootRemediationStrategy sets RemediationType to SoftReboot.
+	SoftRebootRemediationStrategy RemediationType = "SoftReboot"
+
+	// ReprovisionRemediationStrategy sets RemediationType to Reprovision.
+	ReprovisionRemediationStrategy RemediationType = "Reprovision"
+
+	// HostSwapRemediationStrategy sets RemediationType to HostSwap.
+	HostSwapRemediationStrategy RemediationType = "HostSwap"
 )
 
 const (
@@ -51,6 +60,21 @@ type Metal3RemediationSpec struct {
 	// Strategy field defines remediation strategy.
 	// +optional
 	Strategy *RemediationStrategy `json:"strategy,omitempty"`
+
+	// NodeDrain, when set, makes the controller cordon the node and evict
+	// its pods, respecting their PodDisruptionBudgets, before powering off,
+	// deprovisioning or releasing the host.
+	// +optional
+	NodeDrain *NodeDrain `json:"nodeDrain,omitempty"`
+}
+
+// NodeDrain describes how the node is drained before the remediation.
+type NodeDrain struct {
+	// Timeout is the maximum time to wait for the pods to be evicted. Once
+	// expired, the remediation goes on even if some pods are left on the
+	// node. Defaults to 300s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // RemediationStrategy describes how to remediate machines.
@@ -65,7 +89,29 @@ type RemediationStrategy struct {
 
 	// Sets the timeout between remediation retries.
 	// +optional
-	Timeout *metav1.Duration `json:"timeout"`
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Steps is an ordered list of remediation steps. When set, Type and
+	// RetryLimit are ignored: the steps are attempted one after the other,
+	// each one as many times as its own retry limit allows, and the Machine
+	// is deleted once the last step failed. Timeout is used for the steps
+	// which do not set their own.
+	// +optional
+	Steps []RemediationStep `json:"steps,omitempty"`
+}
+
+// RemediationStep describes one step of an escalating remediation.
+type RemediationStep struct {
+	// Type of remediation.
+	Type RemediationType `json:"type"`
+
+	// Sets maximum number of retries of this step.
+	// +optional
+	RetryLimit int `json:"retryLimit,omitempty"`
+
+	// Sets the timeout between retries of this step.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
 // Metal3RemediationStatus defines the observed state of Metal3Remediation.
@@ -83,6 +129,42 @@ type Metal3RemediationStatus struct {
 	// LastRemediated identifies when the host was last remediated
 	// +optional
 	LastRemediated *metav1.Time `json:"lastRemediated,omitempty"`
+
+	// CurrentStep is the index of the remediation step being attempted when
+	// the strategy defines steps.
+	// +optional
+	CurrentStep int `json:"currentStep,omitempty"`
+
+	// NodeDrainStartTime identifies when the drain of the node started for
+	// the current remediation attempt.
+	// +optional
+	NodeDrainStartTime *metav1.Time `json:"nodeDrainStartTime,omitempty"`
+
+	// History lists the remediation attempts which timed out.
+	// +optional
+	History []RemediationAttempt `json:"history,omitempty"`
+}
+
+// RemediationAttempt records a remediation attempt which timed out.
+type RemediationAttempt struct {
+	// Step is the index of the remediation step of the attempt.
+	// +optional
+	Step int `json:"step,omitempty"`
+
+	// Type of remediation of the attempt.
+	Type RemediationType `json:"type"`
+
+	// RetryCount is the number of retries of the step before the attempt.
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// StartTime is when the attempt started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is when the attempt timed out.
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -96,6 +178,7 @@ type Metal3RemediationStatus struct {
 // +kubebuilder:printcolumn:name="Retry count",type=string,JSONPath=".status.retryCount",description="How many times remediation controller has tried to remediate the node"
 // +kubebuilder:printcolumn:name="Last Remediated",type=string,JSONPath=".status.lastRemediated",description="Timestamp of the last remediation attempt"
 // +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=".spec.strategy.type",description="Type of the remediation strategy"
+// +kubebuilder:printcolumn:name="Step",type=string,JSONPath=".status.currentStep",description="Index of the remediation step being attempted",priority=1
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase",description="Phase of the remediation"
 
 // Metal3Remediation is the Schema for the metal3remediations API.
@@ -42,6 +42,13 @@ var _ webhook.Validator = &Metal3Remediation{}
 
 // Default implements webhook.Defaulter so a webhook will be registered for the type.
 func (r *Metal3Remediation) Default() {
+	if r.Spec.Strategy != nil && r.Spec.Strategy.Timeout == nil {
+		r.Spec.Strategy.Timeout = &defaultTimeout
+	}
+
+	if r.Spec.NodeDrain != nil && r.Spec.NodeDrain.Timeout == nil {
+		r.Spec.NodeDrain.Timeout = &defaultNodeDrainTimeout
+	}
 }
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
@@ -73,30 +80,106 @@ func (r *Metal3Remediation) validate() error {
 		)
 	}
 
-	if r.Spec.Strategy.Type != RebootRemediationStrategy {
-		allErrs = append(
-			allErrs,
-			field.Invalid(
-				field.NewPath("spec", "strategy", "type"),
-				r.Spec.Strategy.Type,
-				"is only supported remediation strategy",
-			),
-		)
+	if len(r.Spec.Strategy.Steps) > 0 {
+		allErrs = append(allErrs, validateRemediationSteps(r.Spec.Strategy.Steps, field.NewPath("spec", "strategy", "steps"))...)
+	} else {
+		if !isSupportedRemediationType(r.Spec.Strategy.Type) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "strategy", "type"),
+					r.Spec.Strategy.Type,
+					"only supported remediation strategies are Reboot, SoftReboot, Reprovision and HostSwap",
+				),
+			)
+		}
+
+		if r.Spec.Strategy.RetryLimit < minRetryLimit {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "strategy", "retryLimit"),
+					r.Spec.Strategy.RetryLimit,
+					"is minimum retrylimit",
+				),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, validateNodeDrain(r.Spec.NodeDrain, field.NewPath("spec", "nodeDrain"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("Metal3Remediation").GroupKind(), r.Name, allErrs)
+}
+
+// isSupportedRemediationType returns true if the remediation controller
+// implements the given type of remediation.
+func isSupportedRemediationType(remediationType RemediationType) bool {
+	switch remediationType {
+	case RebootRemediationStrategy, SoftRebootRemediationStrategy,
+		ReprovisionRemediationStrategy, HostSwapRemediationStrategy:
+		return true
+	}
+	return false
+}
+
+// validateRemediationSteps validates the steps of an escalating remediation.
+// Unlike the retry limit of a strategy, the retry limit of a step can be 0,
+// in which case the next step is attempted as soon as the step timed out.
+func validateRemediationSteps(steps []RemediationStep, stepsPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, step := range steps {
+		stepPath := stepsPath.Index(i)
+		if !isSupportedRemediationType(step.Type) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					stepPath.Child("type"),
+					step.Type,
+					"only supported remediation strategies are Reboot, SoftReboot, Reprovision and HostSwap",
+				),
+			)
+		}
+
+		if step.RetryLimit < 0 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					stepPath.Child("retryLimit"),
+					step.RetryLimit,
+					"must be greater than or equal to 0",
+				),
+			)
+		}
+
+		if step.Timeout != nil && step.Timeout.Seconds() < minTimeout.Seconds() {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					stepPath.Child("timeout"),
+					step.Timeout,
+					"min duration is 100s",
+				),
+			)
+		}
 	}
+	return allErrs
+}
 
-	if r.Spec.Strategy.RetryLimit < minRetryLimit {
+// validateNodeDrain validates the drain of the node before the remediation.
+func validateNodeDrain(nodeDrain *NodeDrain, nodeDrainPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if nodeDrain != nil && nodeDrain.Timeout != nil && nodeDrain.Timeout.Duration <= 0 {
 		allErrs = append(
 			allErrs,
 			field.Invalid(
-				field.NewPath("spec", "strategy", "retryLimit"),
-				r.Spec.Strategy.RetryLimit,
-				"is minimum retrylimit",
+				nodeDrainPath.Child("timeout"),
+				nodeDrain.Timeout,
+				"must be greater than 0",
 			),
 		)
 	}
-
-	if len(allErrs) == 0 {
-		return nil
-	}
-	return apierrors.NewInvalid(GroupVersion.WithKind("Metal3Remediation").GroupKind(), r.Name, allErrs)
+	return allErrs
 }
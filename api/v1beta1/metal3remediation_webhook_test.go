@@ -21,6 +21,27 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+func TestMetal3RemediationDefault(t *testing.T) {
+	g := NewWithT(t)
+	m3r := &Metal3Remediation{
+		Spec: Metal3RemediationSpec{
+			Strategy: &RemediationStrategy{
+				Type:       RebootRemediationStrategy,
+				RetryLimit: 1,
+			},
+		},
+	}
+
+	m3r.Default()
+	g.Expect(m3r.Spec.Strategy.Timeout).ToNot(BeNil())
+	g.Expect(*m3r.Spec.Strategy.Timeout).To(Equal(metav1.Duration{Duration: 600 * time.Second}))
+	g.Expect(m3r.Spec.NodeDrain).To(BeNil())
+
+	m3r.Spec.NodeDrain = &NodeDrain{}
+	m3r.Default()
+	g.Expect(*m3r.Spec.NodeDrain.Timeout).To(Equal(metav1.Duration{Duration: 300 * time.Second}))
+}
+
 func TestMetal3RemediationValidation(t *testing.T) {
 	zeroSeconds := metav1.Duration{Duration: 0}
 	thirtySeconds := metav1.Duration{Duration: 30 * time.Second}
@@ -34,6 +55,8 @@ func TestMetal3RemediationValidation(t *testing.T) {
 		timeout   *metav1.Duration
 		limit     int
 		strategy  RemediationType
+		steps     []RemediationStep
+		nodeDrain *NodeDrain
 		expectErr bool
 	}{
 		{
@@ -78,6 +101,95 @@ func TestMetal3RemediationValidation(t *testing.T) {
 			strategy:  RebootRemediationStrategy,
 			expectErr: false,
 		},
+		{
+			name:      "when the Remediation Type is Reprovision",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  ReprovisionRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:      "when the Remediation Type is HostSwap",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  HostSwapRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:      "when the Remediation Type is SoftReboot",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  SoftRebootRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:     "when the Remediation Steps are valid",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: SoftRebootRemediationStrategy, Timeout: &threeMinutes},
+				{Type: RebootRemediationStrategy, RetryLimit: 2},
+				{Type: ReprovisionRemediationStrategy},
+			},
+			expectErr: false,
+		},
+		{
+			name:    "when the Remediation Steps are set without Type and RetryLimit",
+			timeout: &threeMinutes,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy},
+				{Type: HostSwapRemediationStrategy},
+			},
+			expectErr: false,
+		},
+		{
+			name:     "when a Remediation Step Type is not supported",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy},
+				{Type: WrongRemediationStrategy},
+			},
+			expectErr: true,
+		},
+		{
+			name:     "when a Remediation Step Timeout is less than 100s",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy, Timeout: &thirtySeconds},
+			},
+			expectErr: true,
+		},
+		{
+			name:     "when a Remediation Step RetryLimit is less than 0",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy, RetryLimit: -1},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "when the NodeDrain Timeout is given",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  RebootRemediationStrategy,
+			nodeDrain: &NodeDrain{Timeout: &thirtySeconds},
+			expectErr: false,
+		},
+		{
+			name:      "when the NodeDrain Timeout is 0",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  RebootRemediationStrategy,
+			nodeDrain: &NodeDrain{Timeout: &zeroSeconds},
+			expectErr: true,
+		},
 		{
 			name:      "when the Remediation Type is not Reboot",
 			timeout:   &threeMinutes,
@@ -117,7 +229,9 @@ func TestMetal3RemediationValidation(t *testing.T) {
 					Timeout:    tt.timeout,
 					RetryLimit: tt.limit,
 					Type:       tt.strategy,
+					Steps:      tt.steps,
 				},
+				NodeDrain: tt.nodeDrain,
 			},
 		}
 
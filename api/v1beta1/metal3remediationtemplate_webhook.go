@@ -31,6 +31,8 @@ import (
 var (
 	// Default retry timeout is 600 seconds.
 	defaultTimeout = metav1.Duration{Duration: 600 * time.Second}
+	// Default node drain timeout is 300 seconds.
+	defaultNodeDrainTimeout = metav1.Duration{Duration: 300 * time.Second}
 	// Minimum time between remediation retries.
 	minTimeout = metav1.Duration{Duration: 100 * time.Second}
 	// Mininum remediation retry limit is 1.
@@ -66,6 +68,10 @@ func (r *Metal3RemediationTemplate) Default() {
 	if r.Spec.Template.Spec.Strategy.RetryLimit == 0 || r.Spec.Template.Spec.Strategy.RetryLimit < minRetryLimit {
 		r.Spec.Template.Spec.Strategy.RetryLimit = minRetryLimit
 	}
+
+	if r.Spec.Template.Spec.NodeDrain != nil && r.Spec.Template.Spec.NodeDrain.Timeout == nil {
+		r.Spec.Template.Spec.NodeDrain.Timeout = &defaultNodeDrainTimeout
+	}
 }
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
@@ -99,27 +105,35 @@ func (r *Metal3RemediationTemplate) validate() error {
 		)
 	}
 
-	if r.Spec.Template.Spec.Strategy.Type != RebootRemediationStrategy {
-		allErrs = append(
-			allErrs,
-			field.Invalid(
-				field.NewPath("spec", "template", "spec", "strategy", "type"),
-				r.Spec.Template.Spec.Strategy.Type,
-				"only supported remediation strategy is reboot",
-			),
-		)
+	if len(r.Spec.Template.Spec.Strategy.Steps) > 0 {
+		allErrs = append(allErrs, validateRemediationSteps(r.Spec.Template.Spec.Strategy.Steps,
+			field.NewPath("spec", "template", "spec", "strategy", "steps"))...)
+	} else {
+		if !isSupportedRemediationType(r.Spec.Template.Spec.Strategy.Type) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "template", "spec", "strategy", "type"),
+					r.Spec.Template.Spec.Strategy.Type,
+					"only supported remediation strategies are Reboot, SoftReboot, Reprovision and HostSwap",
+				),
+			)
+		}
+
+		if r.Spec.Template.Spec.Strategy.RetryLimit < minRetryLimit {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					field.NewPath("spec", "template", "spec", "strategy", "retryLimit"),
+					r.Spec.Template.Spec.Strategy.RetryLimit,
+					"minimun retrylimit is 1",
+				),
+			)
+		}
 	}
 
-	if r.Spec.Template.Spec.Strategy.RetryLimit < minRetryLimit {
-		allErrs = append(
-			allErrs,
-			field.Invalid(
-				field.NewPath("spec", "template", "spec", "strategy", "retryLimit"),
-				r.Spec.Template.Spec.Strategy.RetryLimit,
-				"minimun retrylimit is 1",
-			),
-		)
-	}
+	allErrs = append(allErrs, validateNodeDrain(r.Spec.Template.Spec.NodeDrain,
+		field.NewPath("spec", "template", "spec", "nodeDrain"))...)
 
 	if len(allErrs) == 0 {
 		return nil
@@ -67,6 +67,12 @@ func TestMetal3RemediationTemplateDefault(t *testing.T) {
 	m3rt.Default()
 	g.Expect(m3rt.Spec.Template.Spec.Strategy.RetryLimit).ToNot(BeNil())
 	g.Expect(m3rt.Spec.Template.Spec.Strategy.RetryLimit).To(Equal(1))
+	g.Expect(m3rt.Spec.Template.Spec.NodeDrain).To(BeNil())
+
+	m3rt.Spec.Template.Spec.NodeDrain = &NodeDrain{}
+	m3rt.Default()
+	g.Expect(m3rt.Spec.Template.Spec.NodeDrain.Timeout).ToNot(BeNil())
+	g.Expect(*m3rt.Spec.Template.Spec.NodeDrain.Timeout).To(Equal(metav1.Duration{Duration: 300 * time.Second}))
 }
 
 func TestMetal3RemediationTemplateValidation(t *testing.T) {
@@ -82,6 +88,7 @@ func TestMetal3RemediationTemplateValidation(t *testing.T) {
 		timeout   *metav1.Duration
 		limit     int
 		strategy  RemediationType
+		steps     []RemediationStep
 		expectErr bool
 	}{
 		{
@@ -126,6 +133,79 @@ func TestMetal3RemediationTemplateValidation(t *testing.T) {
 			strategy:  RebootRemediationStrategy,
 			expectErr: false,
 		},
+		{
+			name:      "when the Remediation Type is Reprovision",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  ReprovisionRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:      "when the Remediation Type is HostSwap",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  HostSwapRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:      "when the Remediation Type is SoftReboot",
+			timeout:   &threeMinutes,
+			limit:     1,
+			strategy:  SoftRebootRemediationStrategy,
+			expectErr: false,
+		},
+		{
+			name:     "when the Remediation Steps are valid",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: SoftRebootRemediationStrategy, Timeout: &threeMinutes},
+				{Type: RebootRemediationStrategy, RetryLimit: 2},
+				{Type: ReprovisionRemediationStrategy},
+			},
+			expectErr: false,
+		},
+		{
+			name:    "when the Remediation Steps are set without Type and RetryLimit",
+			timeout: &threeMinutes,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy},
+				{Type: HostSwapRemediationStrategy},
+			},
+			expectErr: false,
+		},
+		{
+			name:     "when a Remediation Step Type is not supported",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy},
+				{Type: WrongRemediationStrategy},
+			},
+			expectErr: true,
+		},
+		{
+			name:     "when a Remediation Step Timeout is less than 100s",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy, Timeout: &thirtySeconds},
+			},
+			expectErr: true,
+		},
+		{
+			name:     "when a Remediation Step RetryLimit is less than 0",
+			timeout:  &threeMinutes,
+			limit:    1,
+			strategy: RebootRemediationStrategy,
+			steps: []RemediationStep{
+				{Type: RebootRemediationStrategy, RetryLimit: -1},
+			},
+			expectErr: true,
+		},
 		{
 			name:      "when the Remediation Type is not Reboot",
 			timeout:   &threeMinutes,
@@ -167,6 +247,7 @@ func TestMetal3RemediationTemplateValidation(t *testing.T) {
 							Timeout:    tt.timeout,
 							RetryLimit: tt.limit,
 							Type:       tt.strategy,
+							Steps:      tt.steps,
 						},
 					},
 				},
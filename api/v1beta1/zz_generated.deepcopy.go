@@ -23,8 +23,8 @@ package v1beta1
 
 import (
 	"github.com/metal3-io/ip-address-manager/api/v1alpha1"
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
@@ -60,6 +60,21 @@ func (in *FromPool) DeepCopy() *FromPool {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSelectionPolicy) DeepCopyInto(out *HostSelectionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelectionPolicy.
+func (in *HostSelectionPolicy) DeepCopy() *HostSelectionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSelectionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostSelector) DeepCopyInto(out *HostSelector) {
 	*out = *in
@@ -134,6 +149,96 @@ func (in *Image) DeepCopy() *Image {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexAllocation) DeepCopyInto(out *IndexAllocation) {
+	*out = *in
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]IndexReservation, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReservedRanges != nil {
+		in, out := &in.ReservedRanges, &out.ReservedRanges
+		*out = make([]IndexRange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexAllocation.
+func (in *IndexAllocation) DeepCopy() *IndexAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexRange) DeepCopyInto(out *IndexRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexRange.
+func (in *IndexRange) DeepCopy() *IndexRange {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexReservation) DeepCopyInto(out *IndexReservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexReservation.
+func (in *IndexReservation) DeepCopy() *IndexReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LabelSync) DeepCopyInto(out *LabelSync) {
+	*out = *in
+	if in.Prefixes != nil {
+		in, out := &in.Prefixes, &out.Prefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AnnotationPrefixes != nil {
+		in, out := &in.AnnotationPrefixes, &out.AnnotationPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]TaintSync, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LabelSync.
+func (in *LabelSync) DeepCopy() *LabelSync {
+	if in == nil {
+		return nil
+	}
+	out := new(LabelSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaData) DeepCopyInto(out *MetaData) {
 	*out = *in
@@ -192,6 +297,31 @@ func (in *MetaData) DeepCopyInto(out *MetaData) {
 		*out = make([]MetaDataFromAnnotation, len(*in))
 		copy(*out, *in)
 	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]MetaDataTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromHardwareDetails != nil {
+		in, out := &in.FromHardwareDetails, &out.FromHardwareDetails
+		*out = make([]MetaDataFromHardwareDetails, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromConfigMaps != nil {
+		in, out := &in.FromConfigMaps, &out.FromConfigMaps
+		*out = make([]MetaDataFromConfigMap, len(*in))
+		copy(*out, *in)
+	}
+	if in.FromSecrets != nil {
+		in, out := &in.FromSecrets, &out.FromSecrets
+		*out = make([]MetaDataFromSecret, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourcesUpdatePolicy != nil {
+		in, out := &in.SourcesUpdatePolicy, &out.SourcesUpdatePolicy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaData.
@@ -219,6 +349,36 @@ func (in *MetaDataFromAnnotation) DeepCopy() *MetaDataFromAnnotation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetaDataFromConfigMap) DeepCopyInto(out *MetaDataFromConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaDataFromConfigMap.
+func (in *MetaDataFromConfigMap) DeepCopy() *MetaDataFromConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(MetaDataFromConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetaDataFromHardwareDetails) DeepCopyInto(out *MetaDataFromHardwareDetails) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaDataFromHardwareDetails.
+func (in *MetaDataFromHardwareDetails) DeepCopy() *MetaDataFromHardwareDetails {
+	if in == nil {
+		return nil
+	}
+	out := new(MetaDataFromHardwareDetails)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaDataFromLabel) DeepCopyInto(out *MetaDataFromLabel) {
 	*out = *in
@@ -234,6 +394,21 @@ func (in *MetaDataFromLabel) DeepCopy() *MetaDataFromLabel {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetaDataFromSecret) DeepCopyInto(out *MetaDataFromSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaDataFromSecret.
+func (in *MetaDataFromSecret) DeepCopy() *MetaDataFromSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(MetaDataFromSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetaDataHostInterface) DeepCopyInto(out *MetaDataHostInterface) {
 	*out = *in
@@ -339,12 +514,27 @@ func (in *MetaDataString) DeepCopy() *MetaDataString {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetaDataTemplate) DeepCopyInto(out *MetaDataTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaDataTemplate.
+func (in *MetaDataTemplate) DeepCopy() *MetaDataTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MetaDataTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metal3Cluster) DeepCopyInto(out *Metal3Cluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -402,6 +592,23 @@ func (in *Metal3ClusterList) DeepCopyObject() runtime.Object {
 func (in *Metal3ClusterSpec) DeepCopyInto(out *Metal3ClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make(apiv1beta1.FailureDomains, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RemediationBudget != nil {
+		in, out := &in.RemediationBudget, &out.RemediationBudget
+		*out = new(RemediationBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LabelSync != nil {
+		in, out := &in.LabelSync, &out.LabelSync
+		*out = new(LabelSync)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3ClusterSpec.
@@ -431,6 +638,25 @@ func (in *Metal3ClusterStatus) DeepCopyInto(out *Metal3ClusterStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make(apiv1beta1.FailureDomains, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RemediationStartTimes != nil {
+		in, out := &in.RemediationStartTimes, &out.RemediationStartTimes
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemediationsInFlight != nil {
+		in, out := &in.RemediationsInFlight, &out.RemediationsInFlight
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(apiv1beta1.Conditions, len(*in))
@@ -557,7 +783,7 @@ func (in *Metal3DataClaimStatus) DeepCopyInto(out *Metal3DataClaimStatus) {
 	*out = *in
 	if in.RenderedData != nil {
 		in, out := &in.RenderedData, &out.RenderedData
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.ErrorMessage != nil {
@@ -614,12 +840,12 @@ func (in *Metal3DataSpec) DeepCopyInto(out *Metal3DataSpec) {
 	*out = *in
 	if in.MetaData != nil {
 		in, out := &in.MetaData, &out.MetaData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.NetworkData != nil {
 		in, out := &in.NetworkData, &out.NetworkData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	out.Claim = in.Claim
@@ -728,6 +954,16 @@ func (in *Metal3DataTemplateSpec) DeepCopyInto(out *Metal3DataTemplateSpec) {
 		*out = new(NetworkData)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RerenderBatchSize != nil {
+		in, out := &in.RerenderBatchSize, &out.RerenderBatchSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.IndexAllocation != nil {
+		in, out := &in.IndexAllocation, &out.IndexAllocation
+		*out = new(IndexAllocation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3DataTemplateSpec.
@@ -754,6 +990,13 @@ func (in *Metal3DataTemplateStatus) DeepCopyInto(out *Metal3DataTemplateStatus)
 			(*out)[key] = val
 		}
 	}
+	if in.HostIndexes != nil {
+		in, out := &in.HostIndexes, &out.HostIndexes
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3DataTemplateStatus.
@@ -836,23 +1079,33 @@ func (in *Metal3MachineSpec) DeepCopyInto(out *Metal3MachineSpec) {
 	in.Image.DeepCopyInto(&out.Image)
 	if in.UserData != nil {
 		in, out := &in.UserData, &out.UserData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	in.HostSelector.DeepCopyInto(&out.HostSelector)
+	if in.HostSelectionPolicy != nil {
+		in, out := &in.HostSelectionPolicy, &out.HostSelectionPolicy
+		*out = new(HostSelectionPolicy)
+		**out = **in
+	}
+	if in.FailureDomain != nil {
+		in, out := &in.FailureDomain, &out.FailureDomain
+		*out = new(string)
+		**out = **in
+	}
 	if in.DataTemplate != nil {
 		in, out := &in.DataTemplate, &out.DataTemplate
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.MetaData != nil {
 		in, out := &in.MetaData, &out.MetaData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.NetworkData != nil {
 		in, out := &in.NetworkData, &out.NetworkData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.AutomatedCleaningMode != nil {
@@ -860,6 +1113,11 @@ func (in *Metal3MachineSpec) DeepCopyInto(out *Metal3MachineSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ImageUpgradeStrategy != nil {
+		in, out := &in.ImageUpgradeStrategy, &out.ImageUpgradeStrategy
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3MachineSpec.
@@ -896,22 +1154,22 @@ func (in *Metal3MachineStatus) DeepCopyInto(out *Metal3MachineStatus) {
 	}
 	if in.UserData != nil {
 		in, out := &in.UserData, &out.UserData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.RenderedData != nil {
 		in, out := &in.RenderedData, &out.RenderedData
-		*out = new(v1.ObjectReference)
+		*out = new(corev1.ObjectReference)
 		**out = **in
 	}
 	if in.MetaData != nil {
 		in, out := &in.MetaData, &out.MetaData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.NetworkData != nil {
 		in, out := &in.NetworkData, &out.NetworkData
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.Conditions != nil {
@@ -1090,6 +1348,11 @@ func (in *Metal3RemediationSpec) DeepCopyInto(out *Metal3RemediationSpec) {
 		*out = new(RemediationStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeDrain != nil {
+		in, out := &in.NodeDrain, &out.NodeDrain
+		*out = new(NodeDrain)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3RemediationSpec.
@@ -1109,6 +1372,17 @@ func (in *Metal3RemediationStatus) DeepCopyInto(out *Metal3RemediationStatus) {
 		in, out := &in.LastRemediated, &out.LastRemediated
 		*out = (*in).DeepCopy()
 	}
+	if in.NodeDrainStartTime != nil {
+		in, out := &in.NodeDrainStartTime, &out.NodeDrainStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]RemediationAttempt, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Metal3RemediationStatus.
@@ -1231,6 +1505,11 @@ func (in *Metal3RemediationTemplateStatus) DeepCopy() *Metal3RemediationTemplate
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkData) DeepCopyInto(out *NetworkData) {
 	*out = *in
+	if in.Format != nil {
+		in, out := &in.Format, &out.Format
+		*out = new(string)
+		**out = **in
+	}
 	in.Links.DeepCopyInto(&out.Links)
 	in.Networks.DeepCopyInto(&out.Networks)
 	in.Services.DeepCopyInto(&out.Services)
@@ -1246,6 +1525,46 @@ func (in *NetworkData) DeepCopy() *NetworkData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDataBondOptions) DeepCopyInto(out *NetworkDataBondOptions) {
+	*out = *in
+	if in.MIIMon != nil {
+		in, out := &in.MIIMon, &out.MIIMon
+		*out = new(int)
+		**out = **in
+	}
+	if in.XmitHashPolicy != nil {
+		in, out := &in.XmitHashPolicy, &out.XmitHashPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.LACPRate != nil {
+		in, out := &in.LACPRate, &out.LACPRate
+		*out = new(string)
+		**out = **in
+	}
+	if in.Primary != nil {
+		in, out := &in.Primary, &out.Primary
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailOverMac != nil {
+		in, out := &in.FailOverMac, &out.FailOverMac
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataBondOptions.
+func (in *NetworkDataBondOptions) DeepCopy() *NetworkDataBondOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDataBondOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDataIPv4) DeepCopyInto(out *NetworkDataIPv4) {
 	*out = *in
@@ -1256,6 +1575,13 @@ func (in *NetworkDataIPv4) DeepCopyInto(out *NetworkDataIPv4) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]NetworkDataRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataIPv4.
@@ -1300,6 +1626,13 @@ func (in *NetworkDataIPv6) DeepCopyInto(out *NetworkDataIPv6) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]NetworkDataRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataIPv6.
@@ -1358,6 +1691,13 @@ func (in *NetworkDataLink) DeepCopyInto(out *NetworkDataLink) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.EthernetsFromHost != nil {
+		in, out := &in.EthernetsFromHost, &out.EthernetsFromHost
+		*out = make([]NetworkDataLinkEthernetsFromHost, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataLink.
@@ -1383,6 +1723,11 @@ func (in *NetworkDataLinkBond) DeepCopyInto(out *NetworkDataLinkBond) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BondOptions != nil {
+		in, out := &in.BondOptions, &out.BondOptions
+		*out = new(NetworkDataBondOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataLinkBond.
@@ -1415,6 +1760,22 @@ func (in *NetworkDataLinkEthernet) DeepCopy() *NetworkDataLinkEthernet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDataLinkEthernetsFromHost) DeepCopyInto(out *NetworkDataLinkEthernetsFromHost) {
+	*out = *in
+	in.NICSelector.DeepCopyInto(&out.NICSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataLinkEthernetsFromHost.
+func (in *NetworkDataLinkEthernetsFromHost) DeepCopy() *NetworkDataLinkEthernetsFromHost {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDataLinkEthernetsFromHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDataLinkVlan) DeepCopyInto(out *NetworkDataLinkVlan) {
 	*out = *in
@@ -1435,6 +1796,46 @@ func (in *NetworkDataLinkVlan) DeepCopy() *NetworkDataLinkVlan {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDataNICSelector) DeepCopyInto(out *NetworkDataNICSelector) {
+	*out = *in
+	if in.NameRegex != nil {
+		in, out := &in.NameRegex, &out.NameRegex
+		*out = new(string)
+		**out = **in
+	}
+	if in.Vendor != nil {
+		in, out := &in.Vendor, &out.Vendor
+		*out = new(string)
+		**out = **in
+	}
+	if in.Model != nil {
+		in, out := &in.Model, &out.Model
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinSpeedGbps != nil {
+		in, out := &in.MinSpeedGbps, &out.MinSpeedGbps
+		*out = new(int)
+		**out = **in
+	}
+	if in.PXE != nil {
+		in, out := &in.PXE, &out.PXE
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataNICSelector.
+func (in *NetworkDataNICSelector) DeepCopy() *NetworkDataNICSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDataNICSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDataNetwork) DeepCopyInto(out *NetworkDataNetwork) {
 	*out = *in
@@ -1490,6 +1891,16 @@ func (in *NetworkDataRoutev4) DeepCopyInto(out *NetworkDataRoutev4) {
 	*out = *in
 	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Services.DeepCopyInto(&out.Services)
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(int)
+		**out = **in
+	}
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataRoutev4.
@@ -1507,6 +1918,16 @@ func (in *NetworkDataRoutev6) DeepCopyInto(out *NetworkDataRoutev6) {
 	*out = *in
 	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Services.DeepCopyInto(&out.Services)
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(int)
+		**out = **in
+	}
+	if in.Table != nil {
+		in, out := &in.Table, &out.Table
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataRoutev6.
@@ -1519,6 +1940,31 @@ func (in *NetworkDataRoutev6) DeepCopy() *NetworkDataRoutev6 {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkDataRule) DeepCopyInto(out *NetworkDataRule) {
+	*out = *in
+	if in.From != nil {
+		in, out := &in.From, &out.From
+		*out = new(string)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkDataRule.
+func (in *NetworkDataRule) DeepCopy() *NetworkDataRule {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkDataRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkDataService) DeepCopyInto(out *NetworkDataService) {
 	*out = *in
@@ -1669,14 +2115,114 @@ func (in *NetworkLinkEthernetMac) DeepCopy() *NetworkLinkEthernetMac {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrain) DeepCopyInto(out *NodeDrain) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrain.
+func (in *NodeDrain) DeepCopy() *NodeDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationAttempt) DeepCopyInto(out *RemediationAttempt) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationAttempt.
+func (in *RemediationAttempt) DeepCopy() *RemediationAttempt {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationAttempt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationBudget) DeepCopyInto(out *RemediationBudget) {
+	*out = *in
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPerWindow != nil {
+		in, out := &in.MaxPerWindow, &out.MaxPerWindow
+		*out = new(int)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationBudget.
+func (in *RemediationBudget) DeepCopy() *RemediationBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationStep) DeepCopyInto(out *RemediationStep) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStep.
+func (in *RemediationStep) DeepCopy() *RemediationStep {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemediationStrategy) DeepCopyInto(out *RemediationStrategy) {
 	*out = *in
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]RemediationStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationStrategy.
@@ -1688,3 +2234,18 @@ func (in *RemediationStrategy) DeepCopy() *RemediationStrategy {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaintSync) DeepCopyInto(out *TaintSync) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaintSync.
+func (in *TaintSync) DeepCopy() *TaintSync {
+	if in == nil {
+		return nil
+	}
+	out := new(TaintSync)
+	in.DeepCopyInto(out)
+	return out
+}
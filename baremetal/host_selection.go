@@ -0,0 +1,248 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// hostScore is the result of scoring a candidate BareMetalHost. The reason
+// explains how the score was computed.
+type hostScore struct {
+	host   *bmov1alpha1.BareMetalHost
+	score  int64
+	reason string
+}
+
+// hostScorer ranks the candidate BareMetalHosts for a Metal3Machine. Hosts
+// with a higher score are preferred.
+type hostScorer interface {
+	score(host *bmov1alpha1.BareMetalHost) hostScore
+}
+
+// spreadScorer prefers hosts in the topology domain that is the least used by
+// the cluster. Hosts without the topology label come last.
+type spreadScorer struct {
+	topologyKey string
+	usage       map[string]int64
+	total       int64
+}
+
+func (s *spreadScorer) score(host *bmov1alpha1.BareMetalHost) hostScore {
+	domain, ok := host.Labels[s.topologyKey]
+	if !ok {
+		return hostScore{host: host, score: -s.total - 1,
+			reason: fmt.Sprintf("host has no %s label", s.topologyKey),
+		}
+	}
+	return hostScore{host: host, score: -s.usage[domain],
+		reason: fmt.Sprintf("%d host(s) of the cluster in %s=%s", s.usage[domain], s.topologyKey, domain),
+	}
+}
+
+// binPackScorer prefers hosts whose hardware profile is the most used by the
+// cluster.
+type binPackScorer struct {
+	usage map[string]int64
+}
+
+func (s *binPackScorer) score(host *bmov1alpha1.BareMetalHost) hostScore {
+	profile := host.Status.HardwareProfile
+	return hostScore{host: host, score: s.usage[profile],
+		reason: fmt.Sprintf("%d host(s) of the cluster with hardware profile %q", s.usage[profile], profile),
+	}
+}
+
+// mostMemoryScorer prefers hosts with the most RAM.
+type mostMemoryScorer struct{}
+
+func (s *mostMemoryScorer) score(host *bmov1alpha1.BareMetalHost) hostScore {
+	var ram int64
+	if host.Status.HardwareDetails != nil {
+		ram = int64(host.Status.HardwareDetails.RAMMebibytes)
+	}
+	return hostScore{host: host, score: ram,
+		reason: fmt.Sprintf("host has %d MiB of RAM", ram),
+	}
+}
+
+// mostCPUScorer prefers hosts with the most CPUs.
+type mostCPUScorer struct{}
+
+func (s *mostCPUScorer) score(host *bmov1alpha1.BareMetalHost) hostScore {
+	var cpus int64
+	if host.Status.HardwareDetails != nil {
+		cpus = int64(host.Status.HardwareDetails.CPU.Count)
+	}
+	return hostScore{host: host, score: cpus,
+		reason: fmt.Sprintf("host has %d CPU(s)", cpus),
+	}
+}
+
+// leastRecentlyUsedScorer prefers hosts that were deprovisioned the longest
+// time ago. Hosts that were never deprovisioned come first.
+type leastRecentlyUsedScorer struct{}
+
+func (s *leastRecentlyUsedScorer) score(host *bmov1alpha1.BareMetalHost) hostScore {
+	lastUsed := host.Status.OperationHistory.Deprovision.End
+	if lastUsed.IsZero() {
+		return hostScore{host: host, score: math.MaxInt64,
+			reason: "host was never deprovisioned",
+		}
+	}
+	return hostScore{host: host, score: -lastUsed.Unix(),
+		reason: fmt.Sprintf("host was last deprovisioned at %s", lastUsed.UTC()),
+	}
+}
+
+// rankHosts scores the hosts and sorts them from the most to the least
+// preferred one. Ties are broken by host name so that the ranking is
+// deterministic.
+func rankHosts(scorer hostScorer, hosts []*bmov1alpha1.BareMetalHost) []hostScore {
+	scores := make([]hostScore, 0, len(hosts))
+	for _, host := range hosts {
+		scores = append(scores, scorer.score(host))
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].score != scores[j].score {
+			return scores[i].score > scores[j].score
+		}
+		return scores[i].host.Name < scores[j].host.Name
+	})
+	return scores
+}
+
+// hostScorer returns the scorer for the host selection policy of the
+// Metal3Machine, or nil if hosts should be picked randomly. allHosts is the
+// list of all hosts in the namespace, used to compute the usage of the cluster.
+func (m *MachineManager) hostScorer(ctx context.Context, allHosts []bmov1alpha1.BareMetalHost) (hostScorer, error) {
+	policy := m.Metal3Machine.Spec.HostSelectionPolicy
+	if policy == nil {
+		return nil, nil
+	}
+
+	switch policy.Strategy {
+	case "", infrav1.HostSelectionStrategyRandom:
+		return nil, nil
+	case infrav1.HostSelectionStrategyMostMemory:
+		return &mostMemoryScorer{}, nil
+	case infrav1.HostSelectionStrategyMostCPU:
+		return &mostCPUScorer{}, nil
+	case infrav1.HostSelectionStrategyLeastRecentlyUsed:
+		return &leastRecentlyUsedScorer{}, nil
+	case infrav1.HostSelectionStrategySpread, infrav1.HostSelectionStrategyBinPack:
+	default:
+		return nil, errors.Errorf("unknown host selection strategy %s", policy.Strategy)
+	}
+
+	clusterHosts, err := m.clusterHosts(ctx, allHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	if policy.Strategy == infrav1.HostSelectionStrategySpread {
+		scorer := &spreadScorer{
+			topologyKey: policy.TopologyKey,
+			usage:       map[string]int64{},
+		}
+		for _, host := range clusterHosts {
+			if domain, ok := host.Labels[policy.TopologyKey]; ok {
+				scorer.usage[domain]++
+				scorer.total++
+			}
+		}
+		return scorer, nil
+	}
+
+	scorer := &binPackScorer{usage: map[string]int64{}}
+	for _, host := range clusterHosts {
+		scorer.usage[host.Status.HardwareProfile]++
+	}
+	return scorer, nil
+}
+
+// clusterHosts returns the hosts consumed by the Metal3Machines of the same
+// cluster as the Metal3Machine.
+func (m *MachineManager) clusterHosts(ctx context.Context, allHosts []bmov1alpha1.BareMetalHost) ([]*bmov1alpha1.BareMetalHost, error) {
+	clusterHosts := []*bmov1alpha1.BareMetalHost{}
+	clusterName, ok := m.Metal3Machine.Labels[clusterv1.ClusterLabelName]
+	if !ok {
+		return clusterHosts, nil
+	}
+
+	m3Machines := infrav1.Metal3MachineList{}
+	opts := []client.ListOption{
+		client.InNamespace(m.Metal3Machine.Namespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: clusterName},
+	}
+	if err := m.client.List(ctx, &m3Machines, opts...); err != nil {
+		return nil, errors.Wrap(err, "failed to list the Metal3Machines of the cluster")
+	}
+	m3MachineNames := map[string]bool{}
+	for _, m3Machine := range m3Machines.Items {
+		m3MachineNames[m3Machine.Name] = true
+	}
+
+	for i, host := range allHosts {
+		if host.Spec.ConsumerRef == nil || host.Spec.ConsumerRef.Kind != "Metal3Machine" {
+			continue
+		}
+		if m3MachineNames[host.Spec.ConsumerRef.Name] {
+			clusterHosts = append(clusterHosts, &allHosts[i])
+		}
+	}
+	return clusterHosts, nil
+}
+
+// selectHost picks one of the available hosts according to the host selection
+// policy of the Metal3Machine.
+func (m *MachineManager) selectHost(ctx context.Context, availableHosts []*bmov1alpha1.BareMetalHost,
+	allHosts []bmov1alpha1.BareMetalHost) (*bmov1alpha1.BareMetalHost, error) {
+	scorer, err := m.hostScorer(ctx, allHosts)
+	if err != nil {
+		return nil, err
+	}
+
+	if scorer == nil {
+		m.Log.Info("host(s) count available, choosing a random host", "availabeHostCount", len(availableHosts))
+		rHost, _ := rand.Int(rand.Reader, big.NewInt(int64(len(availableHosts))))
+		randomHost := rHost.Int64()
+		return availableHosts[randomHost], nil
+	}
+
+	ranking := rankHosts(scorer, availableHosts)
+	chosen := ranking[0]
+	m.Log.Info("host(s) count available, choosing the best ranked host",
+		"availabeHostCount", len(availableHosts),
+		"strategy", m.Metal3Machine.Spec.HostSelectionPolicy.Strategy,
+		"host", chosen.host.Name,
+		"score", chosen.score,
+		"reason", chosen.reason,
+	)
+	return chosen.host, nil
+}
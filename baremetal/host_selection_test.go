@@ -0,0 +1,284 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const rackLabel = "example.com/rack"
+
+func schedulingHost(name string, labels map[string]string, consumer string,
+	status bmov1alpha1.BareMetalHostStatus) *bmov1alpha1.BareMetalHost {
+	host := &bmov1alpha1.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespaceName,
+			Labels:    labels,
+		},
+		Status: status,
+	}
+	if consumer != "" {
+		host.Spec.ConsumerRef = &corev1.ObjectReference{
+			Name:       consumer,
+			Namespace:  namespaceName,
+			Kind:       "Metal3Machine",
+			APIVersion: infrav1.GroupVersion.String(),
+		}
+	} else {
+		host.Status.Provisioning.State = bmov1alpha1.StateAvailable
+	}
+	return host
+}
+
+func schedulingM3Machine(name string, policy *infrav1.HostSelectionPolicy) *infrav1.Metal3Machine {
+	return &infrav1.Metal3Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespaceName,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Metal3Machine",
+			APIVersion: infrav1.GroupVersion.String(),
+		},
+		Spec: infrav1.Metal3MachineSpec{
+			HostSelectionPolicy: policy,
+		},
+	}
+}
+
+var _ = Describe("Host selection", func() {
+
+	type testCaseSelectHost struct {
+		Policy           *infrav1.HostSelectionPolicy
+		Hosts            []*bmov1alpha1.BareMetalHost
+		ExpectedHostName string
+		ExpectError      bool
+	}
+
+	DescribeTable("Test chooseHost with a host selection policy",
+		func(tc testCaseSelectHost) {
+			objects := []client.Object{
+				schedulingM3Machine(metal3machineName, tc.Policy),
+				schedulingM3Machine("other-m3m-1", nil),
+				schedulingM3Machine("other-m3m-2", nil),
+			}
+			for _, host := range tc.Hosts {
+				objects = append(objects, host)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, nil,
+				schedulingM3Machine(metal3machineName, tc.Policy), logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, _, err := machineMgr.chooseHost(context.TODO())
+			if tc.ExpectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).NotTo(BeNil())
+			Expect(result.Name).To(Equal(tc.ExpectedHostName))
+		},
+		Entry("Spread picks the least used rack", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy:    infrav1.HostSelectionStrategySpread,
+				TopologyKey: rackLabel,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("used-a", map[string]string{rackLabel: "a"}, "other-m3m-1", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("used-b", map[string]string{rackLabel: "b"}, "other-m3m-2", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("used-other-cluster", map[string]string{rackLabel: "c"}, "foreign-m3m", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("free-a", map[string]string{rackLabel: "a"}, "", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("free-c", map[string]string{rackLabel: "c"}, "", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("free-no-rack", map[string]string{}, "", bmov1alpha1.BareMetalHostStatus{}),
+			},
+			ExpectedHostName: "free-c",
+		}),
+		Entry("BinPack picks the most used hardware profile", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyBinPack,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("used-large", nil, "other-m3m-1", bmov1alpha1.BareMetalHostStatus{HardwareProfile: "large"}),
+				schedulingHost("free-small", nil, "", bmov1alpha1.BareMetalHostStatus{HardwareProfile: "small"}),
+				schedulingHost("free-large", nil, "", bmov1alpha1.BareMetalHostStatus{HardwareProfile: "large"}),
+			},
+			ExpectedHostName: "free-large",
+		}),
+		Entry("MostMemory picks the host with the most RAM", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyMostMemory,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("no-details", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("big", nil, "", bmov1alpha1.BareMetalHostStatus{
+					HardwareDetails: &bmov1alpha1.HardwareDetails{RAMMebibytes: 65536},
+				}),
+				schedulingHost("small", nil, "", bmov1alpha1.BareMetalHostStatus{
+					HardwareDetails: &bmov1alpha1.HardwareDetails{RAMMebibytes: 16384},
+				}),
+			},
+			ExpectedHostName: "big",
+		}),
+		Entry("MostCPU picks the host with the most CPUs", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyMostCPU,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("few", nil, "", bmov1alpha1.BareMetalHostStatus{
+					HardwareDetails: &bmov1alpha1.HardwareDetails{CPU: bmov1alpha1.CPU{Count: 8}},
+				}),
+				schedulingHost("many", nil, "", bmov1alpha1.BareMetalHostStatus{
+					HardwareDetails: &bmov1alpha1.HardwareDetails{CPU: bmov1alpha1.CPU{Count: 64}},
+				}),
+			},
+			ExpectedHostName: "many",
+		}),
+		Entry("LeastRecentlyUsed picks the host deprovisioned the longest time ago", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyLeastRecentlyUsed,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("recent", nil, "", bmov1alpha1.BareMetalHostStatus{
+					OperationHistory: bmov1alpha1.OperationHistory{
+						Deprovision: bmov1alpha1.OperationMetric{End: metav1.NewTime(time.Now())},
+					},
+				}),
+				schedulingHost("old", nil, "", bmov1alpha1.BareMetalHostStatus{
+					OperationHistory: bmov1alpha1.OperationHistory{
+						Deprovision: bmov1alpha1.OperationMetric{End: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}),
+			},
+			ExpectedHostName: "old",
+		}),
+		Entry("LeastRecentlyUsed prefers hosts never deprovisioned", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyLeastRecentlyUsed,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("old", nil, "", bmov1alpha1.BareMetalHostStatus{
+					OperationHistory: bmov1alpha1.OperationHistory{
+						Deprovision: bmov1alpha1.OperationMetric{End: metav1.NewTime(time.Now().Add(-time.Hour))},
+					},
+				}),
+				schedulingHost("new", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+			},
+			ExpectedHostName: "new",
+		}),
+		Entry("Ties are broken by host name", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyMostCPU,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("host-b", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+				schedulingHost("host-a", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+			},
+			ExpectedHostName: "host-a",
+		}),
+		Entry("Random picks the only available host", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: infrav1.HostSelectionStrategyRandom,
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("host-a", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+			},
+			ExpectedHostName: "host-a",
+		}),
+		Entry("Unknown strategy returns an error", testCaseSelectHost{
+			Policy: &infrav1.HostSelectionPolicy{
+				Strategy: "Bogus",
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				schedulingHost("host-a", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+			},
+			ExpectError: true,
+		}),
+	)
+
+	It("Explains the ranking of the hosts", func() {
+		scorer := &spreadScorer{
+			topologyKey: rackLabel,
+			usage:       map[string]int64{"a": 2},
+			total:       2,
+		}
+		ranking := rankHosts(scorer, []*bmov1alpha1.BareMetalHost{
+			schedulingHost("free-a", map[string]string{rackLabel: "a"}, "", bmov1alpha1.BareMetalHostStatus{}),
+			schedulingHost("free-no-rack", nil, "", bmov1alpha1.BareMetalHostStatus{}),
+			schedulingHost("free-b", map[string]string{rackLabel: "b"}, "", bmov1alpha1.BareMetalHostStatus{}),
+		})
+		Expect(ranking).To(HaveLen(3))
+		Expect(ranking[0].host.Name).To(Equal("free-b"))
+		Expect(ranking[0].reason).To(Equal("0 host(s) of the cluster in example.com/rack=b"))
+		Expect(ranking[1].host.Name).To(Equal("free-a"))
+		Expect(ranking[1].score).To(Equal(int64(-2)))
+		Expect(ranking[2].host.Name).To(Equal("free-no-rack"))
+		Expect(ranking[2].reason).To(Equal("host has no example.com/rack label"))
+	})
+
+	It("Only considers the hosts of the failure domain of the Machine", func() {
+		m3m := schedulingM3Machine(metal3machineName, &infrav1.HostSelectionPolicy{
+			Strategy: infrav1.HostSelectionStrategyMostCPU,
+		})
+		objects := []client.Object{
+			m3m.DeepCopy(),
+			schedulingHost("rack-a", map[string]string{rackLabel: "a"}, "", bmov1alpha1.BareMetalHostStatus{
+				HardwareDetails: &bmov1alpha1.HardwareDetails{CPU: bmov1alpha1.CPU{Count: 64}},
+			}),
+			schedulingHost("rack-b", map[string]string{rackLabel: "b"}, "", bmov1alpha1.BareMetalHostStatus{
+				HardwareDetails: &bmov1alpha1.HardwareDetails{CPU: bmov1alpha1.CPU{Count: 8}},
+			}),
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		m3Cluster := &infrav1.Metal3Cluster{
+			Spec: infrav1.Metal3ClusterSpec{FailureDomainLabel: rackLabel},
+		}
+		machine := &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{FailureDomain: pointer.StringPtr("b")},
+		}
+		machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, m3Cluster, machine, m3m, logr.Discard())
+		Expect(err).NotTo(HaveOccurred())
+
+		result, _, err := machineMgr.chooseHost(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.Name).To(Equal("rack-b"))
+
+		machineMgr.setFailureDomain(result)
+		Expect(m3m.Spec.FailureDomain).To(Equal(pointer.StringPtr("b")))
+	})
+})
@@ -0,0 +1,333 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ignitionVersion is the version of the Ignition configs rendered.
+	ignitionVersion = "3.3.0"
+	// ignitionFileMode is the mode of the files written by the Ignition
+	// configs rendered (0644).
+	ignitionFileMode = 420
+	// ignitionNetworkdDir is the directory of the systemd-networkd units.
+	ignitionNetworkdDir = "/etc/systemd/network"
+	// metaDataHostnameKey is the key of the rendered metadata holding the
+	// hostname written by the Ignition config.
+	metaDataHostnameKey = "local-hostname"
+	// bootstrapFormatIgnition is the format of the bootstrap data secrets
+	// containing an Ignition config.
+	bootstrapFormatIgnition = "ignition"
+)
+
+type ignitionConfig struct {
+	Ignition ignitionMetadata `json:"ignition"`
+	Storage  *ignitionStorage `json:"storage,omitempty"`
+}
+
+type ignitionMetadata struct {
+	Version string                `json:"version"`
+	Config  *ignitionConfigSource `json:"config,omitempty"`
+}
+
+type ignitionConfigSource struct {
+	Merge []ignitionResource `json:"merge"`
+}
+
+type ignitionResource struct {
+	Source string `json:"source"`
+}
+
+type ignitionStorage struct {
+	Files []ignitionFile `json:"files"`
+}
+
+type ignitionFile struct {
+	Path      string           `json:"path"`
+	Mode      int              `json:"mode"`
+	Overwrite bool             `json:"overwrite"`
+	Contents  ignitionResource `json:"contents"`
+}
+
+// ignitionDataURL returns the data URL of the content.
+func ignitionDataURL(content []byte) string {
+	return "data:;base64," + base64.StdEncoding.EncodeToString(content)
+}
+
+// networkdUnit is a systemd-networkd unit file, as an ordered list of
+// sections.
+type networkdUnit struct {
+	sections []networkdSection
+}
+
+type networkdSection struct {
+	name    string
+	entries []string
+}
+
+// add adds an entry to the last section of the unit with the given name,
+// creating it if needed.
+func (u *networkdUnit) add(section, key, value string) {
+	if len(u.sections) == 0 || u.sections[len(u.sections)-1].name != section {
+		u.sections = append(u.sections, networkdSection{name: section})
+	}
+	last := &u.sections[len(u.sections)-1]
+	last.entries = append(last.entries, key+"="+value)
+}
+
+// newSection starts a new section, for the sections that can be repeated.
+func (u *networkdUnit) newSection(section string) {
+	u.sections = append(u.sections, networkdSection{name: section})
+}
+
+func (u *networkdUnit) String() string {
+	sections := []string{}
+	for _, section := range u.sections {
+		sections = append(sections,
+			fmt.Sprintf("[%s]\n%s\n", section.name, strings.Join(section.entries, "\n")),
+		)
+	}
+	return strings.Join(sections, "\n")
+}
+
+// renderIgnition renders the network data as systemd-networkd units and the
+// hostname in an Ignition v3 config.
+func renderIgnition(model networkDataModel, hostname string) ([]byte, error) {
+	files := []ignitionFile{}
+	if hostname != "" {
+		files = append(files, ignitionFile{
+			Path:      "/etc/hostname",
+			Mode:      ignitionFileMode,
+			Overwrite: true,
+			Contents:  ignitionResource{Source: ignitionDataURL([]byte(hostname + "\n"))},
+		})
+	}
+
+	netdevs := map[string]*networkdUnit{}
+	networks := map[string]*networkdUnit{}
+	for _, link := range model.Links {
+		network := &networkdUnit{}
+		switch link.Type {
+		case "bond", "vlan":
+			netdev := &networkdUnit{}
+			netdev.add("NetDev", "Name", link.ID)
+			netdev.add("NetDev", "Kind", link.Type)
+			if link.MTU != 0 {
+				netdev.add("NetDev", "MTUBytes", fmt.Sprint(link.MTU))
+			}
+			if link.Type == "bond" {
+				if link.EthernetMACAddress != "" {
+					netdev.add("NetDev", "MACAddress", link.EthernetMACAddress)
+				}
+				if link.BondMode != "" {
+					netdev.add("Bond", "Mode", link.BondMode)
+				}
+				if link.BondMIIMon != nil {
+					netdev.add("Bond", "MIIMonitorSec", fmt.Sprintf("%dms", *link.BondMIIMon))
+				}
+				if link.BondXmitHashPolicy != "" {
+					netdev.add("Bond", "TransmitHashPolicy", link.BondXmitHashPolicy)
+				}
+				if link.BondLACPRate != "" {
+					netdev.add("Bond", "LACPTransmitRate", link.BondLACPRate)
+				}
+				if link.BondFailOverMac != "" {
+					netdev.add("Bond", "FailOverMACPolicy", link.BondFailOverMac)
+				}
+			} else {
+				if link.VlanMACAddress != "" {
+					netdev.add("NetDev", "MACAddress", link.VlanMACAddress)
+				}
+				netdev.add("VLAN", "Id", fmt.Sprint(link.VlanID))
+			}
+			netdevs[link.ID] = netdev
+			network.add("Match", "Name", link.ID)
+		default:
+			// Match on the permanent MAC address, the bonds and the VLANs
+			// sharing the address of the interface.
+			if link.EthernetMACAddress != "" {
+				network.add("Match", "PermanentMACAddress", link.EthernetMACAddress)
+			} else {
+				network.add("Match", "Name", link.ID)
+			}
+			if link.MTU != 0 {
+				network.add("Link", "MTUBytes", fmt.Sprint(link.MTU))
+			}
+		}
+		networks[link.ID] = network
+	}
+
+	// Attach the bond ports and the VLANs to their parent interface.
+	for _, link := range model.Links {
+		switch link.Type {
+		case "bond":
+			for _, port := range link.BondLinks {
+				network, ok := networks[port]
+				if !ok {
+					return nil, errors.Errorf("bond %s refers to unknown link %s", link.ID, port)
+				}
+				network.add("Network", "Bond", link.ID)
+				if port == link.BondPrimary {
+					network.add("Network", "PrimarySlave", "yes")
+				}
+			}
+		case "vlan":
+			network, ok := networks[link.VlanLink]
+			if !ok {
+				return nil, errors.Errorf("vlan %s refers to unknown link %s", link.ID, link.VlanLink)
+			}
+			network.add("Network", "VLAN", link.ID)
+		}
+	}
+
+	globalDNS := dnsAddresses(model.Services)
+	dhcp := map[string][]string{}
+	dns := map[string][]string{}
+	for _, ipNetwork := range model.Networks {
+		network, ok := networks[ipNetwork.Link]
+		if !ok {
+			return nil, errors.Errorf("network %s refers to unknown link %s", ipNetwork.ID, ipNetwork.Link)
+		}
+		switch ipNetwork.Type {
+		case "ipv4", "ipv6":
+			address, err := cidr(ipNetwork.IPAddress, ipNetwork.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			network.add("Network", "Address", address)
+		case "ipv4_dhcp":
+			dhcp[ipNetwork.Link] = append(dhcp[ipNetwork.Link], "ipv4")
+		case "ipv6_dhcp":
+			dhcp[ipNetwork.Link] = append(dhcp[ipNetwork.Link], "ipv6")
+		case "ipv6_slaac":
+			network.add("Network", "IPv6AcceptRA", "yes")
+		}
+		dns[ipNetwork.Link] = appendUnique(dns[ipNetwork.Link], globalDNS...)
+		for _, route := range ipNetwork.Routes {
+			dns[ipNetwork.Link] = appendUnique(dns[ipNetwork.Link], dnsAddresses(route.Services)...)
+		}
+	}
+	for _, link := range model.Links {
+		for _, address := range dns[link.ID] {
+			networks[link.ID].add("Network", "DNS", address)
+		}
+		if families, ok := dhcp[link.ID]; ok {
+			value := families[0]
+			if len(families) > 1 {
+				value = "yes"
+			}
+			networks[link.ID].add("Network", "DHCP", value)
+		}
+	}
+
+	// The routes and the rules are added last, each in its own section.
+	for _, ipNetwork := range model.Networks {
+		for _, route := range ipNetwork.Routes {
+			destination, err := cidr(route.Network, route.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			network := networks[ipNetwork.Link]
+			network.newSection("Route")
+			network.add("Route", "Destination", destination)
+			if route.Gateway != "" {
+				network.add("Route", "Gateway", route.Gateway)
+			}
+			if route.Metric != nil {
+				network.add("Route", "Metric", fmt.Sprint(*route.Metric))
+			}
+			if route.Table != nil {
+				network.add("Route", "Table", fmt.Sprint(*route.Table))
+			}
+		}
+		for _, rule := range ipNetwork.Rules {
+			network := networks[ipNetwork.Link]
+			network.newSection("RoutingPolicyRule")
+			network.add("RoutingPolicyRule", "From", rule.From)
+			network.add("RoutingPolicyRule", "Table", fmt.Sprint(rule.Table))
+			if rule.Priority != nil {
+				network.add("RoutingPolicyRule", "Priority", fmt.Sprint(*rule.Priority))
+			}
+		}
+	}
+
+	for _, link := range model.Links {
+		if netdev, ok := netdevs[link.ID]; ok {
+			files = append(files, networkdFile(link.ID+".netdev", netdev))
+		}
+		files = append(files, networkdFile(link.ID+".network", networks[link.ID]))
+	}
+
+	return json.Marshal(ignitionConfig{
+		Ignition: ignitionMetadata{Version: ignitionVersion},
+		Storage:  &ignitionStorage{Files: files},
+	})
+}
+
+// networkdFile returns the Ignition file of a systemd-networkd unit. The
+// units are prefixed so that they take precedence over the default units of
+// the distribution.
+func networkdFile(name string, unit *networkdUnit) ignitionFile {
+	return ignitionFile{
+		Path:      ignitionNetworkdDir + "/10-capm3-" + name,
+		Mode:      ignitionFileMode,
+		Overwrite: true,
+		Contents:  ignitionResource{Source: ignitionDataURL([]byte(unit.String()))},
+	}
+}
+
+// metaDataHostname returns the hostname from the rendered metadata, or an
+// empty string if the metadata does not contain it.
+func metaDataHostname(metaData []byte) (string, error) {
+	values := map[string]interface{}{}
+	if err := yaml.Unmarshal(metaData, &values); err != nil {
+		return "", errors.Wrap(err, "failed to parse the rendered metadata")
+	}
+	hostname, ok := values[metaDataHostnameKey]
+	if !ok {
+		return "", nil
+	}
+	value, ok := hostname.(string)
+	if !ok {
+		return "", errors.Errorf("the %s metadata is not a string", metaDataHostnameKey)
+	}
+	return value, nil
+}
+
+// mergeIgnitionConfigs returns an Ignition config merging the given configs.
+func mergeIgnitionConfigs(configs ...[]byte) ([]byte, error) {
+	merged := ignitionConfig{
+		Ignition: ignitionMetadata{
+			Version: ignitionVersion,
+			Config:  &ignitionConfigSource{Merge: []ignitionResource{}},
+		},
+	}
+	for _, config := range configs {
+		merged.Ignition.Config.Merge = append(merged.Ignition.Config.Merge,
+			ignitionResource{Source: ignitionDataURL(config)},
+		)
+	}
+	return json.Marshal(merged)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+// decodeIgnitionFiles returns the content of the files of an Ignition config,
+// by path.
+func decodeIgnitionFiles(data []byte) map[string]string {
+	config := ignitionConfig{}
+	Expect(json.Unmarshal(data, &config)).To(Succeed())
+	Expect(config.Ignition.Version).To(Equal(ignitionVersion))
+	files := map[string]string{}
+	for _, file := range config.Storage.Files {
+		Expect(file.Contents.Source).To(HavePrefix("data:;base64,"))
+		content, err := base64.StdEncoding.DecodeString(
+			strings.TrimPrefix(file.Contents.Source, "data:;base64,"),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		files[file.Path] = string(content)
+	}
+	return files
+}
+
+var _ = Describe("Ignition", func() {
+	It("Renders the network data as networkd units", func() {
+		output, err := convertNetworkData([]byte(openStackNetworkData),
+			infrav1.NetworkDataFormatIgnition, "node-0",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodeIgnitionFiles(output)).To(Equal(map[string]string{
+			"/etc/hostname": "node-0\n",
+			"/etc/systemd/network/10-capm3-eth0.network": `[Match]
+PermanentMACAddress=00:01:02:03:04:05
+
+[Link]
+MTUBytes=1500
+
+[Network]
+Bond=bond0
+DNS=8.8.8.8
+DHCP=ipv4
+`,
+			"/etc/systemd/network/10-capm3-eth1.network": `[Match]
+PermanentMACAddress=00:01:02:03:04:06
+
+[Link]
+MTUBytes=1500
+
+[Network]
+Bond=bond0
+`,
+			"/etc/systemd/network/10-capm3-bond0.netdev": `[NetDev]
+Name=bond0
+Kind=bond
+MTUBytes=1500
+MACAddress=00:01:02:03:04:05
+
+[Bond]
+Mode=active-backup
+`,
+			"/etc/systemd/network/10-capm3-bond0.network": `[Match]
+Name=bond0
+
+[Network]
+VLAN=vlan1
+Address=192.168.0.14/24
+DNS=8.8.8.8
+DNS=8.8.4.4
+
+[Route]
+Destination=0.0.0.0/0
+Gateway=192.168.0.1
+`,
+			"/etc/systemd/network/10-capm3-vlan1.netdev": `[NetDev]
+Name=vlan1
+Kind=vlan
+MTUBytes=1500
+MACAddress=00:01:02:03:04:05
+
+[VLAN]
+Id=1
+`,
+			"/etc/systemd/network/10-capm3-vlan1.network": `[Match]
+Name=vlan1
+
+[Network]
+Address=2001::10/64
+IPv6AcceptRA=yes
+DNS=8.8.8.8
+`,
+		}))
+	})
+
+	It("Renders the routing tables and the rules", func() {
+		output, err := convertNetworkData([]byte(policyRoutingNetworkData),
+			infrav1.NetworkDataFormatIgnition, "",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decodeIgnitionFiles(output)).To(Equal(map[string]string{
+			"/etc/systemd/network/10-capm3-eth0.network": `[Match]
+PermanentMACAddress=00:01:02:03:04:05
+
+[Link]
+MTUBytes=1500
+
+[Network]
+Address=192.168.0.14/24
+
+[Route]
+Destination=0.0.0.0/0
+Gateway=192.168.0.1
+Metric=200
+Table=100
+
+[RoutingPolicyRule]
+From=192.168.0.14/32
+Table=100
+Priority=1000
+`,
+		}))
+	})
+
+	It("Renders the bond options", func() {
+		output, err := convertNetworkData([]byte(bondOptionsNetworkData),
+			infrav1.NetworkDataFormatIgnition, "",
+		)
+		Expect(err).NotTo(HaveOccurred())
+		files := decodeIgnitionFiles(output)
+		Expect(files["/etc/systemd/network/10-capm3-bond0.netdev"]).To(Equal(`[NetDev]
+Name=bond0
+Kind=bond
+MTUBytes=1500
+MACAddress=00:01:02:03:04:05
+
+[Bond]
+Mode=active-backup
+MIIMonitorSec=100ms
+FailOverMACPolicy=active
+`))
+		Expect(files["/etc/systemd/network/10-capm3-eth1.network"]).To(HaveSuffix(`[Network]
+Bond=bond0
+PrimarySlave=yes
+`))
+	})
+
+	It("Fails on a bond of an unknown link", func() {
+		_, err := renderIgnition(networkDataModel{
+			Links: []networkDataLinkModel{{Type: "bond", ID: "bond0", BondLinks: []string{"eth0"}}},
+		}, "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("Test metaDataHostname",
+		func(metaData string, expected string, expectError bool) {
+			hostname, err := metaDataHostname([]byte(metaData))
+			if expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hostname).To(Equal(expected))
+		},
+		Entry("Hostname", "local-hostname: node-0\nproviderid: abc\n", "node-0", false),
+		Entry("No hostname", "providerid: abc\n", "", false),
+		Entry("Not a string", "local-hostname:\n  a: b\n", "", true),
+		Entry("Invalid", "- abc", "", true),
+	)
+
+	It("Merges the Ignition configs", func() {
+		output, err := mergeIgnitionConfigs([]byte("a"), []byte("b"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output).To(MatchJSON(`{"ignition":{"version":"3.3.0","config":{"merge":[
+			{"source":"data:;base64,YQ=="},{"source":"data:;base64,Yg=="}
+		]}}}`))
+	})
+})
@@ -20,6 +20,7 @@ import (
 	"github.com/go-logr/logr"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	capm3remote "github.com/metal3-io/cluster-api-provider-metal3/baremetal/remote"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -48,14 +49,24 @@ type ManagerFactoryInterface interface {
 	)
 }
 
-// ManagerFactory only contains a client.
+// ManagerFactory contains a client, an event recorder and a workload cluster
+// client getter shared by the managers.
 type ManagerFactory struct {
-	client client.Client
+	client       client.Client
+	recorder     record.EventRecorder
+	clientGetter ClientGetter
 }
 
 // NewManagerFactory returns a new factory.
-func NewManagerFactory(client client.Client) ManagerFactory {
-	return ManagerFactory{client: client}
+func NewManagerFactory(client client.Client, recorder record.EventRecorder) ManagerFactory {
+	return ManagerFactory{client: client, recorder: recorder, clientGetter: capm3remote.NewClusterClient}
+}
+
+// WithClientGetter returns a copy of the factory getting the workload cluster
+// clients with the given ClientGetter.
+func (f ManagerFactory) WithClientGetter(clientGetter ClientGetter) ManagerFactory {
+	f.clientGetter = clientGetter
+	return f
 }
 
 // NewClusterManager creates a new ClusterManager.
@@ -68,13 +79,13 @@ func (f ManagerFactory) NewMachineManager(capiCluster *clusterv1.Cluster,
 	capm3Cluster *infrav1.Metal3Cluster,
 	capiMachine *clusterv1.Machine, capm3Machine *infrav1.Metal3Machine,
 	machineLog logr.Logger) (MachineManagerInterface, error) {
-	return NewMachineManager(f.client, capiCluster, capm3Cluster, capiMachine,
+	return NewMachineManager(f.client, f.recorder, capiCluster, capm3Cluster, capiMachine,
 		capm3Machine, machineLog)
 }
 
 // NewDataTemplateManager creates a new DataTemplateManager.
 func (f ManagerFactory) NewDataTemplateManager(metadata *infrav1.Metal3DataTemplate, metadataLog logr.Logger) (DataTemplateManagerInterface, error) {
-	return NewDataTemplateManager(f.client, metadata, metadataLog)
+	return NewDataTemplateManager(f.client, f.recorder, metadata, metadataLog)
 }
 
 // NewDataManager creates a new DataManager.
@@ -93,5 +104,5 @@ func (f ManagerFactory) NewMachineTemplateManager(capm3Template *infrav1.Metal3M
 func (f ManagerFactory) NewRemediationManager(remediation *infrav1.Metal3Remediation,
 	metal3machine *infrav1.Metal3Machine, machine *clusterv1.Machine,
 	remediationLog logr.Logger) (RemediationManagerInterface, error) {
-	return NewRemediationManager(f.client, capm3remote.NewClusterClient, remediation, metal3machine, machine, remediationLog)
+	return NewRemediationManager(f.client, f.clientGetter, remediation, metal3machine, machine, remediationLog)
 }
@@ -17,11 +17,15 @@ limitations under the License.
 package baremetal
 
 import (
+	"context"
+
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -34,7 +38,7 @@ var _ = Describe("Manager factory testing", func() {
 
 	BeforeEach(func() {
 		fakeClient = fake.NewClientBuilder().WithScheme(setupScheme()).Build()
-		managerFactory = NewManagerFactory(fakeClient)
+		managerFactory = NewManagerFactory(fakeClient, &record.FakeRecorder{})
 	})
 
 	It("returns a manager factory", func() {
@@ -89,4 +93,16 @@ var _ = Describe("Manager factory testing", func() {
 		_, err := managerFactory.NewRemediationManager(&infrav1.Metal3Remediation{}, &infrav1.Metal3Machine{}, &clusterv1.Machine{}, clusterLog)
 		Expect(err).NotTo(HaveOccurred())
 	})
+
+	It("returns a Remediation manager with the client getter", func() {
+		getterCalled := false
+		clientGetter := func(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) (clientcorev1.CoreV1Interface, error) {
+			getterCalled = true
+			return nil, nil
+		}
+		remediationMgr, err := managerFactory.WithClientGetter(clientGetter).NewRemediationManager(&infrav1.Metal3Remediation{}, &infrav1.Metal3Machine{}, &clusterv1.Machine{}, clusterLog)
+		Expect(err).NotTo(HaveOccurred())
+		_, _ = remediationMgr.(*RemediationManager).CapiClientGetter(context.TODO(), fakeClient, &clusterv1.Cluster{})
+		Expect(getterCalled).To(BeTrue())
+	})
 })
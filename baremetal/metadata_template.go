@@ -0,0 +1,250 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// maxMetaDataTemplateOutput is the maximum size of the output of a
+	// metadata template, in bytes.
+	maxMetaDataTemplateOutput = 64 * 1024
+	// maxMetaDataTemplateIterations is the maximum number of iterations of
+	// the ranges of a metadata template, nested ranges included.
+	maxMetaDataTemplateIterations = 10000
+	// rangeableFuncName is the name of the function checking the values
+	// ranged over by the metadata templates.
+	rangeableFuncName = "rangeable"
+)
+
+// metaDataTemplateFuncs are the functions available in the metadata
+// templates, on top of the text/template builtins.
+var metaDataTemplateFuncs = template.FuncMap{
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trim":       strings.TrimSpace,
+	"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"join": func(sep string, values interface{}) (string, error) {
+		switch v := values.(type) {
+		case []string:
+			return strings.Join(v, sep), nil
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, value := range v {
+				items = append(items, fmt.Sprint(value))
+			}
+			return strings.Join(items, sep), nil
+		}
+		return "", errors.Errorf("cannot join %T", values)
+	},
+	"default": func(defaultValue, value interface{}) interface{} {
+		if value == nil || value == "" {
+			return defaultValue
+		}
+		return value
+	},
+	"quote": func(value interface{}) string { return fmt.Sprintf("%q", fmt.Sprint(value)) },
+	"toYaml": func(value interface{}) (string, error) {
+		output, err := yaml.Marshal(value)
+		return strings.TrimSuffix(string(output), "\n"), err
+	},
+}
+
+// limitedBuffer is a buffer failing the writes beyond its limit.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errors.Errorf("output exceeds %d bytes", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
+
+// rangeableFunc returns the function checking the values ranged over during
+// one execution of a metadata template. It only allows the lists and the
+// maps, and fails once the ranges of the template exceed
+// maxMetaDataTemplateIterations iterations, as the loops writing no output
+// are not limited by maxMetaDataTemplateOutput.
+func rangeableFunc() func(interface{}) (interface{}, error) {
+	iterations := 0
+	return func(value interface{}) (interface{}, error) {
+		if value == nil {
+			return value, nil
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+		default:
+			return nil, errors.Errorf("can not range over %T, only over lists and maps", value)
+		}
+		iterations += v.Len()
+		if iterations > maxMetaDataTemplateIterations {
+			return nil, errors.Errorf("ranges exceed %d iterations", maxMetaDataTemplateIterations)
+		}
+		return value, nil
+	}
+}
+
+// checkMetaDataTemplateNode rejects the calls of templates, that allow
+// recursion, and pipes the value of each range to the rangeable function, so
+// that it is checked when the template is executed.
+func checkMetaDataTemplateNode(tree *parse.Tree, node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkMetaDataTemplateNode(tree, child); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return errors.Errorf("calling the template %s is not allowed", n.Name)
+	case *parse.RangeNode:
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pipe.Position(),
+			Args: []parse.Node{
+				parse.NewIdentifier(rangeableFuncName).SetTree(tree).SetPos(n.Pipe.Position()),
+			},
+		})
+		return checkMetaDataTemplateBranch(tree, &n.BranchNode)
+	case *parse.IfNode:
+		return checkMetaDataTemplateBranch(tree, &n.BranchNode)
+	case *parse.WithNode:
+		return checkMetaDataTemplateBranch(tree, &n.BranchNode)
+	}
+	return nil
+}
+
+func checkMetaDataTemplateBranch(tree *parse.Tree, branch *parse.BranchNode) error {
+	if err := checkMetaDataTemplateNode(tree, branch.List); err != nil {
+		return err
+	}
+	return checkMetaDataTemplateNode(tree, branch.ElseList)
+}
+
+// metaDataTemplateContext returns the context of the metadata templates. The
+// objects are converted to maps so that the templates can only access their
+// fields and not call their methods.
+func metaDataTemplateContext(m3d *infrav1.Metal3Data, m3m *infrav1.Metal3Machine,
+	machine *clusterv1.Machine, cluster *clusterv1.Cluster, bmh *bmov1alpha1.BareMetalHost,
+	poolAddresses map[string]addressFromPool,
+) (map[string]interface{}, error) {
+	context := map[string]interface{}{
+		"index":     int64(m3d.Spec.Index),
+		"namespace": m3d.Namespace,
+	}
+	// The missing objects are rendered as empty maps.
+	objects := map[string]interface{}{}
+	for _, key := range []string{"metal3Machine", "machine", "cluster", "bareMetalHost", "hardwareDetails"} {
+		context[key] = map[string]interface{}{}
+	}
+	if m3m != nil {
+		objects["metal3Machine"] = m3m
+	}
+	if machine != nil {
+		objects["machine"] = machine
+	}
+	if cluster != nil {
+		objects["cluster"] = cluster
+	}
+	if bmh != nil {
+		objects["bareMetalHost"] = bmh
+		if bmh.Status.HardwareDetails != nil {
+			objects["hardwareDetails"] = bmh.Status.HardwareDetails
+		}
+	}
+	for key, object := range objects {
+		value, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s for the metadata templates", key)
+		}
+		context[key] = value
+	}
+
+	ipAddresses := map[string]interface{}{}
+	for name, poolAddress := range poolAddresses {
+		dnsServers := []interface{}{}
+		for _, server := range poolAddress.dnsServers {
+			dnsServers = append(dnsServers, string(server))
+		}
+		ipAddresses[name] = map[string]interface{}{
+			"address":    string(poolAddress.address),
+			"prefix":     int64(poolAddress.prefix),
+			"gateway":    string(poolAddress.gateway),
+			"dnsServers": dnsServers,
+		}
+	}
+	context["ipAddresses"] = ipAddresses
+	return context, nil
+}
+
+// renderMetaDataTemplate renders the value of a metadata template.
+func renderMetaDataTemplate(entry infrav1.MetaDataTemplate,
+	context map[string]interface{},
+) (interface{}, error) {
+	tmpl, err := template.New(entry.Key).Funcs(metaDataTemplateFuncs).
+		Funcs(template.FuncMap{rangeableFuncName: rangeableFunc()}).
+		Option("missingkey=error").Parse(entry.Template)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the metadata template %s", entry.Key)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, errors.Errorf("metadata template %s can not define templates", entry.Key)
+	}
+	if tmpl.Tree != nil {
+		if err := checkMetaDataTemplateNode(tmpl.Tree, tmpl.Tree.Root); err != nil {
+			return nil, errors.Wrapf(err, "invalid metadata template %s", entry.Key)
+		}
+	}
+	output := &limitedBuffer{limit: maxMetaDataTemplateOutput}
+	if err := tmpl.Execute(output, context); err != nil {
+		return nil, errors.Wrapf(err, "failed to render the metadata template %s", entry.Key)
+	}
+	if !entry.YAML {
+		return output.String(), nil
+	}
+	var value interface{}
+	if err := yaml.Unmarshal(output.Bytes(), &value); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the output of the metadata template %s", entry.Key)
+	}
+	return value, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"strings"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	ipamv1 "github.com/metal3-io/ip-address-manager/api/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Metadata templates", func() {
+	m3d := &infrav1.Metal3Data{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-abc", Namespace: namespaceName},
+		Spec:       infrav1.Metal3DataSpec{Index: 2},
+	}
+	m3m := &infrav1.Metal3Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      metal3machineName,
+			Namespace: namespaceName,
+			Labels:    map[string]string{"role": "Worker"},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: machineName, Namespace: namespaceName},
+		Spec:       clusterv1.MachineSpec{FailureDomain: pointer.String("rack-1")},
+	}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName, Namespace: namespaceName},
+	}
+	bmh := &bmov1alpha1.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{Name: baremetalhostName, Namespace: namespaceName},
+		Status: bmov1alpha1.BareMetalHostStatus{
+			HardwareDetails: &bmov1alpha1.HardwareDetails{
+				CPU: bmov1alpha1.CPU{Count: 32},
+				NIC: []bmov1alpha1.NIC{
+					{Name: "eth0", MAC: "00:01:02:03:04:05"},
+					{Name: "eth1", MAC: "00:01:02:03:04:06"},
+				},
+			},
+		},
+	}
+	poolAddresses := map[string]addressFromPool{
+		"pool1": {
+			address:    ipamv1.IPAddressStr("192.168.0.14"),
+			prefix:     24,
+			gateway:    ipamv1.IPAddressStr("192.168.0.1"),
+			dnsServers: []ipamv1.IPAddressStr{"8.8.8.8", "8.8.4.4"},
+		},
+	}
+
+	DescribeTable("Test renderMetaDataTemplate",
+		func(entry infrav1.MetaDataTemplate, expected interface{}, expectError bool) {
+			context, err := metaDataTemplateContext(m3d, m3m, machine, cluster, bmh, poolAddresses)
+			Expect(err).NotTo(HaveOccurred())
+			value, err := renderMetaDataTemplate(entry, context)
+			if expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(expected))
+		},
+		Entry("Object fields", infrav1.MetaDataTemplate{
+			Key:      "name",
+			Template: "{{ .cluster.metadata.name }}-{{ .machine.spec.failureDomain }}-{{ .index }}",
+		}, clusterName+"-rack-1-2", false),
+		Entry("Functions", infrav1.MetaDataTemplate{
+			Key:      "role",
+			Template: `{{ lower .metal3Machine.metadata.labels.role }}-{{ index .metal3Machine.metadata.labels "zone" | default "none" }}`,
+		}, "worker-none", false),
+		Entry("Hardware details", infrav1.MetaDataTemplate{
+			Key:      "cpus",
+			Template: "{{ .hardwareDetails.cpu.count }}",
+		}, "32", false),
+		Entry("Pool addresses", infrav1.MetaDataTemplate{
+			Key:      "dns",
+			Template: `{{ with index .ipAddresses "pool1" }}{{ join "," .dnsServers }}{{ end }}`,
+		}, "8.8.8.8,8.8.4.4", false),
+		Entry("Nested YAML", infrav1.MetaDataTemplate{
+			Key: "nics",
+			Template: `{{ range .hardwareDetails.nics }}
+- {{ .name }}: {{ quote .mac }}
+{{- end }}`,
+			YAML: true,
+		}, []interface{}{
+			map[string]interface{}{"eth0": "00:01:02:03:04:05"},
+			map[string]interface{}{"eth1": "00:01:02:03:04:06"},
+		}, false),
+		Entry("Invalid template", infrav1.MetaDataTemplate{
+			Key:      "invalid",
+			Template: "{{ .cluster",
+		}, nil, true),
+		Entry("Missing key", infrav1.MetaDataTemplate{
+			Key:      "missing",
+			Template: "{{ .doesnotexist }}",
+		}, nil, true),
+		Entry("Methods are not available", infrav1.MetaDataTemplate{
+			Key:      "method",
+			Template: "{{ .metal3Machine.GetName }}",
+		}, nil, true),
+		Entry("Invalid YAML", infrav1.MetaDataTemplate{
+			Key:      "yaml",
+			Template: "a: [b",
+			YAML:     true,
+		}, nil, true),
+		Entry("Template definitions are not allowed", infrav1.MetaDataTemplate{
+			Key:      "define",
+			Template: `{{ define "loop" }}{{ template "loop" }}{{ end }}{{ template "loop" }}`,
+		}, nil, true),
+		Entry("Template calls are not allowed", infrav1.MetaDataTemplate{
+			Key:      "template",
+			Template: `{{ if .index }}{{ template "template" }}{{ end }}`,
+		}, nil, true),
+		Entry("Blocks are not allowed", infrav1.MetaDataTemplate{
+			Key:      "block",
+			Template: `{{ block "block" . }}{{ .index }}{{ end }}`,
+		}, nil, true),
+		Entry("Ranging over an integer is not allowed", infrav1.MetaDataTemplate{
+			Key:      "range",
+			Template: `{{ range .hardwareDetails.nics }}{{ range 1000000000 }}{{ end }}{{ end }}`,
+		}, nil, true),
+		Entry("Ranging over an integer field is not allowed", infrav1.MetaDataTemplate{
+			Key:      "rangeField",
+			Template: `{{ range .index }}{{ range $.index }}{{ end }}{{ end }}`,
+		}, nil, true),
+		Entry("Ranging over an integer variable is not allowed", infrav1.MetaDataTemplate{
+			Key:      "rangeVariable",
+			Template: `{{ $n := 2000 }}{{ range $n }}{{ range $n }}{{ end }}{{ end }}`,
+		}, nil, true),
+		Entry("Ranging over a list variable", infrav1.MetaDataTemplate{
+			Key:      "rangeList",
+			Template: `{{ $nics := .hardwareDetails.nics }}{{ range $i, $nic := $nics }}{{ $i }}{{ $nic.name }}{{ end }}`,
+		}, "0eth01eth1", false),
+		Entry("Too many iterations", infrav1.MetaDataTemplate{
+			Key: "iterations",
+			Template: `{{ $l := split "," "` + strings.Repeat(",", 99) + `" }}` +
+				`{{ range $l }}{{ range $l }}{{ range $l }}{{ end }}{{ end }}{{ end }}`,
+		}, nil, true),
+		Entry("Output too large", infrav1.MetaDataTemplate{
+			Key:      "large",
+			Template: `{{ range .hardwareDetails.nics }}` + strings.Repeat("a", maxMetaDataTemplateOutput) + `{{ end }}`,
+		}, nil, true),
+	)
+
+	It("Renders the missing objects as empty maps", func() {
+		context, err := metaDataTemplateContext(m3d, m3m, machine, nil, &bmov1alpha1.BareMetalHost{}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(context["cluster"]).To(Equal(map[string]interface{}{}))
+		Expect(context["hardwareDetails"]).To(Equal(map[string]interface{}{}))
+		Expect(context["ipAddresses"]).To(Equal(map[string]interface{}{}))
+	})
+
+	It("Renders the templates in the metadata", func() {
+		m3dt := &infrav1.Metal3DataTemplate{
+			Spec: infrav1.Metal3DataTemplateSpec{
+				MetaData: &infrav1.MetaData{
+					Strings: []infrav1.MetaDataString{{Key: "String-1", Value: "String-1"}},
+					Templates: []infrav1.MetaDataTemplate{
+						{Key: "local-hostname", Template: "{{ .bareMetalHost.metadata.name }}"},
+						{Key: "address", Template: `{{ toYaml (index .ipAddresses "pool1") }}`, YAML: true},
+					},
+				},
+			},
+		}
+		output, err := renderMetaData(m3d, m3dt, m3m, machine, cluster, bmh, poolAddresses, nil)
+		Expect(err).NotTo(HaveOccurred())
+		metadata := map[string]interface{}{}
+		Expect(yaml.Unmarshal(output, &metadata)).To(Succeed())
+		Expect(metadata).To(Equal(map[string]interface{}{
+			"String-1":       "String-1",
+			"local-hostname": baremetalhostName,
+			"address": map[string]interface{}{
+				"address":    "192.168.0.14",
+				"prefix":     float64(24),
+				"gateway":    "192.168.0.1",
+				"dnsServers": []interface{}{"8.8.8.8", "8.8.4.4"},
+			},
+			"providerid": namespaceName + "/" + baremetalhostName + "/" + metal3machineName,
+		}))
+		hostname, err := metaDataHostname(output)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hostname).To(Equal(baremetalhostName))
+	})
+})
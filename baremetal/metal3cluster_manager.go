@@ -26,6 +26,7 @@ import (
 	// TODO Why blank import ?
 	_ "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -40,6 +41,7 @@ type ClusterManagerInterface interface {
 	Create(context.Context) error
 	Delete() error
 	UpdateClusterStatus() error
+	UpdateFailureDomains(context.Context) error
 	SetFinalizer()
 	UnsetFinalizer()
 	CountDescendants(context.Context) (int, error)
@@ -153,6 +155,42 @@ func (s *ClusterManager) UpdateClusterStatus() error {
 	return nil
 }
 
+// UpdateFailureDomains populates the failure domains of the metal3Cluster
+// status from the values of the FailureDomainLabel on the BareMetalHosts.
+func (s *ClusterManager) UpdateFailureDomains(ctx context.Context) error {
+	fdLabel := s.Metal3Cluster.Spec.FailureDomainLabel
+	if fdLabel == "" {
+		s.Metal3Cluster.Status.FailureDomains = nil
+		return nil
+	}
+
+	hosts := bmov1alpha1.BareMetalHostList{}
+	listOptions := []client.ListOption{
+		client.InNamespace(s.Metal3Cluster.Namespace),
+		client.HasLabels{fdLabel},
+	}
+	if err := s.client.List(ctx, &hosts, listOptions...); err != nil {
+		return errors.Wrap(err, "failed to list BareMetalHosts")
+	}
+
+	failureDomains := clusterv1.FailureDomains{}
+	for _, host := range hosts.Items {
+		name := host.Labels[fdLabel]
+		// If the failure domains are not restricted, all of them are
+		// suitable for control plane machines.
+		if len(s.Metal3Cluster.Spec.FailureDomains) == 0 {
+			failureDomains[name] = clusterv1.FailureDomainSpec{ControlPlane: true}
+			continue
+		}
+		if failureDomain, ok := s.Metal3Cluster.Spec.FailureDomains[name]; ok {
+			failureDomains[name] = failureDomain
+		}
+	}
+
+	s.Metal3Cluster.Status.FailureDomains = failureDomains
+	return nil
+}
+
 // setError sets the FailureMessage and FailureReason fields on the metal3Cluster and logs
 // the message. It assumes the reason is invalid configuration, since that is
 // currently the only relevant Metal3ClusterStatusError choice.
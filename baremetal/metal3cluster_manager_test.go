@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	_ "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -303,6 +304,74 @@ var _ = Describe("Metal3Cluster manager", func() {
 		),
 	)
 
+	type testCaseUpdateFailureDomains struct {
+		FailureDomainLabel     string
+		FailureDomains         clusterv1.FailureDomains
+		Hosts                  []*bmov1alpha1.BareMetalHost
+		ExpectedFailureDomains clusterv1.FailureDomains
+	}
+
+	DescribeTable("Test UpdateFailureDomains",
+		func(tc testCaseUpdateFailureDomains) {
+			spec := bmcSpec()
+			spec.FailureDomainLabel = tc.FailureDomainLabel
+			spec.FailureDomains = tc.FailureDomains
+			bmCluster := newMetal3Cluster(metal3ClusterName, bmcOwnerRef, spec, nil)
+			objects := []client.Object{bmCluster}
+			for _, host := range tc.Hosts {
+				objects = append(objects, host)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			clusterMgr := &ClusterManager{
+				client:        fakeClient,
+				Metal3Cluster: bmCluster,
+				Cluster:       newCluster(clusterName),
+				Log:           logr.Discard(),
+			}
+
+			err := clusterMgr.UpdateFailureDomains(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(bmCluster.Status.FailureDomains).To(Equal(tc.ExpectedFailureDomains))
+		},
+		Entry("No failure domain label", testCaseUpdateFailureDomains{
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				failureDomainHost("host-0", namespaceName, map[string]string{"example.com/rack": "rack-1"}),
+			},
+			ExpectedFailureDomains: nil,
+		}),
+		Entry("Failure domains from all label values", testCaseUpdateFailureDomains{
+			FailureDomainLabel: "example.com/rack",
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				failureDomainHost("host-0", namespaceName, map[string]string{"example.com/rack": "rack-1"}),
+				failureDomainHost("host-1", namespaceName, map[string]string{"example.com/rack": "rack-2"}),
+				failureDomainHost("host-2", namespaceName, map[string]string{"example.com/rack": "rack-2"}),
+				failureDomainHost("host-3", namespaceName, map[string]string{}),
+				failureDomainHost("host-4", "otherns", map[string]string{"example.com/rack": "rack-3"}),
+			},
+			ExpectedFailureDomains: clusterv1.FailureDomains{
+				"rack-1": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"rack-2": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		}),
+		Entry("Failure domains restricted by the spec", testCaseUpdateFailureDomains{
+			FailureDomainLabel: "example.com/rack",
+			FailureDomains: clusterv1.FailureDomains{
+				"rack-1": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"rack-2": clusterv1.FailureDomainSpec{ControlPlane: false},
+				"rack-3": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+			Hosts: []*bmov1alpha1.BareMetalHost{
+				failureDomainHost("host-0", namespaceName, map[string]string{"example.com/rack": "rack-1"}),
+				failureDomainHost("host-1", namespaceName, map[string]string{"example.com/rack": "rack-2"}),
+				failureDomainHost("host-2", namespaceName, map[string]string{"example.com/rack": "rack-4"}),
+			},
+			ExpectedFailureDomains: clusterv1.FailureDomains{
+				"rack-1": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"rack-2": clusterv1.FailureDomainSpec{ControlPlane: false},
+			},
+		}),
+	)
+
 	var descendantsTestCases = []TableEntry{
 		Entry("No Cluster Descendants", descendantsTestCase{
 			Machines:            []*clusterv1.Machine{},
@@ -398,6 +467,16 @@ func newBMClusterSetup(tc testCaseBMClusterManager) (*ClusterManager, error) {
 	}, nil
 }
 
+func failureDomainHost(name, namespace string, labels map[string]string) *bmov1alpha1.BareMetalHost {
+	return &bmov1alpha1.BareMetalHost{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+	}
+}
+
 func descendantsSetup(tc descendantsTestCase) *ClusterManager {
 	cluster := newCluster(clusterName)
 	bmCluster := newMetal3Cluster(metal3ClusterName, tc.OwnerRef,
@@ -17,10 +17,13 @@ limitations under the License.
 package baremetal
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"net"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -32,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
@@ -123,7 +127,22 @@ func (m *DataManager) Reconcile(ctx context.Context) error {
 
 // CreateSecrets creates the secret if they do not exist.
 func (m *DataManager) createSecrets(ctx context.Context) error {
+	return m.renderSecrets(ctx, false)
+}
+
+// rerenderSecrets renders the existing secrets again from the current
+// Metal3DataTemplate, and creates the missing ones.
+func (m *DataManager) rerenderSecrets(ctx context.Context) error {
+	return m.renderSecrets(ctx, true)
+}
+
+// renderSecrets creates the secrets if they do not exist. If rerender is set,
+// the existing secrets are rendered again and updated if their content
+// changed.
+func (m *DataManager) renderSecrets(ctx context.Context, rerender bool) error {
 	var metaDataErr, networkDataErr error
+	var metaDataSecret, networkDataSecret corev1.Secret
+	rerenderMetaData, rerenderNetworkData := false, false
 
 	if m.Data.Spec.Template.Name == "" {
 		return nil
@@ -165,9 +184,10 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		}
 
 		// Try to fetch the secret. If it exists, we do not modify it, to be able
-		// to reprovision a node in the exact same state.
+		// to reprovision a node in the exact same state, unless the template
+		// requires to render it again when its sources change.
 		m.Log.Info("Checking if secret exists", "secret", m.Data.Spec.MetaData.Name)
-		_, metaDataErr = checkSecretExists(ctx, m.client, m.Data.Spec.MetaData.Name,
+		metaDataSecret, metaDataErr = checkSecretExists(ctx, m.client, m.Data.Spec.MetaData.Name,
 			m.Data.Namespace,
 		)
 
@@ -176,6 +196,8 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		}
 		if apierrors.IsNotFound(metaDataErr) {
 			m.Log.Info("MetaData secret creation needed", "secret", m.Data.Spec.MetaData.Name)
+		} else if rerender || rerenderMetaDataSources(m3dt) {
+			rerenderMetaData = true
 		}
 	}
 
@@ -191,9 +213,9 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		}
 
 		// Try to fetch the secret. If it exists, we do not modify it, to be able
-		// to reprovision a node in the exact same state.
+		// to reprovision a node in the exact same state, unless re-rendering.
 		m.Log.Info("Checking if secret exists", "secret", m.Data.Spec.NetworkData.Name)
-		_, networkDataErr = checkSecretExists(ctx, m.client, m.Data.Spec.NetworkData.Name,
+		networkDataSecret, networkDataErr = checkSecretExists(ctx, m.client, m.Data.Spec.NetworkData.Name,
 			m.Data.Namespace,
 		)
 		if networkDataErr != nil && !apierrors.IsNotFound(networkDataErr) {
@@ -201,11 +223,13 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		}
 		if apierrors.IsNotFound(networkDataErr) {
 			m.Log.Info("NetworkData secret creation needed", "secret", m.Data.Spec.NetworkData.Name)
+		} else if rerender {
+			rerenderNetworkData = true
 		}
 	}
 
 	// No secret needs creation
-	if metaDataErr == nil && networkDataErr == nil {
+	if metaDataErr == nil && networkDataErr == nil && !rerenderMetaData && !rerenderNetworkData {
 		m.Log.Info("Metal3Data Reconciled")
 		m.Data.Status.Ready = true
 		return nil
@@ -224,7 +248,7 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 	m.Log.Info("Fetched Machine")
 
 	// Fetch the BMH associated with the M3M
-	bmh, err := getHost(ctx, m3m, m.client, m.Log)
+	bmh, err := m.getMachineHost(ctx, m3m)
 	if err != nil {
 		return err
 	}
@@ -233,6 +257,15 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 	}
 	m.Log.Info("Fetched BMH")
 
+	// Fetch the Cluster, only needed by the metadata templates.
+	var cluster *clusterv1.Cluster
+	if m3dt.Spec.MetaData != nil && len(m3dt.Spec.MetaData.Templates) > 0 {
+		cluster, err = util.GetClusterFromMetadata(ctx, m.client, capiMachine.ObjectMeta)
+		if err != nil {
+			return errors.Wrapf(err, "Machine's Cluster could not be retrieved")
+		}
+	}
+
 	// Fetch all the Metal3IPPools and create Metal3IPClaims as needed. Check if the
 	// IP address has been allocated, if so, fetch the address, gateway and prefix.
 	poolAddresses, err := m.getAddressesFromPool(ctx, *m3dt)
@@ -240,6 +273,12 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		return err
 	}
 
+	// Fetch the values from the ConfigMaps and the Secrets.
+	sourceValues, err := m.getMetaDataFromSources(ctx, m3dt)
+	if err != nil {
+		return err
+	}
+
 	// Create the owner Ref for the secret
 	ownerRefs := []metav1.OwnerReference{
 		{
@@ -251,38 +290,68 @@ func (m *DataManager) createSecrets(ctx context.Context) error {
 		},
 	}
 
-	// The MetaData secret must be created
-	if apierrors.IsNotFound(metaDataErr) {
-		m.Log.Info("Creating Metadata secret")
-		metadata, err := renderMetaData(m.Data, m3dt, m3m, capiMachine, bmh, poolAddresses)
+	// The MetaData secret must be created, or updated if its sources changed
+	if apierrors.IsNotFound(metaDataErr) || rerenderMetaData {
+		metadata, err := renderMetaData(m.Data, m3dt, m3m, capiMachine, cluster, bmh,
+			poolAddresses, sourceValues,
+		)
 		if err != nil {
 			return err
 		}
-		if err := createSecret(ctx, m.client, m.Data.Spec.MetaData.Name,
-			m.Data.Namespace, m3dt.Labels[clusterv1.ClusterLabelName],
-			ownerRefs, map[string][]byte{"metaData": metadata},
-		); err != nil {
-			return err
+		if !rerenderMetaData || !bytes.Equal(metadata, metaDataSecret.Data["metaData"]) {
+			m.Log.Info("Writing Metadata secret")
+			if err := createSecret(ctx, m.client, m.Data.Spec.MetaData.Name,
+				m.Data.Namespace, m3dt.Labels[clusterv1.ClusterLabelName],
+				ownerRefs, map[string][]byte{"metaData": metadata},
+			); err != nil {
+				return err
+			}
 		}
 	}
 
-	// The NetworkData secret must be created
-	if apierrors.IsNotFound(networkDataErr) {
-		m.Log.Info("Creating Networkdata secret")
+	// The NetworkData secret must be created, or updated if re-rendering
+	if apierrors.IsNotFound(networkDataErr) || rerenderNetworkData {
 		networkData, err := renderNetworkData(m.Data, m3dt, bmh, poolAddresses)
 		if err != nil {
 			return err
 		}
-		if err := createSecret(ctx, m.client, m.Data.Spec.NetworkData.Name,
-			m.Data.Namespace, m3dt.Labels[clusterv1.ClusterLabelName],
-			ownerRefs, map[string][]byte{"networkData": networkData},
-		); err != nil {
+		format := networkDataFormat(m3dt.Spec.NetworkData)
+		// The Ignition config also sets the hostname, taken from the metadata.
+		hostname := ""
+		if format == infrav1.NetworkDataFormatIgnition && m3dt.Spec.MetaData != nil {
+			metadata, err := renderMetaData(m.Data, m3dt, m3m, capiMachine, cluster, bmh,
+				poolAddresses, sourceValues,
+			)
+			if err != nil {
+				return err
+			}
+			hostname, err = metaDataHostname(metadata)
+			if err != nil {
+				return err
+			}
+		}
+		networkData, err = convertNetworkData(networkData, format, hostname)
+		if err != nil {
 			return err
 		}
+		if !rerenderNetworkData || !bytes.Equal(networkData, networkDataSecret.Data["networkData"]) ||
+			string(networkDataSecret.Data[networkDataFormatKey]) != format {
+			m.Log.Info("Writing Networkdata secret")
+			if err := createSecret(ctx, m.client, m.Data.Spec.NetworkData.Name,
+				m.Data.Namespace, m3dt.Labels[clusterv1.ClusterLabelName],
+				ownerRefs, map[string][]byte{
+					"networkData":        networkData,
+					networkDataFormatKey: []byte(format),
+				},
+			); err != nil {
+				return err
+			}
+		}
 	}
 
 	m.Log.Info("Metal3Data reconciled")
 	m.Data.Status.Ready = true
+	m.Data.Status.TemplateGeneration = m3dt.Generation
 	return nil
 }
 
@@ -524,7 +593,7 @@ func (m *DataManager) ensureM3IPClaim(ctx context.Context, poolRef corev1.TypedL
 	m.Log.Info("Fetched Metal3Machine")
 
 	// Fetch the BMH associated with the M3M
-	bmh, err := getHost(ctx, m3m, m.client, m.Log)
+	bmh, err := m.getMachineHost(ctx, m3m)
 	if err != nil {
 		return reconciledClaim{m3Claim: ipClaim}, err
 	}
@@ -741,6 +810,17 @@ func renderNetworkServices(services infrav1.NetworkDataService, poolAddresses ma
 func renderNetworkLinks(networkLinks infrav1.NetworkDataLink, bmh *bmov1alpha1.BareMetalHost) ([]interface{}, error) {
 	data := []interface{}{}
 
+	// The ethernet links generated from the NICs come first
+	ethernets, err := getEthernetsFromHost(networkLinks.EthernetsFromHost, bmh)
+	if err != nil {
+		return nil, err
+	}
+	networkLinks.Ethernets = append(ethernets, networkLinks.Ethernets...)
+
+	if err := checkNetworkLinks(networkLinks); err != nil {
+		return nil, err
+	}
+
 	// Ethernet links
 	for _, link := range networkLinks.Ethernets {
 		macAddress, err := getLinkMacAddress(link.MACAddress, bmh)
@@ -761,14 +841,32 @@ func renderNetworkLinks(networkLinks infrav1.NetworkDataLink, bmh *bmov1alpha1.B
 		if err != nil {
 			return nil, err
 		}
-		data = append(data, map[string]interface{}{
+		bond := map[string]interface{}{
 			"type":                 "bond",
 			"id":                   link.Id,
 			"mtu":                  link.MTU,
 			"ethernet_mac_address": macAddress,
 			"bond_mode":            link.BondMode,
 			"bond_links":           link.BondLinks,
-		})
+		}
+		if options := link.BondOptions; options != nil {
+			if options.MIIMon != nil {
+				bond["bond_miimon"] = *options.MIIMon
+			}
+			if options.XmitHashPolicy != nil {
+				bond["bond_xmit_hash_policy"] = *options.XmitHashPolicy
+			}
+			if options.LACPRate != nil {
+				bond["bond_lacp_rate"] = *options.LACPRate
+			}
+			if options.Primary != nil {
+				bond["bond_primary"] = *options.Primary
+			}
+			if options.FailOverMac != nil {
+				bond["bond_fail_over_mac"] = *options.FailOverMac
+			}
+		}
+		data = append(data, bond)
 	}
 
 	// Vlan links
@@ -790,6 +888,134 @@ func renderNetworkLinks(networkLinks infrav1.NetworkDataLink, bmh *bmov1alpha1.B
 	return data, nil
 }
 
+// getEthernetsFromHost generates the ethernet links from the NICs of the
+// BareMetalHost matching the selectors.
+func getEthernetsFromHost(selectors []infrav1.NetworkDataLinkEthernetsFromHost,
+	bmh *bmov1alpha1.BareMetalHost,
+) ([]infrav1.NetworkDataLinkEthernet, error) {
+	ethernets := []infrav1.NetworkDataLinkEthernet{}
+	if len(selectors) == 0 {
+		return ethernets, nil
+	}
+	if bmh == nil || bmh.Status.HardwareDetails == nil || bmh.Status.HardwareDetails.NIC == nil {
+		return nil, errors.New("Nics list not populated")
+	}
+
+	// A NIC is listed once per IP address, only keep one entry per MAC
+	// address.
+	nics := []bmov1alpha1.NIC{}
+	macs := map[string]bool{}
+	for _, nic := range bmh.Status.HardwareDetails.NIC {
+		if macs[nic.MAC] {
+			continue
+		}
+		macs[nic.MAC] = true
+		nics = append(nics, nic)
+	}
+	sort.SliceStable(nics, func(i, j int) bool {
+		if nics[i].Name != nics[j].Name {
+			return nics[i].Name < nics[j].Name
+		}
+		return nics[i].MAC < nics[j].MAC
+	})
+
+	// A NIC is only used by the first selector matching it.
+	used := map[string]bool{}
+	for _, selector := range selectors {
+		index := 0
+		for _, nic := range nics {
+			if used[nic.MAC] {
+				continue
+			}
+			selected, err := nicMatchesSelector(nic, selector.NICSelector)
+			if err != nil {
+				return nil, err
+			}
+			if !selected {
+				continue
+			}
+			used[nic.MAC] = true
+			linkType := selector.Type
+			if linkType == "" {
+				linkType = "phy"
+			}
+			mac := nic.MAC
+			ethernets = append(ethernets, infrav1.NetworkDataLinkEthernet{
+				Type:       linkType,
+				Id:         fmt.Sprintf("%s%d", selector.IDPrefix, index),
+				MTU:        selector.MTU,
+				MACAddress: &infrav1.NetworkLinkEthernetMac{String: &mac},
+			})
+			index++
+		}
+	}
+	return ethernets, nil
+}
+
+// nicMatchesSelector returns true if the NIC matches all the fields set in
+// the selector.
+func nicMatchesSelector(nic bmov1alpha1.NIC, selector infrav1.NetworkDataNICSelector) (bool, error) {
+	if selector.NameRegex != nil {
+		matched, err := regexp.MatchString(*selector.NameRegex, nic.Name)
+		if err != nil {
+			return false, errors.Wrap(err, "invalid NIC name regex")
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	if selector.Vendor != nil && nicVendor(nic) != *selector.Vendor {
+		return false, nil
+	}
+	if selector.Model != nil && nic.Model != *selector.Model {
+		return false, nil
+	}
+	if selector.MinSpeedGbps != nil && nic.SpeedGbps < *selector.MinSpeedGbps {
+		return false, nil
+	}
+	if selector.PXE != nil && nic.PXE != *selector.PXE {
+		return false, nil
+	}
+	return true, nil
+}
+
+// nicVendor returns the vendor ID of the NIC, the first part of its model.
+func nicVendor(nic bmov1alpha1.NIC) string {
+	fields := strings.Fields(nic.Model)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// checkNetworkLinks checks that the links of the bonds and the parent links of
+// the vlans are defined.
+func checkNetworkLinks(networkLinks infrav1.NetworkDataLink) error {
+	linkIDs := map[string]bool{}
+	for _, link := range networkLinks.Ethernets {
+		linkIDs[link.Id] = true
+	}
+	for _, link := range networkLinks.Bonds {
+		linkIDs[link.Id] = true
+	}
+	for _, link := range networkLinks.Vlans {
+		linkIDs[link.Id] = true
+	}
+	for _, link := range networkLinks.Bonds {
+		for _, bondLink := range link.BondLinks {
+			if !linkIDs[bondLink] {
+				return errors.Errorf("bond %s refers to unknown link %s", link.Id, bondLink)
+			}
+		}
+	}
+	for _, link := range networkLinks.Vlans {
+		if !linkIDs[link.VlanLink] {
+			return errors.Errorf("vlan %s refers to unknown link %s", link.Id, link.VlanLink)
+		}
+	}
+	return nil
+}
+
 // renderNetworkNetworks renders the different types of network.
 func renderNetworkNetworks(networks infrav1.NetworkDataNetwork,
 	m3d *infrav1.Metal3Data, poolAddresses map[string]addressFromPool,
@@ -808,14 +1034,18 @@ func renderNetworkNetworks(networks infrav1.NetworkDataNetwork,
 		if err != nil {
 			return nil, err
 		}
-		data = append(data, map[string]interface{}{
+		networkData := map[string]interface{}{
 			"type":       "ipv4",
 			"id":         network.ID,
 			"link":       network.Link,
 			"netmask":    mask,
 			"ip_address": ip,
 			"routes":     routes,
-		})
+		}
+		if len(network.Rules) > 0 {
+			networkData["rules"] = getRules(network.Rules, poolAddress.address, false)
+		}
+		data = append(data, networkData)
 	}
 
 	// IPv6 networks static allocation
@@ -830,14 +1060,18 @@ func renderNetworkNetworks(networks infrav1.NetworkDataNetwork,
 		if err != nil {
 			return nil, err
 		}
-		data = append(data, map[string]interface{}{
+		networkData := map[string]interface{}{
 			"type":       "ipv6",
 			"id":         network.ID,
 			"link":       network.Link,
 			"netmask":    mask,
 			"ip_address": ip,
 			"routes":     routes,
-		})
+		}
+		if len(network.Rules) > 0 {
+			networkData["rules"] = getRules(network.Rules, poolAddress.address, true)
+		}
+		data = append(data, networkData)
 	}
 
 	// IPv4 networks DHCP allocation
@@ -921,16 +1155,60 @@ func getRoutesv4(netRoutes []infrav1.NetworkDataRoutev4,
 			}
 		}
 		mask := translateMask(route.Prefix, true)
-		routes = append(routes, map[string]interface{}{
-			"network":  route.Network,
-			"netmask":  mask,
-			"gateway":  gateway,
-			"services": services,
-		})
+		routes = append(routes, getRoute(route.Network, mask, gateway, services,
+			route.Metric, route.Table,
+		))
 	}
 	return routes, nil
 }
 
+// getRoute returns a route. The metric and the table are only set when given.
+func getRoute(network interface{}, mask interface{}, gateway interface{},
+	services []interface{}, metric *int, table *int,
+) map[string]interface{} {
+	route := map[string]interface{}{
+		"network":  network,
+		"netmask":  mask,
+		"gateway":  gateway,
+		"services": services,
+	}
+	if metric != nil {
+		route["metric"] = *metric
+	}
+	if table != nil {
+		route["table"] = *table
+	}
+	return route
+}
+
+// getRules returns the routing policy rules of a network with a static
+// address. The rules without source prefix apply to the address of the host
+// only, as a /32 or /128 prefix.
+func getRules(netRules []infrav1.NetworkDataRule, address ipamv1.IPAddressStr,
+	ipv6 bool,
+) []interface{} {
+	rules := []interface{}{}
+	for _, netRule := range netRules {
+		from := ""
+		if netRule.From != nil {
+			from = *netRule.From
+		} else if ipv6 {
+			from = fmt.Sprintf("%s/128", address)
+		} else {
+			from = fmt.Sprintf("%s/32", address)
+		}
+		rule := map[string]interface{}{
+			"from":  from,
+			"table": netRule.Table,
+		}
+		if netRule.Priority != nil {
+			rule["priority"] = *netRule.Priority
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
 // getRoutesv6 returns the IPv6 routes.
 func getRoutesv6(netRoutes []infrav1.NetworkDataRoutev6,
 	poolAddresses map[string]addressFromPool,
@@ -967,12 +1245,9 @@ func getRoutesv6(netRoutes []infrav1.NetworkDataRoutev6,
 			}
 		}
 		mask := translateMask(route.Prefix, false)
-		routes = append(routes, map[string]interface{}{
-			"network":  route.Network,
-			"netmask":  mask,
-			"gateway":  gateway,
-			"services": services,
-		})
+		routes = append(routes, getRoute(route.Network, mask, gateway, services,
+			route.Metric, route.Table,
+		))
 	}
 	return routes, nil
 }
@@ -1012,13 +1287,14 @@ func getLinkMacAddress(mac *infrav1.NetworkLinkEthernetMac, bmh *bmov1alpha1.Bar
 
 // renderMetaData renders the MetaData items.
 func renderMetaData(m3d *infrav1.Metal3Data, m3dt *infrav1.Metal3DataTemplate,
-	m3m *infrav1.Metal3Machine, machine *clusterv1.Machine, bmh *bmov1alpha1.BareMetalHost,
-	poolAddresses map[string]addressFromPool,
+	m3m *infrav1.Metal3Machine, machine *clusterv1.Machine, cluster *clusterv1.Cluster,
+	bmh *bmov1alpha1.BareMetalHost, poolAddresses map[string]addressFromPool,
+	sourceValues map[string]string,
 ) ([]byte, error) {
 	if m3dt.Spec.MetaData == nil {
 		return nil, nil
 	}
-	metadata := make(map[string]string)
+	metadata := make(map[string]interface{})
 
 	// Mac addresses
 	for _, entry := range m3dt.Spec.MetaData.FromHostInterfaces {
@@ -1029,6 +1305,15 @@ func renderMetaData(m3d *infrav1.Metal3Data, m3dt *infrav1.Metal3DataTemplate,
 		metadata[entry.Key] = value
 	}
 
+	// Hardware details
+	for _, entry := range m3dt.Spec.MetaData.FromHardwareDetails {
+		value, err := getBMHHardwareDetail(entry.Field, bmh)
+		if err != nil {
+			return nil, err
+		}
+		metadata[entry.Key] = value
+	}
+
 	// IP addresses
 	for _, entry := range m3dt.Spec.MetaData.IPAddressesFromPool {
 		poolAddress, ok := poolAddresses[entry.Name]
@@ -1111,15 +1396,81 @@ func renderMetaData(m3d *infrav1.Metal3Data, m3dt *infrav1.Metal3DataTemplate,
 		}
 	}
 
+	// ConfigMaps and Secrets
+	for key, value := range sourceValues {
+		metadata[key] = value
+	}
+
 	// Strings
 	for _, entry := range m3dt.Spec.MetaData.Strings {
 		metadata[entry.Key] = entry.Value
 	}
+
+	// Templates
+	if len(m3dt.Spec.MetaData.Templates) > 0 {
+		context, err := metaDataTemplateContext(m3d, m3m, machine, cluster, bmh, poolAddresses)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range m3dt.Spec.MetaData.Templates {
+			value, err := renderMetaDataTemplate(entry, context)
+			if err != nil {
+				return nil, err
+			}
+			metadata[entry.Key] = value
+		}
+	}
 	providerid := fmt.Sprintf("%s/%s/%s", m3m.GetNamespace(), bmh.GetName(), m3m.GetName())
 	metadata["providerid"] = providerid
 	return yaml.Marshal(metadata)
 }
 
+// rerenderMetaDataSources returns whether the metadata secret is rendered
+// again when the ConfigMaps and the Secrets it is fetched from change.
+func rerenderMetaDataSources(m3dt *infrav1.Metal3DataTemplate) bool {
+	metaData := m3dt.Spec.MetaData
+	if metaData == nil || len(metaData.FromConfigMaps)+len(metaData.FromSecrets) == 0 {
+		return false
+	}
+	return metaData.SourcesUpdatePolicy != nil &&
+		*metaData.SourcesUpdatePolicy == infrav1.MetaDataSourcesUpdatePolicyRerender
+}
+
+// getMetaDataFromSources fetches the metadata values from the ConfigMaps and
+// the Secrets, in the namespace of the Metal3DataTemplate, by metadata key.
+func (m *DataManager) getMetaDataFromSources(ctx context.Context,
+	m3dt *infrav1.Metal3DataTemplate,
+) (map[string]string, error) {
+	values := map[string]string{}
+	if m3dt.Spec.MetaData == nil {
+		return values, nil
+	}
+	for _, entry := range m3dt.Spec.MetaData.FromConfigMaps {
+		configMap := &corev1.ConfigMap{}
+		key := client.ObjectKey{Name: entry.Name, Namespace: m3dt.Namespace}
+		if err := m.client.Get(ctx, key, configMap); err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch ConfigMap %s", entry.Name)
+		}
+		value, ok := configMap.Data[entry.DataKey]
+		if !ok {
+			return nil, errors.Errorf("key %s not found in ConfigMap %s", entry.DataKey, entry.Name)
+		}
+		values[entry.Key] = value
+	}
+	for _, entry := range m3dt.Spec.MetaData.FromSecrets {
+		secret, err := checkSecretExists(ctx, m.client, entry.Name, m3dt.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to fetch Secret %s", entry.Name)
+		}
+		value, ok := secret.Data[entry.DataKey]
+		if !ok {
+			return nil, errors.Errorf("key %s not found in Secret %s", entry.DataKey, entry.Name)
+		}
+		values[entry.Key] = string(value)
+	}
+	return values, nil
+}
+
 // getBMHMacByName returns the mac address of the interface matching the name.
 func getBMHMacByName(name string, bmh *bmov1alpha1.BareMetalHost) (string, error) {
 	if bmh == nil || bmh.Status.HardwareDetails == nil || bmh.Status.HardwareDetails.NIC == nil {
@@ -1133,6 +1484,62 @@ func getBMHMacByName(name string, bmh *bmov1alpha1.BareMetalHost) (string, error
 	return "", fmt.Errorf("nic name not found %v", name)
 }
 
+// getBMHHardwareDetail returns the value of a hardware detail of the
+// BareMetalHost.
+func getBMHHardwareDetail(field string, bmh *bmov1alpha1.BareMetalHost) (interface{}, error) {
+	if field == infrav1.HardwareDetailsRootDeviceHints {
+		hints := map[string]interface{}{}
+		if bmh == nil || bmh.Spec.RootDeviceHints == nil {
+			return hints, nil
+		}
+		return runtime.DefaultUnstructuredConverter.ToUnstructured(bmh.Spec.RootDeviceHints)
+	}
+	if bmh == nil || bmh.Status.HardwareDetails == nil {
+		return nil, errors.New("Hardware details not populated")
+	}
+	details := bmh.Status.HardwareDetails
+	switch field {
+	case infrav1.HardwareDetailsCPUArch:
+		return details.CPU.Arch, nil
+	case infrav1.HardwareDetailsCPUModel:
+		return details.CPU.Model, nil
+	case infrav1.HardwareDetailsCPUCount:
+		return strconv.Itoa(details.CPU.Count), nil
+	case infrav1.HardwareDetailsCPUClockMegahertz:
+		return strconv.FormatFloat(float64(details.CPU.ClockMegahertz), 'f', -1, 64), nil
+	case infrav1.HardwareDetailsRAMMebibytes:
+		return strconv.Itoa(details.RAMMebibytes), nil
+	case infrav1.HardwareDetailsSystemManufacturer:
+		return details.SystemVendor.Manufacturer, nil
+	case infrav1.HardwareDetailsSystemProductName:
+		return details.SystemVendor.ProductName, nil
+	case infrav1.HardwareDetailsSystemSerialNumber:
+		return details.SystemVendor.SerialNumber, nil
+	case infrav1.HardwareDetailsFirmwareVendor:
+		return details.Firmware.BIOS.Vendor, nil
+	case infrav1.HardwareDetailsFirmwareVersion:
+		return details.Firmware.BIOS.Version, nil
+	case infrav1.HardwareDetailsFirmwareDate:
+		return details.Firmware.BIOS.Date, nil
+	case infrav1.HardwareDetailsDisks:
+		disks := []interface{}{}
+		for _, disk := range details.Storage {
+			disks = append(disks, map[string]interface{}{
+				"name":         disk.Name,
+				"model":        disk.Model,
+				"serialNumber": disk.SerialNumber,
+				"sizeBytes":    int64(disk.SizeBytes),
+				"type":         string(disk.Type),
+				"rotational":   disk.Rotational,
+				"wwn":          disk.WWN,
+				"hctl":         disk.HCTL,
+			})
+		}
+		return disks, nil
+	}
+	return nil, errors.Errorf("Unknown hardware detail %s", field)
+}
+
 func (m *DataManager) getM3Machine(ctx context.Context, m3dt *infrav1.Metal3DataTemplate) (*infrav1.Metal3Machine, error) {
 	if m.Data.Spec.Claim.Name == "" {
 		return nil, errors.New("Claim name not set")
@@ -1174,6 +1581,24 @@ func (m *DataManager) getM3Machine(ctx context.Context, m3dt *infrav1.Metal3Data
 	)
 }
 
+// getMachineHost returns the BareMetalHost associated with the Metal3Machine,
+// or nil if the host is not consumed by the Metal3Machine anymore, for example
+// while a released host is still referenced by the Metal3Machine. The data is
+// not rendered with the details of a host that is not consumed.
+func (m *DataManager) getMachineHost(ctx context.Context, m3m *infrav1.Metal3Machine) (*bmov1alpha1.BareMetalHost, error) {
+	bmh, err := getHost(ctx, m3m, m.client, m.Log)
+	if err != nil || bmh == nil {
+		return nil, err
+	}
+	consumer := bmh.Spec.ConsumerRef
+	if consumer == nil || consumer.Kind != "Metal3Machine" ||
+		consumer.Name != m3m.Name || consumer.Namespace != m3m.Namespace {
+		m.Log.Info("BareMetalHost is not consumed by the Metal3Machine", "host", bmh.Name)
+		return nil, nil
+	}
+	return bmh, nil
+}
+
 // fetchM3IPClaim returns an IPClaim.
 func fetchM3IPClaim(ctx context.Context, cl client.Client, mLog logr.Logger,
 	name, namespace string,
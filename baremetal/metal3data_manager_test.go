@@ -150,11 +150,13 @@ var _ = Describe("Metal3Data manager", func() {
 		bmh                 *bmov1alpha1.BareMetalHost
 		metadataSecret      *corev1.Secret
 		networkdataSecret   *corev1.Secret
+		configMap           *corev1.ConfigMap
 		expectError         bool
 		expectRequeue       bool
 		expectReady         bool
 		expectedMetadata    *string
 		expectedNetworkData *string
+		expectedFormat      *string
 	}
 
 	DescribeTable("Test CreateSecret",
@@ -181,6 +183,9 @@ var _ = Describe("Metal3Data manager", func() {
 			if tc.networkdataSecret != nil {
 				objects = append(objects, tc.networkdataSecret)
 			}
+			if tc.configMap != nil {
+				objects = append(objects, tc.configMap)
+			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
 			dataMgr, err := NewDataManager(fakeClient, tc.m3d,
 				logr.Discard(),
@@ -225,6 +230,9 @@ var _ = Describe("Metal3Data manager", func() {
 				)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(string(tmpSecret.Data["networkData"])).To(Equal(*tc.expectedNetworkData))
+				if tc.expectedFormat != nil {
+					Expect(string(tmpSecret.Data[networkDataFormatKey])).To(Equal(*tc.expectedFormat))
+				}
 			}
 		},
 		Entry("Empty", testCaseCreateSecrets{
@@ -443,10 +451,153 @@ var _ = Describe("Metal3Data manager", func() {
 			},
 			bmh: &bmov1alpha1.BareMetalHost{
 				ObjectMeta: testObjectMeta(baremetalhostName, namespaceName, bmhuid),
+				Spec: bmov1alpha1.BareMetalHostSpec{
+					ConsumerRef: m3mConsumerRef(),
+				},
 			},
 			expectReady:         true,
 			expectedMetadata:    pointer.StringPtr(fmt.Sprintf("String-1: String-1\nproviderid: %s\n", providerid)),
 			expectedNetworkData: pointer.StringPtr("links:\n- ethernet_mac_address: XX:XX:XX:XX:XX:XX\n  id: eth0\n  mtu: 1500\n  type: phy\nnetworks: []\nservices: []\n"),
+			expectedFormat:      pointer.StringPtr(infrav1.NetworkDataFormatOpenStack),
+		}),
+		Entry("metadata secret with immutable sources", testCaseCreateSecrets{
+			m3d: &infrav1.Metal3Data{
+				ObjectMeta: testObjectMetaWithOR(metal3DataName, metal3machineName),
+				Spec: infrav1.Metal3DataSpec{
+					Template: *testObjectReference(metal3DataTemplateName),
+					Claim:    *testObjectReference(metal3DataClaimName),
+				},
+			},
+			m3dt: &infrav1.Metal3DataTemplate{
+				ObjectMeta: testObjectMeta(metal3DataTemplateName, namespaceName, m3dtuid),
+				Spec: infrav1.Metal3DataTemplateSpec{
+					MetaData: &infrav1.MetaData{
+						FromConfigMaps: []infrav1.MetaDataFromConfigMap{
+							{
+								Key:     "ntp",
+								Name:    "site",
+								DataKey: "ntpServers",
+							},
+						},
+						SourcesUpdatePolicy: pointer.StringPtr(infrav1.MetaDataSourcesUpdatePolicyImmutable),
+					},
+				},
+			},
+			m3m: &infrav1.Metal3Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metal3machineName,
+					Namespace: namespaceName,
+					UID:       m3muid,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Name:       machineName,
+							Kind:       "Machine",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+					},
+					Annotations: map[string]string{
+						"metal3.io/BareMetalHost": namespaceName + "/" + baremetalhostName,
+					},
+				},
+				Spec: infrav1.Metal3MachineSpec{
+					DataTemplate: testObjectReference(metal3DataTemplateName),
+				},
+			},
+			dataClaim: &infrav1.Metal3DataClaim{
+				ObjectMeta: testObjectMetaWithOR(metal3DataClaimName, metal3machineName),
+				Spec:       infrav1.Metal3DataClaimSpec{},
+			},
+			machine: &clusterv1.Machine{
+				ObjectMeta: testObjectMeta(machineName, namespaceName, muid),
+			},
+			bmh: &bmov1alpha1.BareMetalHost{
+				ObjectMeta: testObjectMeta(baremetalhostName, namespaceName, bmhuid),
+			},
+			metadataSecret: &corev1.Secret{
+				ObjectMeta: testObjectMeta(metal3machineName+"-metadata", namespaceName, ""),
+				Data: map[string][]byte{
+					"metaData": []byte("Hello"),
+				},
+			},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: testObjectMeta("site", namespaceName, ""),
+				Data: map[string]string{
+					"ntpServers": "pool.ntp.org",
+				},
+			},
+			expectReady:      true,
+			expectedMetadata: pointer.StringPtr("Hello"),
+		}),
+		Entry("metadata secret with re-rendered sources", testCaseCreateSecrets{
+			m3d: &infrav1.Metal3Data{
+				ObjectMeta: testObjectMetaWithOR(metal3DataName, metal3machineName),
+				Spec: infrav1.Metal3DataSpec{
+					Template: *testObjectReference(metal3DataTemplateName),
+					Claim:    *testObjectReference(metal3DataClaimName),
+				},
+			},
+			m3dt: &infrav1.Metal3DataTemplate{
+				ObjectMeta: testObjectMeta(metal3DataTemplateName, namespaceName, m3dtuid),
+				Spec: infrav1.Metal3DataTemplateSpec{
+					MetaData: &infrav1.MetaData{
+						FromConfigMaps: []infrav1.MetaDataFromConfigMap{
+							{
+								Key:     "ntp",
+								Name:    "site",
+								DataKey: "ntpServers",
+							},
+						},
+						SourcesUpdatePolicy: pointer.StringPtr(infrav1.MetaDataSourcesUpdatePolicyRerender),
+					},
+				},
+			},
+			m3m: &infrav1.Metal3Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metal3machineName,
+					Namespace: namespaceName,
+					UID:       m3muid,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Name:       machineName,
+							Kind:       "Machine",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+					},
+					Annotations: map[string]string{
+						"metal3.io/BareMetalHost": namespaceName + "/" + baremetalhostName,
+					},
+				},
+				Spec: infrav1.Metal3MachineSpec{
+					DataTemplate: testObjectReference(metal3DataTemplateName),
+				},
+			},
+			dataClaim: &infrav1.Metal3DataClaim{
+				ObjectMeta: testObjectMetaWithOR(metal3DataClaimName, metal3machineName),
+				Spec:       infrav1.Metal3DataClaimSpec{},
+			},
+			machine: &clusterv1.Machine{
+				ObjectMeta: testObjectMeta(machineName, namespaceName, muid),
+			},
+			bmh: &bmov1alpha1.BareMetalHost{
+				ObjectMeta: testObjectMeta(baremetalhostName, namespaceName, bmhuid),
+				Spec: bmov1alpha1.BareMetalHostSpec{
+					ConsumerRef: m3mConsumerRef(),
+				},
+			},
+			metadataSecret: &corev1.Secret{
+				ObjectMeta: testObjectMeta(metal3machineName+"-metadata", namespaceName, ""),
+				Data: map[string][]byte{
+					"metaData": []byte("Hello"),
+				},
+			},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: testObjectMeta("site", namespaceName, ""),
+				Data: map[string]string{
+					"ntpServers": "pool.ntp.org",
+				},
+			},
+			expectReady:      true,
+			expectedMetadata: pointer.StringPtr(fmt.Sprintf("ntp: pool.ntp.org\nproviderid: %s\n", providerid)),
 		}),
 		Entry("No Machine OwnerRef on M3M", testCaseCreateSecrets{
 			m3d: &infrav1.Metal3Data{
@@ -555,6 +706,59 @@ var _ = Describe("Metal3Data manager", func() {
 			},
 			expectRequeue: true,
 		}),
+		Entry("host not consumed by the Metal3Machine", testCaseCreateSecrets{
+			m3d: &infrav1.Metal3Data{
+				ObjectMeta: testObjectMetaWithOR(metal3DataName, metal3machineName),
+				Spec: infrav1.Metal3DataSpec{
+					Template: *testObjectReference(metal3DataTemplateName),
+					Claim:    *testObjectReference(metal3DataClaimName),
+				},
+			},
+			m3dt: &infrav1.Metal3DataTemplate{
+				ObjectMeta: testObjectMeta(metal3DataTemplateName, namespaceName, m3dtuid),
+				Spec: infrav1.Metal3DataTemplateSpec{
+					MetaData: &infrav1.MetaData{
+						Strings: []infrav1.MetaDataString{
+							{
+								Key:   "String-1",
+								Value: "String-1",
+							},
+						},
+					},
+				},
+			},
+			m3m: &infrav1.Metal3Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metal3machineName,
+					Namespace: namespaceName,
+					OwnerReferences: []metav1.OwnerReference{
+						{
+							Name:       machineName,
+							Kind:       "Machine",
+							APIVersion: clusterv1.GroupVersion.String(),
+						},
+					},
+					Annotations: map[string]string{
+						HostAnnotation: namespaceName + "/" + baremetalhostName,
+					},
+				},
+				Spec: infrav1.Metal3MachineSpec{
+					DataTemplate: testObjectReference(metal3DataTemplateName),
+				},
+			},
+			machine: &clusterv1.Machine{
+				ObjectMeta: testObjectMeta(machineName, namespaceName, muid),
+			},
+			dataClaim: &infrav1.Metal3DataClaim{
+				ObjectMeta: testObjectMetaWithOR(metal3DataClaimName, metal3machineName),
+				Spec:       infrav1.Metal3DataClaimSpec{},
+			},
+			// The host was released, it is not consumed anymore.
+			bmh: &bmov1alpha1.BareMetalHost{
+				ObjectMeta: testObjectMeta(baremetalhostName, namespaceName, bmhuid),
+			},
+			expectRequeue: true,
+		}),
 	)
 
 	type testCaseReleaseLeases struct {
@@ -1751,6 +1955,16 @@ var _ = Describe("Metal3Data manager", func() {
 		}),
 		Entry("Bond, MAC from string", testCaseRenderNetworkLinks{
 			links: infrav1.NetworkDataLink{
+				Ethernets: []infrav1.NetworkDataLinkEthernet{
+					{
+						Type: "phy",
+						Id:   "eth0",
+						MTU:  1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+					},
+				},
 				Bonds: []infrav1.NetworkDataLinkBond{
 					{
 						BondMode: "802.3ad",
@@ -1764,6 +1978,12 @@ var _ = Describe("Metal3Data manager", func() {
 				},
 			},
 			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"type":                 "phy",
+					"id":                   "eth0",
+					"mtu":                  1500,
+					"ethernet_mac_address": "XX:XX:XX:XX:XX:XX",
+				},
 				map[string]interface{}{
 					"type":                 "bond",
 					"id":                   "bond0",
@@ -1774,8 +1994,82 @@ var _ = Describe("Metal3Data manager", func() {
 				},
 			},
 		}),
+		Entry("Bond with options", testCaseRenderNetworkLinks{
+			links: infrav1.NetworkDataLink{
+				Ethernets: []infrav1.NetworkDataLinkEthernet{
+					{
+						Type: "phy",
+						Id:   "eth0",
+						MTU:  1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+					},
+				},
+				Bonds: []infrav1.NetworkDataLinkBond{
+					{
+						BondMode: "802.3ad",
+						Id:       "bond0",
+						MTU:      1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+						BondLinks: []string{"eth0"},
+						BondOptions: &infrav1.NetworkDataBondOptions{
+							MIIMon:         pointer.IntPtr(100),
+							XmitHashPolicy: pointer.StringPtr("layer3+4"),
+							LACPRate:       pointer.StringPtr("fast"),
+						},
+					},
+				},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"type":                 "phy",
+					"id":                   "eth0",
+					"mtu":                  1500,
+					"ethernet_mac_address": "XX:XX:XX:XX:XX:XX",
+				},
+				map[string]interface{}{
+					"type":                  "bond",
+					"id":                    "bond0",
+					"mtu":                   1500,
+					"ethernet_mac_address":  "XX:XX:XX:XX:XX:XX",
+					"bond_mode":             "802.3ad",
+					"bond_links":            []string{"eth0"},
+					"bond_miimon":           100,
+					"bond_xmit_hash_policy": "layer3+4",
+					"bond_lacp_rate":        "fast",
+				},
+			},
+		}),
+		Entry("Bond, unknown link", testCaseRenderNetworkLinks{
+			links: infrav1.NetworkDataLink{
+				Bonds: []infrav1.NetworkDataLinkBond{
+					{
+						BondMode: "802.3ad",
+						Id:       "bond0",
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+						BondLinks: []string{"eth0"},
+					},
+				},
+			},
+			expectError: true,
+		}),
 		Entry("Bond, MAC error", testCaseRenderNetworkLinks{
 			links: infrav1.NetworkDataLink{
+				Ethernets: []infrav1.NetworkDataLinkEthernet{
+					{
+						Type: "phy",
+						Id:   "eth0",
+						MTU:  1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+					},
+				},
 				Bonds: []infrav1.NetworkDataLinkBond{
 					{
 						BondMode: "802.3ad",
@@ -1796,6 +2090,16 @@ var _ = Describe("Metal3Data manager", func() {
 		}),
 		Entry("Vlan, MAC from string", testCaseRenderNetworkLinks{
 			links: infrav1.NetworkDataLink{
+				Ethernets: []infrav1.NetworkDataLinkEthernet{
+					{
+						Type: "phy",
+						Id:   "eth0",
+						MTU:  1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+					},
+				},
 				Vlans: []infrav1.NetworkDataLinkVlan{
 					{
 						VlanID: 2222,
@@ -1809,6 +2113,12 @@ var _ = Describe("Metal3Data manager", func() {
 				},
 			},
 			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"type":                 "phy",
+					"id":                   "eth0",
+					"mtu":                  1500,
+					"ethernet_mac_address": "XX:XX:XX:XX:XX:XX",
+				},
 				map[string]interface{}{
 					"vlan_mac_address": "XX:XX:XX:XX:XX:XX",
 					"vlan_id":          2222,
@@ -1819,8 +2129,33 @@ var _ = Describe("Metal3Data manager", func() {
 				},
 			},
 		}),
+		Entry("Vlan, unknown parent link", testCaseRenderNetworkLinks{
+			links: infrav1.NetworkDataLink{
+				Vlans: []infrav1.NetworkDataLinkVlan{
+					{
+						VlanID: 2222,
+						Id:     "vlan0",
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+						VlanLink: "eth0",
+					},
+				},
+			},
+			expectError: true,
+		}),
 		Entry("Vlan, MAC error", testCaseRenderNetworkLinks{
 			links: infrav1.NetworkDataLink{
+				Ethernets: []infrav1.NetworkDataLinkEthernet{
+					{
+						Type: "phy",
+						Id:   "eth0",
+						MTU:  1500,
+						MACAddress: &infrav1.NetworkLinkEthernetMac{
+							String: pointer.StringPtr("XX:XX:XX:XX:XX:XX"),
+						},
+					},
+				},
 				Vlans: []infrav1.NetworkDataLinkVlan{
 					{
 						VlanID: 2222,
@@ -1841,6 +2176,174 @@ var _ = Describe("Metal3Data manager", func() {
 		}),
 	)
 
+	hostWithNICs := &bmov1alpha1.BareMetalHost{
+		Status: bmov1alpha1.BareMetalHostStatus{
+			HardwareDetails: &bmov1alpha1.HardwareDetails{
+				NIC: []bmov1alpha1.NIC{
+					{
+						Name:      "eno2",
+						MAC:       "00:00:00:00:00:02",
+						Model:     "0x8086 0x1572",
+						SpeedGbps: 10,
+					},
+					{
+						Name:      "eno1",
+						MAC:       "00:00:00:00:00:01",
+						Model:     "0x8086 0x1572",
+						SpeedGbps: 10,
+						PXE:       true,
+						IP:        "192.168.0.10",
+					},
+					{
+						Name:      "eno1",
+						MAC:       "00:00:00:00:00:01",
+						Model:     "0x8086 0x1572",
+						SpeedGbps: 10,
+						PXE:       true,
+						IP:        "2001::10",
+					},
+					{
+						Name:      "ens1f0",
+						MAC:       "00:00:00:00:00:03",
+						Model:     "0x15b3 0x1015",
+						SpeedGbps: 25,
+					},
+				},
+			},
+		},
+	}
+
+	type testCaseGetEthernetsFromHost struct {
+		selectors      []infrav1.NetworkDataLinkEthernetsFromHost
+		bmh            *bmov1alpha1.BareMetalHost
+		expectError    bool
+		expectedLinks  map[string]string
+		expectedLength int
+	}
+
+	DescribeTable("Test getEthernetsFromHost",
+		func(tc testCaseGetEthernetsFromHost) {
+			result, err := getEthernetsFromHost(tc.selectors, tc.bmh)
+			if tc.expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(HaveLen(tc.expectedLength))
+			for _, link := range result {
+				Expect(link.Type).To(Equal("phy"))
+				Expect(*link.MACAddress.String).To(Equal(tc.expectedLinks[link.Id]))
+			}
+		},
+		Entry("No selector", testCaseGetEthernetsFromHost{
+			expectedLength: 0,
+		}),
+		Entry("No hardware details", testCaseGetEthernetsFromHost{
+			selectors: []infrav1.NetworkDataLinkEthernetsFromHost{
+				{IDPrefix: "nic"},
+			},
+			bmh:         &bmov1alpha1.BareMetalHost{},
+			expectError: true,
+		}),
+		Entry("All NICs, sorted by name and deduplicated", testCaseGetEthernetsFromHost{
+			selectors: []infrav1.NetworkDataLinkEthernetsFromHost{
+				{IDPrefix: "nic"},
+			},
+			bmh: hostWithNICs,
+			expectedLinks: map[string]string{
+				"nic0": "00:00:00:00:00:01",
+				"nic1": "00:00:00:00:00:02",
+				"nic2": "00:00:00:00:00:03",
+			},
+			expectedLength: 3,
+		}),
+		Entry("Selectors", testCaseGetEthernetsFromHost{
+			selectors: []infrav1.NetworkDataLinkEthernetsFromHost{
+				{
+					IDPrefix: "pxe",
+					NICSelector: infrav1.NetworkDataNICSelector{
+						PXE: pointer.BoolPtr(true),
+					},
+				},
+				{
+					IDPrefix: "data",
+					NICSelector: infrav1.NetworkDataNICSelector{
+						NameRegex:    pointer.StringPtr("^en"),
+						Vendor:       pointer.StringPtr("0x8086"),
+						MinSpeedGbps: pointer.IntPtr(10),
+					},
+				},
+				{
+					IDPrefix: "fast",
+					NICSelector: infrav1.NetworkDataNICSelector{
+						Model: pointer.StringPtr("0x15b3 0x1015"),
+					},
+				},
+			},
+			bmh: hostWithNICs,
+			expectedLinks: map[string]string{
+				"pxe0":  "00:00:00:00:00:01",
+				"data0": "00:00:00:00:00:02",
+				"fast0": "00:00:00:00:00:03",
+			},
+			expectedLength: 3,
+		}),
+		Entry("Invalid regex", testCaseGetEthernetsFromHost{
+			selectors: []infrav1.NetworkDataLinkEthernetsFromHost{
+				{
+					IDPrefix: "nic",
+					NICSelector: infrav1.NetworkDataNICSelector{
+						NameRegex: pointer.StringPtr("eno[0-9"),
+					},
+				},
+			},
+			bmh:         hostWithNICs,
+			expectError: true,
+		}),
+	)
+
+	It("Renders the links generated from the NICs", func() {
+		result, err := renderNetworkLinks(infrav1.NetworkDataLink{
+			EthernetsFromHost: []infrav1.NetworkDataLinkEthernetsFromHost{
+				{
+					IDPrefix: "nic",
+					MTU:      9000,
+					NICSelector: infrav1.NetworkDataNICSelector{
+						MinSpeedGbps: pointer.IntPtr(25),
+					},
+				},
+			},
+			Vlans: []infrav1.NetworkDataLinkVlan{
+				{
+					VlanID: 100,
+					Id:     "vlan100",
+					MTU:    9000,
+					MACAddress: &infrav1.NetworkLinkEthernetMac{
+						String: pointer.StringPtr("00:00:00:00:00:03"),
+					},
+					VlanLink: "nic0",
+				},
+			},
+		}, hostWithNICs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal([]interface{}{
+			map[string]interface{}{
+				"type":                 "phy",
+				"id":                   "nic0",
+				"mtu":                  9000,
+				"ethernet_mac_address": "00:00:00:00:00:03",
+			},
+			map[string]interface{}{
+				"type":             "vlan",
+				"id":               "vlan100",
+				"mtu":              9000,
+				"vlan_mac_address": "00:00:00:00:00:03",
+				"vlan_id":          100,
+				"vlan_link":        "nic0",
+			},
+		}))
+	})
+
 	type testCaseRenderNetworkNetworks struct {
 		networks       infrav1.NetworkDataNetwork
 		m3d            *infrav1.Metal3Data
@@ -1918,6 +2421,79 @@ var _ = Describe("Metal3Data manager", func() {
 				},
 			},
 		}),
+		Entry("IPv4 network with routing table and rule", testCaseRenderNetworkNetworks{
+			poolAddresses: map[string]addressFromPool{
+				"abc": {
+					address: ipamv1.IPAddressStr("192.168.0.14"),
+					prefix:  24,
+					gateway: ipamv1.IPAddressStr("192.168.0.1"),
+				},
+			},
+			networks: infrav1.NetworkDataNetwork{
+				IPv4: []infrav1.NetworkDataIPv4{
+					{
+						ID:                  "abc",
+						Link:                "def",
+						IPAddressFromIPPool: "abc",
+						Routes: []infrav1.NetworkDataRoutev4{
+							{
+								Network: "0.0.0.0",
+								Gateway: infrav1.NetworkGatewayv4{
+									FromIPPool: pointer.StringPtr("abc"),
+								},
+								Metric: pointer.IntPtr(200),
+								Table:  pointer.IntPtr(100),
+							},
+						},
+						Rules: []infrav1.NetworkDataRule{
+							{
+								Table:    100,
+								Priority: pointer.IntPtr(1000),
+							},
+							{
+								From:  pointer.StringPtr("192.168.0.0/24"),
+								Table: 100,
+							},
+						},
+					},
+				},
+			},
+			m3d: &infrav1.Metal3Data{
+				Spec: infrav1.Metal3DataSpec{
+					Index: 2,
+				},
+			},
+			expectedOutput: []interface{}{
+				map[string]interface{}{
+					"ip_address": ipamv1.IPAddressv4Str("192.168.0.14"),
+					"routes": []interface{}{
+						map[string]interface{}{
+							"network":  ipamv1.IPAddressv4Str("0.0.0.0"),
+							"netmask":  ipamv1.IPAddressv4Str("0.0.0.0"),
+							"gateway":  ipamv1.IPAddressv4Str("192.168.0.1"),
+							"services": []interface{}{},
+							"metric":   200,
+							"table":    100,
+						},
+					},
+					"rules": []interface{}{
+						map[string]interface{}{
+							"from":     "192.168.0.14/32",
+							"table":    100,
+							"priority": 1000,
+						},
+						map[string]interface{}{
+							"from":  "192.168.0.0/24",
+							"table": 100,
+						},
+					},
+					"type":    "ipv4",
+					"id":      "abc",
+					"link":    "def",
+					"netmask": ipamv1.IPAddressv4Str("255.255.255.0"),
+				},
+			},
+		}),
 		Entry("IPv4 network, error", testCaseRenderNetworkNetworks{
 			networks: infrav1.NetworkDataNetwork{
 				IPv4: []infrav1.NetworkDataIPv4{
@@ -2407,7 +2983,7 @@ var _ = Describe("Metal3Data manager", func() {
 	DescribeTable("Test renderMetaData",
 		func(tc testCaseRenderMetaData) {
 			resultBytes, err := renderMetaData(tc.m3d, tc.m3dt, tc.m3m, tc.machine,
-				tc.bmh, tc.poolAddresses,
+				nil, tc.bmh, tc.poolAddresses, nil,
 			)
 			if tc.expectError {
 				Expect(err).To(HaveOccurred())
@@ -2524,6 +3100,12 @@ var _ = Describe("Metal3Data manager", func() {
 								Interface: "eth1",
 							},
 						},
+						FromHardwareDetails: []infrav1.MetaDataFromHardwareDetails{
+							{
+								Key:   "CPUs-1",
+								Field: infrav1.HardwareDetailsCPUCount,
+							},
+						},
 						FromLabels: []infrav1.MetaDataFromLabel{
 							{
 								Key:    "Label-1",
@@ -2667,6 +3249,7 @@ var _ = Describe("Metal3Data manager", func() {
 				"Prefix-2":     "25",
 				"Prefix-3":     "26",
 				"Mac-1":        "XX:XX:XX:XX:XX:YY",
+				"CPUs-1":       "0",
 				"Label-1":      "",
 				"Label-2":      "",
 				"Label-3":      "Metal3MachineLabel",
@@ -2832,6 +3415,190 @@ var _ = Describe("Metal3Data manager", func() {
 		}),
 	)
 
+	type testCaseGetMetaDataFromSources struct {
+		metaData       *infrav1.MetaData
+		expectedValues map[string]string
+		expectError    bool
+	}
+
+	DescribeTable("Test getMetaDataFromSources",
+		func(tc testCaseGetMetaDataFromSources) {
+			objects := []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: testObjectMeta("site", namespaceName, ""),
+					Data:       map[string]string{"ntpServers": "pool.ntp.org"},
+				},
+				&corev1.Secret{
+					ObjectMeta: testObjectMeta("join", namespaceName, ""),
+					Data:       map[string][]byte{"token": []byte("abcdef")},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			dataMgr, err := NewDataManager(fakeClient, &infrav1.Metal3Data{},
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			m3dt := &infrav1.Metal3DataTemplate{
+				ObjectMeta: testObjectMeta(metal3DataTemplateName, namespaceName, m3dtuid),
+				Spec:       infrav1.Metal3DataTemplateSpec{MetaData: tc.metaData},
+			}
+			values, err := dataMgr.getMetaDataFromSources(context.TODO(), m3dt)
+			if tc.expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(tc.expectedValues))
+		},
+		Entry("No metadata", testCaseGetMetaDataFromSources{
+			expectedValues: map[string]string{},
+		}),
+		Entry("ConfigMap and Secret", testCaseGetMetaDataFromSources{
+			metaData: &infrav1.MetaData{
+				FromConfigMaps: []infrav1.MetaDataFromConfigMap{
+					{Key: "ntp", Name: "site", DataKey: "ntpServers"},
+				},
+				FromSecrets: []infrav1.MetaDataFromSecret{
+					{Key: "token", Name: "join", DataKey: "token"},
+				},
+			},
+			expectedValues: map[string]string{"ntp": "pool.ntp.org", "token": "abcdef"},
+		}),
+		Entry("Missing ConfigMap", testCaseGetMetaDataFromSources{
+			metaData: &infrav1.MetaData{
+				FromConfigMaps: []infrav1.MetaDataFromConfigMap{
+					{Key: "ntp", Name: "other", DataKey: "ntpServers"},
+				},
+			},
+			expectError: true,
+		}),
+		Entry("Missing Secret key", testCaseGetMetaDataFromSources{
+			metaData: &infrav1.MetaData{
+				FromSecrets: []infrav1.MetaDataFromSecret{
+					{Key: "token", Name: "join", DataKey: "other"},
+				},
+			},
+			expectError: true,
+		}),
+	)
+
+	DescribeTable("Test rerenderMetaDataSources",
+		func(metaData *infrav1.MetaData, expected bool) {
+			Expect(rerenderMetaDataSources(&infrav1.Metal3DataTemplate{
+				Spec: infrav1.Metal3DataTemplateSpec{MetaData: metaData},
+			})).To(Equal(expected))
+		},
+		Entry("No metadata", nil, false),
+		Entry("No sources", &infrav1.MetaData{
+			SourcesUpdatePolicy: pointer.StringPtr(infrav1.MetaDataSourcesUpdatePolicyRerender),
+		}, false),
+		Entry("Default policy", &infrav1.MetaData{
+			FromSecrets: []infrav1.MetaDataFromSecret{{Key: "token", Name: "join", DataKey: "token"}},
+		}, false),
+		Entry("Rerender", &infrav1.MetaData{
+			FromSecrets:         []infrav1.MetaDataFromSecret{{Key: "token", Name: "join", DataKey: "token"}},
+			SourcesUpdatePolicy: pointer.StringPtr(infrav1.MetaDataSourcesUpdatePolicyRerender),
+		}, true),
+	)
+
+	hardwareDetailsHost := &bmov1alpha1.BareMetalHost{
+		Spec: bmov1alpha1.BareMetalHostSpec{
+			RootDeviceHints: &bmov1alpha1.RootDeviceHints{
+				DeviceName:       "/dev/sda",
+				MinSizeGigabytes: 100,
+			},
+		},
+		Status: bmov1alpha1.BareMetalHostStatus{
+			HardwareDetails: &bmov1alpha1.HardwareDetails{
+				CPU: bmov1alpha1.CPU{
+					Arch:           "x86_64",
+					Model:          "Intel(R) Xeon(R) Gold 6230",
+					ClockMegahertz: 2100.5,
+					Count:          40,
+				},
+				RAMMebibytes: 196608,
+				SystemVendor: bmov1alpha1.HardwareSystemVendor{
+					Manufacturer: "Dell Inc.",
+					ProductName:  "PowerEdge R640",
+					SerialNumber: "ABC123",
+				},
+				Firmware: bmov1alpha1.Firmware{
+					BIOS: bmov1alpha1.BIOS{
+						Vendor:  "Dell Inc.",
+						Version: "2.10.2",
+						Date:    "02/24/2021",
+					},
+				},
+				Storage: []bmov1alpha1.Storage{
+					{
+						Name:         "/dev/sda",
+						Model:        "PERC H730P",
+						SerialNumber: "6d0946606d",
+						SizeBytes:    479559942144,
+						Type:         bmov1alpha1.SSD,
+						HCTL:         "0:2:0:0",
+					},
+				},
+			},
+		},
+	}
+
+	DescribeTable("Test getBMHHardwareDetail",
+		func(bmh *bmov1alpha1.BareMetalHost, field string, expected interface{}, expectError bool) {
+			result, err := getBMHHardwareDetail(field, bmh)
+			if expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry("No hardware details", &bmov1alpha1.BareMetalHost{},
+			infrav1.HardwareDetailsCPUModel, nil, true,
+		),
+		Entry("Unknown field", hardwareDetailsHost, "gpuModel", nil, true),
+		Entry("CPU arch", hardwareDetailsHost, infrav1.HardwareDetailsCPUArch, "x86_64", false),
+		Entry("CPU model", hardwareDetailsHost, infrav1.HardwareDetailsCPUModel,
+			"Intel(R) Xeon(R) Gold 6230", false,
+		),
+		Entry("CPU count", hardwareDetailsHost, infrav1.HardwareDetailsCPUCount, "40", false),
+		Entry("CPU clock", hardwareDetailsHost, infrav1.HardwareDetailsCPUClockMegahertz, "2100.5", false),
+		Entry("RAM", hardwareDetailsHost, infrav1.HardwareDetailsRAMMebibytes, "196608", false),
+		Entry("System manufacturer", hardwareDetailsHost, infrav1.HardwareDetailsSystemManufacturer,
+			"Dell Inc.", false,
+		),
+		Entry("System product name", hardwareDetailsHost, infrav1.HardwareDetailsSystemProductName,
+			"PowerEdge R640", false,
+		),
+		Entry("System serial number", hardwareDetailsHost, infrav1.HardwareDetailsSystemSerialNumber,
+			"ABC123", false,
+		),
+		Entry("Firmware vendor", hardwareDetailsHost, infrav1.HardwareDetailsFirmwareVendor, "Dell Inc.", false),
+		Entry("Firmware version", hardwareDetailsHost, infrav1.HardwareDetailsFirmwareVersion, "2.10.2", false),
+		Entry("Firmware date", hardwareDetailsHost, infrav1.HardwareDetailsFirmwareDate, "02/24/2021", false),
+		Entry("Disks", hardwareDetailsHost, infrav1.HardwareDetailsDisks, []interface{}{
+			map[string]interface{}{
+				"name":         "/dev/sda",
+				"model":        "PERC H730P",
+				"serialNumber": "6d0946606d",
+				"sizeBytes":    int64(479559942144),
+				"type":         "SSD",
+				"rotational":   false,
+				"wwn":          "",
+				"hctl":         "0:2:0:0",
+			},
+		}, false),
+		Entry("Root device hints", hardwareDetailsHost, infrav1.HardwareDetailsRootDeviceHints,
+			map[string]interface{}{
+				"deviceName":       "/dev/sda",
+				"minSizeGigabytes": int64(100),
+			}, false,
+		),
+		Entry("No root device hints", &bmov1alpha1.BareMetalHost{},
+			infrav1.HardwareDetailsRootDeviceHints, map[string]interface{}{}, false,
+		),
+	)
+
 	type testCaseGetBMHMacByName struct {
 		bmh         *bmov1alpha1.BareMetalHost
 		name        string
@@ -19,9 +19,11 @@ package baremetal
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/go-logr/logr"
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +31,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -46,15 +50,17 @@ type DataTemplateManagerInterface interface {
 // DataTemplateManager is responsible for performing machine reconciliation.
 type DataTemplateManager struct {
 	client       client.Client
+	recorder     record.EventRecorder
 	DataTemplate *infrav1.Metal3DataTemplate
 	Log          logr.Logger
 }
 
 // NewDataTemplateManager returns a new helper for managing a dataTemplate object.
-func NewDataTemplateManager(client client.Client,
+func NewDataTemplateManager(client client.Client, recorder record.EventRecorder,
 	dataTemplate *infrav1.Metal3DataTemplate, dataTemplateLog logr.Logger) (*DataTemplateManager, error) {
 	return &DataTemplateManager{
 		client:       client,
+		recorder:     recorder,
 		DataTemplate: dataTemplate,
 		Log:          dataTemplateLog,
 	}, nil
@@ -176,6 +182,10 @@ func (m *DataTemplateManager) UpdateDatas(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
+	if err := m.pruneHostIndexes(ctx); err != nil {
+		return 0, err
+	}
+
 	// get list of Metal3DataClaim objects
 	dataClaimObjects := infrav1.Metal3DataClaimList{}
 	// without this ListOption, all namespaces would be including in the listing
@@ -206,9 +216,92 @@ func (m *DataTemplateManager) UpdateDatas(ctx context.Context) (int, error) {
 		}
 	}
 	m.updateStatusTimestamp()
+
+	if err := m.rerenderDatas(ctx); err != nil {
+		return len(indexes), err
+	}
 	return len(indexes), nil
 }
 
+// rerenderDatas renders again the secrets of the Metal3Data rendered from an
+// older generation of a mutable template, RerenderBatchSize at a time, in name
+// order. It requeues while some Metal3Data are outdated.
+func (m *DataTemplateManager) rerenderDatas(ctx context.Context) error {
+	if !m.DataTemplate.Spec.Mutable || !m.DataTemplate.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	batchSize := 1
+	if m.DataTemplate.Spec.RerenderBatchSize != nil {
+		batchSize = *m.DataTemplate.Spec.RerenderBatchSize
+	}
+
+	dataObjects := infrav1.Metal3DataList{}
+	opts := &client.ListOptions{
+		Namespace: m.DataTemplate.Namespace,
+	}
+	if err := m.client.List(ctx, &dataObjects, opts); err != nil {
+		return err
+	}
+
+	// The Metal3Data not rendered yet will be rendered from the current
+	// generation by the Metal3Data controller.
+	outdated := []infrav1.Metal3Data{}
+	for _, dataObject := range dataObjects.Items {
+		if dataObject.Spec.Template.Name != m.DataTemplate.Name ||
+			!dataObject.DeletionTimestamp.IsZero() || !dataObject.Status.Ready {
+			continue
+		}
+		if dataObject.Status.TemplateGeneration >= m.DataTemplate.Generation {
+			continue
+		}
+		outdated = append(outdated, dataObject)
+	}
+	if len(outdated) == 0 {
+		return nil
+	}
+	sort.Slice(outdated, func(i, j int) bool {
+		return outdated[i].Name < outdated[j].Name
+	})
+
+	for i := range outdated {
+		if i == batchSize {
+			m.Log.Info("Waiting to render the next Metal3Data", "outdated", len(outdated)-batchSize)
+			return &RequeueAfterError{RequeueAfter: requeueAfter}
+		}
+		if err := m.rerenderData(ctx, &outdated[i]); err != nil {
+			return errors.Wrapf(err, "failed to render again Metal3Data %s", outdated[i].Name)
+		}
+	}
+	return nil
+}
+
+// rerenderData renders again the secrets of a Metal3Data.
+func (m *DataTemplateManager) rerenderData(ctx context.Context, dataObject *infrav1.Metal3Data) error {
+	helper, err := patch.NewHelper(dataObject, m.client)
+	if err != nil {
+		return errors.Wrap(err, "failed to init patch helper")
+	}
+	// The owner references of the secrets are built from the type.
+	dataObject.TypeMeta = metav1.TypeMeta{
+		APIVersion: infrav1.GroupVersion.String(),
+		Kind:       "Metal3Data",
+	}
+	dataMgr, err := NewDataManager(m.client, dataObject,
+		m.Log.WithValues("metal3-data", dataObject.Name),
+	)
+	if err != nil {
+		return err
+	}
+	m.Log.Info("Rendering Metal3Data again", "metal3-data", dataObject.Name)
+	if err := dataMgr.rerenderSecrets(ctx); err != nil {
+		return err
+	}
+	m.recorder.Eventf(m.DataTemplate, corev1.EventTypeNormal, "DataRerendered",
+		"Rendered the secrets of Metal3Data %s from generation %d", dataObject.Name, m.DataTemplate.Generation,
+	)
+	return helper.Patch(ctx, dataObject)
+}
+
 func (m *DataTemplateManager) updateData(ctx context.Context,
 	dataClaim *infrav1.Metal3DataClaim, indexes map[int]string,
 ) (map[int]string, error) {
@@ -285,16 +378,16 @@ func (m *DataTemplateManager) createData(ctx context.Context,
 
 	// Get a new index for this machine
 	m.Log.Info("Getting index", "Claim", dataClaim.Name)
-	claimIndex := len(indexes)
-	// The length of the map might be smaller than the highest index stored,
-	// this means we have a gap to find
-	for index := 0; index < len(indexes); index++ {
-		if _, ok := indexes[index]; !ok {
-			if claimIndex == len(indexes) {
-				claimIndex = index
-				break
-			}
-		}
+	hostName, err := m.getHostName(ctx, m3mName)
+	if err != nil {
+		return indexes, err
+	}
+	claimIndex, err := m.allocateIndex(hostName, indexes)
+	if err != nil {
+		// Do not block the other claims, the index may be released later.
+		m.Log.Info("Failed to allocate an index", "Claim", dataClaim.Name, "error", err.Error())
+		dataClaim.Status.ErrorMessage = pointer.StringPtr(err.Error())
+		return indexes, nil
 	}
 
 	// Set the index and Metal3Data names
@@ -364,6 +457,12 @@ func (m *DataTemplateManager) createData(ctx context.Context,
 
 	m.DataTemplate.Status.Indexes[dataClaim.Name] = claimIndex
 	indexes[claimIndex] = dataClaim.Name
+	if hostName != "" && m.DataTemplate.Spec.IndexAllocation.Policy == infrav1.IndexAllocationPolicyHostSticky {
+		if m.DataTemplate.Status.HostIndexes == nil {
+			m.DataTemplate.Status.HostIndexes = make(map[string]int)
+		}
+		m.DataTemplate.Status.HostIndexes[hostName] = claimIndex
+	}
 
 	dataClaim.Status.RenderedData = &corev1.ObjectReference{
 		Name:      dataName,
@@ -373,6 +472,121 @@ func (m *DataTemplateManager) createData(ctx context.Context,
 	return indexes, nil
 }
 
+// getHostName returns the name of the BareMetalHost of the Metal3Machine. It
+// returns an empty string if the index allocation does not depend on the
+// BareMetalHosts, and requeues if the Metal3Machine is not associated with
+// one yet, so that the index is not allocated without the host.
+func (m *DataTemplateManager) getHostName(ctx context.Context, m3mName string) (string, error) {
+	allocation := m.DataTemplate.Spec.IndexAllocation
+	if allocation == nil || (allocation.Policy != infrav1.IndexAllocationPolicyHostSticky &&
+		len(allocation.Reservations) == 0) {
+		return "", nil
+	}
+	m3m, err := getM3Machine(ctx, m.client, m.Log, m3mName, m.DataTemplate.Namespace, nil, true)
+	if err != nil {
+		return "", err
+	}
+	hostKey, ok := m3m.Annotations[HostAnnotation]
+	if !ok {
+		m.Log.Info("Waiting for the Metal3Machine to be associated with a BareMetalHost",
+			"Metal3Machine", m3mName,
+		)
+		return "", &RequeueAfterError{RequeueAfter: requeueAfter}
+	}
+	_, hostName, err := cache.SplitMetaNamespaceKey(hostKey)
+	if err != nil {
+		return "", err
+	}
+	return hostName, nil
+}
+
+// pruneHostIndexes removes the indexes recorded for the BareMetalHosts that
+// do not exist anymore, so that their indexes can be allocated again.
+func (m *DataTemplateManager) pruneHostIndexes(ctx context.Context) error {
+	if len(m.DataTemplate.Status.HostIndexes) == 0 {
+		return nil
+	}
+	hosts := bmov1alpha1.BareMetalHostList{}
+	opts := &client.ListOptions{
+		Namespace: m.DataTemplate.Namespace,
+	}
+	if err := m.client.List(ctx, &hosts, opts); err != nil {
+		return err
+	}
+	existingHosts := make(map[string]bool, len(hosts.Items))
+	for _, host := range hosts.Items {
+		existingHosts[host.Name] = true
+	}
+	for hostName := range m.DataTemplate.Status.HostIndexes {
+		if !existingHosts[hostName] {
+			m.Log.Info("Releasing the index of a deleted BareMetalHost", "host", hostName)
+			delete(m.DataTemplate.Status.HostIndexes, hostName)
+		}
+	}
+	return nil
+}
+
+// allocateIndex returns the index of a new Metal3Data for the BareMetalHost.
+// The index reserved for the host is allocated first, then with the
+// HostSticky policy the index last allocated to the host, and otherwise the
+// lowest index that is neither used nor reserved.
+func (m *DataTemplateManager) allocateIndex(hostName string, indexes map[int]string) (int, error) {
+	allocation := m.DataTemplate.Spec.IndexAllocation
+	if allocation == nil {
+		allocation = &infrav1.IndexAllocation{}
+	}
+	sticky := allocation.Policy == infrav1.IndexAllocationPolicyHostSticky
+
+	if hostName != "" {
+		for _, reservation := range allocation.Reservations {
+			if reservation.HostName != hostName {
+				continue
+			}
+			if claimName, ok := indexes[reservation.Index]; ok {
+				return 0, errors.Errorf("index %d reserved for BareMetalHost %s is used by claim %s",
+					reservation.Index, hostName, claimName,
+				)
+			}
+			return reservation.Index, nil
+		}
+	}
+
+	isFree := func(index int) bool {
+		if _, ok := indexes[index]; ok {
+			return false
+		}
+		for _, reservation := range allocation.Reservations {
+			if reservation.Index == index {
+				return false
+			}
+		}
+		for _, reservedRange := range allocation.ReservedRanges {
+			if reservedRange.Contains(index) {
+				return false
+			}
+		}
+		if sticky {
+			for name, hostIndex := range m.DataTemplate.Status.HostIndexes {
+				if hostIndex == index && name != hostName {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	if sticky && hostName != "" {
+		if index, ok := m.DataTemplate.Status.HostIndexes[hostName]; ok && isFree(index) {
+			return index, nil
+		}
+	}
+	index := 0
+	for !isFree(index) {
+		index++
+	}
+	return index, nil
+}
+
 // DeleteDatas deletes old secrets.
 func (m *DataTemplateManager) deleteData(ctx context.Context,
 	dataClaim *infrav1.Metal3DataClaim, indexes map[int]string,
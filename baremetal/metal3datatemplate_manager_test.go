@@ -26,9 +26,11 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -39,7 +41,7 @@ var timeNow = metav1.Now()
 var _ = Describe("Metal3DataTemplate manager", func() {
 	DescribeTable("Test Finalizers",
 		func(template *infrav1.Metal3DataTemplate) {
-			templateMgr, err := NewDataTemplateManager(nil, template,
+			templateMgr, err := NewDataTemplateManager(nil, &record.FakeRecorder{}, template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -72,7 +74,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 
 	DescribeTable("Test SetClusterOwnerRef",
 		func(tc testCaseSetClusterOwnerRef) {
-			templateMgr, err := NewDataTemplateManager(nil, tc.template,
+			templateMgr, err := NewDataTemplateManager(nil, &record.FakeRecorder{}, tc.template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -147,7 +149,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 				objects = append(objects, address)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			templateMgr, err := NewDataTemplateManager(fakeClient, tc.template,
+			templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, tc.template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -254,7 +256,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 				objects = append(objects, claim)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			templateMgr, err := NewDataTemplateManager(fakeClient, tc.template,
+			templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, tc.template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -448,7 +450,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 				objects = append(objects, tc.dataObject)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			templateMgr, err := NewDataTemplateManager(fakeClient, tc.template2,
+			templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, tc.template2,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -615,7 +617,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 				objects = append(objects, address)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			templateMgr, err := NewDataTemplateManager(fakeClient, tc.template,
+			templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, tc.template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -746,6 +748,208 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 		}),
 	)
 
+	type testCaseAllocateIndex struct {
+		allocation    *infrav1.IndexAllocation
+		hostIndexes   map[string]int
+		hostName      string
+		indexes       map[int]string
+		expectedIndex int
+		expectError   bool
+	}
+
+	DescribeTable("Test allocateIndex",
+		func(tc testCaseAllocateIndex) {
+			templateMgr, err := NewDataTemplateManager(nil, &record.FakeRecorder{},
+				&infrav1.Metal3DataTemplate{
+					ObjectMeta: templateMeta,
+					Spec:       infrav1.Metal3DataTemplateSpec{IndexAllocation: tc.allocation},
+					Status:     infrav1.Metal3DataTemplateStatus{HostIndexes: tc.hostIndexes},
+				},
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			index, err := templateMgr.allocateIndex(tc.hostName, tc.indexes)
+			if tc.expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(index).To(Equal(tc.expectedIndex))
+		},
+		Entry("Lowest free by default", testCaseAllocateIndex{
+			hostName:      "host-0",
+			indexes:       map[int]string{0: "abc", 2: "bcd"},
+			expectedIndex: 1,
+		}),
+		Entry("Reserved index", testCaseAllocateIndex{
+			allocation: &infrav1.IndexAllocation{
+				Reservations: []infrav1.IndexReservation{{HostName: "host-0", Index: 5}},
+			},
+			hostName:      "host-0",
+			indexes:       map[int]string{0: "abc"},
+			expectedIndex: 5,
+		}),
+		Entry("Reserved index in use", testCaseAllocateIndex{
+			allocation: &infrav1.IndexAllocation{
+				Reservations: []infrav1.IndexReservation{{HostName: "host-0", Index: 0}},
+			},
+			hostName:    "host-0",
+			indexes:     map[int]string{0: "abc"},
+			expectError: true,
+		}),
+		Entry("Index reserved for another host", testCaseAllocateIndex{
+			allocation: &infrav1.IndexAllocation{
+				Reservations: []infrav1.IndexReservation{{HostName: "host-1", Index: 0}},
+			},
+			hostName:      "host-0",
+			indexes:       map[int]string{},
+			expectedIndex: 1,
+		}),
+		Entry("Reserved ranges", testCaseAllocateIndex{
+			allocation: &infrav1.IndexAllocation{
+				ReservedRanges: []infrav1.IndexRange{{Start: 0, End: 1}, {Start: 3, End: 4}},
+			},
+			indexes:       map[int]string{2: "abc"},
+			expectedIndex: 5,
+		}),
+		Entry("Reserved index in a reserved range", testCaseAllocateIndex{
+			allocation: &infrav1.IndexAllocation{
+				Reservations:   []infrav1.IndexReservation{{HostName: "host-0", Index: 1}},
+				ReservedRanges: []infrav1.IndexRange{{Start: 0, End: 9}},
+			},
+			hostName:      "host-0",
+			expectedIndex: 1,
+		}),
+		Entry("Sticky index", testCaseAllocateIndex{
+			allocation:    &infrav1.IndexAllocation{Policy: infrav1.IndexAllocationPolicyHostSticky},
+			hostIndexes:   map[string]int{"host-0": 3, "host-1": 0},
+			hostName:      "host-0",
+			indexes:       map[int]string{},
+			expectedIndex: 3,
+		}),
+		Entry("Sticky index in use", testCaseAllocateIndex{
+			allocation:    &infrav1.IndexAllocation{Policy: infrav1.IndexAllocationPolicyHostSticky},
+			hostIndexes:   map[string]int{"host-0": 3, "host-1": 0},
+			hostName:      "host-0",
+			indexes:       map[int]string{3: "abc"},
+			expectedIndex: 1,
+		}),
+		Entry("New host skips the sticky indexes", testCaseAllocateIndex{
+			allocation:    &infrav1.IndexAllocation{Policy: infrav1.IndexAllocationPolicyHostSticky},
+			hostIndexes:   map[string]int{"host-0": 0, "host-1": 1},
+			hostName:      "host-2",
+			indexes:       map[int]string{},
+			expectedIndex: 2,
+		}),
+		Entry("Sticky indexes ignored by the LowestFree policy", testCaseAllocateIndex{
+			allocation:    &infrav1.IndexAllocation{Policy: infrav1.IndexAllocationPolicyLowestFree},
+			hostIndexes:   map[string]int{"host-0": 0},
+			hostName:      "host-2",
+			indexes:       map[int]string{},
+			expectedIndex: 0,
+		}),
+	)
+
+	It("Records the index of the host with the HostSticky policy", func() {
+		template := &infrav1.Metal3DataTemplate{
+			ObjectMeta: templateMeta,
+			Spec: infrav1.Metal3DataTemplateSpec{
+				IndexAllocation: &infrav1.IndexAllocation{
+					Policy:       infrav1.IndexAllocationPolicyHostSticky,
+					Reservations: []infrav1.IndexReservation{{HostName: "host-1", Index: 0}},
+				},
+			},
+			Status: infrav1.Metal3DataTemplateStatus{
+				Indexes:     map[string]int{},
+				HostIndexes: map[string]int{"host-0": 2},
+			},
+		}
+		m3m := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        metal3machineName,
+				Namespace:   namespaceName,
+				Annotations: map[string]string{HostAnnotation: namespaceName + "/host-0"},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(m3m).Build()
+		templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, template,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		dataClaim := &infrav1.Metal3DataClaim{
+			ObjectMeta: testObjectMetaWithOR(metal3DataClaimName, metal3machineName),
+		}
+		indexes, err := templateMgr.createData(context.TODO(), dataClaim, map[int]string{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexes).To(Equal(map[int]string{2: metal3DataClaimName}))
+		Expect(dataClaim.Status.RenderedData.Name).To(Equal("abc-2"))
+		Expect(template.Status.HostIndexes).To(Equal(map[string]int{"host-0": 2}))
+	})
+
+	It("Requeues until the Metal3Machine is associated with a host", func() {
+		template := &infrav1.Metal3DataTemplate{
+			ObjectMeta: templateMeta,
+			Spec: infrav1.Metal3DataTemplateSpec{
+				IndexAllocation: &infrav1.IndexAllocation{
+					Policy: infrav1.IndexAllocationPolicyHostSticky,
+				},
+			},
+			Status: infrav1.Metal3DataTemplateStatus{
+				Indexes: map[string]int{},
+			},
+		}
+		m3m := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      metal3machineName,
+				Namespace: namespaceName,
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(m3m).Build()
+		templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, template,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		dataClaim := &infrav1.Metal3DataClaim{
+			ObjectMeta: testObjectMetaWithOR(metal3DataClaimName, metal3machineName),
+		}
+		indexes, err := templateMgr.createData(context.TODO(), dataClaim, map[int]string{})
+		Expect(err).To(BeAssignableToTypeOf(&RequeueAfterError{}))
+		Expect(indexes).To(BeEmpty())
+		Expect(dataClaim.Status.RenderedData).To(BeNil())
+	})
+
+	It("Prunes the indexes of the deleted hosts", func() {
+		template := &infrav1.Metal3DataTemplate{
+			ObjectMeta: templateMeta,
+			Spec: infrav1.Metal3DataTemplateSpec{
+				IndexAllocation: &infrav1.IndexAllocation{
+					Policy: infrav1.IndexAllocationPolicyHostSticky,
+				},
+			},
+			Status: infrav1.Metal3DataTemplateStatus{
+				HostIndexes: map[string]int{"host-0": 0, "host-1": 1},
+			},
+		}
+		host := &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "host-0",
+				Namespace: namespaceName,
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(host).Build()
+		templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, template,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = templateMgr.UpdateDatas(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(template.Status.HostIndexes).To(Equal(map[string]int{"host-0": 0}))
+	})
+
 	type testCaseDeleteDatas struct {
 		template        *infrav1.Metal3DataTemplate
 		dataClaim       *infrav1.Metal3DataClaim
@@ -763,7 +967,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 				objects = append(objects, address)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			templateMgr, err := NewDataTemplateManager(fakeClient, tc.template,
+			templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, tc.template,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -853,4 +1057,120 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 		}),
 	)
 
+	// mutableTemplateObjects returns the objects of a Metal3Data rendered from
+	// the first generation of the template, with its claim, Metal3Machine,
+	// Machine, BareMetalHost and metadata secret.
+	mutableTemplateObjects := func(index int) []client.Object {
+		suffix := "-" + strconv.Itoa(index)
+		return []client.Object{
+			&infrav1.Metal3Data{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metal3DataName + suffix,
+					Namespace: namespaceName,
+				},
+				Spec: infrav1.Metal3DataSpec{
+					Index:    index,
+					Template: corev1.ObjectReference{Name: metal3DataTemplateName, Namespace: namespaceName},
+					Claim:    corev1.ObjectReference{Name: metal3DataClaimName + suffix},
+					MetaData: &corev1.SecretReference{Name: metal3machineName + suffix + "-metadata"},
+				},
+				Status: infrav1.Metal3DataStatus{Ready: true, TemplateGeneration: 1},
+			},
+			&infrav1.Metal3DataClaim{
+				ObjectMeta: testObjectMetaWithOR(metal3DataClaimName+suffix, metal3machineName+suffix),
+			},
+			&infrav1.Metal3Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      metal3machineName + suffix,
+					Namespace: namespaceName,
+					OwnerReferences: []metav1.OwnerReference{{
+						Name:       machineName + suffix,
+						Kind:       "Machine",
+						APIVersion: clusterv1.GroupVersion.String(),
+					}},
+					Annotations: map[string]string{
+						HostAnnotation: namespaceName + "/" + baremetalhostName + suffix,
+					},
+				},
+				Spec: infrav1.Metal3MachineSpec{
+					DataTemplate: &corev1.ObjectReference{Name: metal3DataTemplateName},
+				},
+			},
+			&clusterv1.Machine{
+				ObjectMeta: testObjectMeta(machineName+suffix, namespaceName, ""),
+			},
+			&bmov1alpha1.BareMetalHost{
+				ObjectMeta: testObjectMeta(baremetalhostName+suffix, namespaceName, ""),
+				Spec: bmov1alpha1.BareMetalHostSpec{
+					ConsumerRef: &corev1.ObjectReference{
+						Name:      metal3machineName + suffix,
+						Namespace: namespaceName,
+						Kind:      "Metal3Machine",
+					},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: testObjectMeta(metal3machineName+suffix+"-metadata", namespaceName, ""),
+				Data:       map[string][]byte{"metaData": []byte("String-1: old\n")},
+			},
+		}
+	}
+
+	It("Renders the outdated Metal3Data again, one batch at a time", func() {
+		template := &infrav1.Metal3DataTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       metal3DataTemplateName,
+				Namespace:  namespaceName,
+				Generation: 2,
+			},
+			Spec: infrav1.Metal3DataTemplateSpec{
+				Mutable: true,
+				MetaData: &infrav1.MetaData{
+					Strings: []infrav1.MetaDataString{{Key: "String-1", Value: "new"}},
+				},
+			},
+		}
+		objects := []client.Object{template}
+		objects = append(objects, mutableTemplateObjects(0)...)
+		objects = append(objects, mutableTemplateObjects(1)...)
+		fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
+		templateMgr, err := NewDataTemplateManager(fakeClient, &record.FakeRecorder{}, template,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		expectRendered := func(index int, rendered bool) {
+			suffix := "-" + strconv.Itoa(index)
+			m3d := &infrav1.Metal3Data{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKey{
+				Name: metal3DataName + suffix, Namespace: namespaceName,
+			}, m3d)).To(Succeed())
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKey{
+				Name: metal3machineName + suffix + "-metadata", Namespace: namespaceName,
+			}, secret)).To(Succeed())
+			if rendered {
+				Expect(m3d.Status.TemplateGeneration).To(Equal(int64(2)))
+				Expect(string(secret.Data["metaData"])).To(HavePrefix("String-1: new\n"))
+			} else {
+				Expect(m3d.Status.TemplateGeneration).To(Equal(int64(1)))
+				Expect(string(secret.Data["metaData"])).To(Equal("String-1: old\n"))
+			}
+		}
+
+		err = templateMgr.rerenderDatas(context.TODO())
+		Expect(err).To(BeAssignableToTypeOf(&RequeueAfterError{}))
+		expectRendered(0, true)
+		expectRendered(1, false)
+
+		Expect(templateMgr.rerenderDatas(context.TODO())).To(Succeed())
+		expectRendered(1, true)
+
+		// An immutable template does not render the Metal3Data again.
+		template.Generation = 3
+		template.Spec.Mutable = false
+		Expect(templateMgr.rerenderDatas(context.TODO())).To(Succeed())
+		expectRendered(0, true)
+	})
+
 })
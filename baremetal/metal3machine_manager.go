@@ -44,6 +44,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -106,7 +107,8 @@ type MachineManagerInterface interface {
 
 // MachineManager is responsible for performing machine reconciliation.
 type MachineManager struct {
-	client client.Client
+	client   client.Client
+	recorder record.EventRecorder
 
 	Cluster               *clusterv1.Cluster
 	Metal3Cluster         *infrav1.Metal3Cluster
@@ -120,12 +122,13 @@ type MachineManager struct {
 }
 
 // NewMachineManager returns a new helper for managing a machine.
-func NewMachineManager(client client.Client,
+func NewMachineManager(client client.Client, recorder record.EventRecorder,
 	cluster *clusterv1.Cluster, metal3Cluster *infrav1.Metal3Cluster,
 	machine *clusterv1.Machine, metal3machine *infrav1.Metal3Machine,
 	machineLog logr.Logger) (*MachineManager, error) {
 	return &MachineManager{
-		client: client,
+		client:   client,
+		recorder: recorder,
 
 		Cluster:       cluster,
 		Metal3Cluster: metal3Cluster,
@@ -136,11 +139,12 @@ func NewMachineManager(client client.Client,
 }
 
 // NewMachineSetManager returns a new helper for managing a machineset.
-func NewMachineSetManager(client client.Client,
+func NewMachineSetManager(client client.Client, recorder record.EventRecorder,
 	machine *clusterv1.Machine, machineSetList *clusterv1.MachineSetList,
 	machineLog logr.Logger) (*MachineManager, error) {
 	return &MachineManager{
 		client:         client,
+		recorder:       recorder,
 		Machine:        machine,
 		MachineSetList: machineSetList,
 		Log:            machineLog,
@@ -211,8 +215,12 @@ func (m *MachineManager) RemovePauseAnnotation(ctx context.Context) error {
 	if annotations != nil {
 		if _, ok := annotations[bmov1alpha1.PausedAnnotation]; ok {
 			if m.Cluster.Name == host.Labels[clusterv1.ClusterLabelName] && annotations[bmov1alpha1.PausedAnnotation] == PausedAnnotationKey {
+				if err := m.checkStatusSnapshot(host); err != nil {
+					return err
+				}
 				// Removing BMH Paused Annotation Since Owner Cluster is not paused.
 				delete(host.Annotations, bmov1alpha1.PausedAnnotation)
+				delete(host.Annotations, StatusSnapshotAnnotation)
 			} else if m.Cluster.Name == host.Labels[clusterv1.ClusterLabelName] && annotations[bmov1alpha1.PausedAnnotation] != PausedAnnotationKey {
 				m.Log.Info("BMH is paused by user. Not removing Pause Annotation")
 				return nil
@@ -264,9 +272,61 @@ func (m *MachineManager) SetPauseAnnotation(ctx context.Context) error {
 	delete(obj, "hardware")
 	newAnnotation, _ = json.Marshal(obj)
 	host.Annotations[bmov1alpha1.StatusAnnotation] = string(newAnnotation)
+
+	// Setting annotation with the BMH status snapshot, hardware included
+	snapshot, err := newStatusSnapshot(&host.Status, statusSnapshotCompressed())
+	if err != nil {
+		m.SetError("Failed to snapshot the BareMetalHost status",
+			capierrors.UpdateMachineError,
+		)
+		return err
+	}
+	host.Annotations[StatusSnapshotAnnotation] = snapshot
 	return helper.Patch(ctx, host)
 }
 
+// checkStatusSnapshot compares the status snapshot taken when pausing the
+// BareMetalHost with its live status, before unpausing it. The hardware
+// details missing from the live status, as after a move, are restored from the
+// snapshot. The BareMetalHost stays paused while its status is not restored
+// from the status annotation yet, if the snapshot is invalid or if the live
+// status does not identify the same provisioned host. The other changes of the
+// status are only reported. Removing the snapshot annotation skips the check.
+func (m *MachineManager) checkStatusSnapshot(host *bmov1alpha1.BareMetalHost) error {
+	annotation, ok := host.Annotations[StatusSnapshotAnnotation]
+	if !ok {
+		return nil
+	}
+	if _, ok := host.Annotations[bmov1alpha1.StatusAnnotation]; ok && host.Status.LastUpdated == nil {
+		m.Log.Info("Waiting for the BareMetalHost status to be restored from the status annotation")
+		return &RequeueAfterError{RequeueAfter: requeueAfter}
+	}
+	snapshot, err := parseStatusSnapshot(annotation)
+	if err != nil {
+		m.recordEvent(host, corev1.EventTypeWarning, "InvalidStatusSnapshot",
+			"Not unpausing the BareMetalHost, invalid status snapshot: %v", err,
+		)
+		return &RequeueAfterError{RequeueAfter: requeueAfter}
+	}
+	identityDrift, otherDrift := statusSnapshotDrift(snapshot, &host.Status)
+	if len(identityDrift) > 0 {
+		m.recordEvent(host, corev1.EventTypeWarning, "StatusSnapshotDrift",
+			"Not unpausing the BareMetalHost, status drifted from the snapshot: %s", strings.Join(identityDrift, ", "),
+		)
+		return &RequeueAfterError{RequeueAfter: requeueAfter}
+	}
+	if len(otherDrift) > 0 {
+		m.recordEvent(host, corev1.EventTypeWarning, "StatusSnapshotChanged",
+			"Unpausing the BareMetalHost, status changed since the snapshot: %s", strings.Join(otherDrift, ", "),
+		)
+	}
+	if host.Status.HardwareDetails == nil && snapshot.HardwareDetails != nil {
+		m.Log.Info("Restoring the BareMetalHost hardware details from the status snapshot")
+		host.Status.HardwareDetails = snapshot.HardwareDetails
+	}
+	return nil
+}
+
 // GetBaremetalHostID return the provider identifier for this machine.
 func (m *MachineManager) GetBaremetalHostID(ctx context.Context) (*string, error) {
 	// look for associated BMH
@@ -324,13 +384,28 @@ func (m *MachineManager) Associate(ctx context.Context) error {
 		}
 		if host == nil {
 			m.Log.Info("No available host found. Requeuing.")
+			chooseHostMissesTotal.WithLabelValues(m.Metal3Machine.Namespace).Inc()
+			// chooseHost already failed if the host selector is invalid.
+			selector, _ := hostSelectorToLabelSelector(m.Metal3Machine.Spec.HostSelector)
+			if selector == nil || selector.Empty() {
+				m.recordEvent(nil, corev1.EventTypeWarning, "NoAvailableHost", "No available host")
+			} else {
+				m.recordEvent(nil, corev1.EventTypeWarning, "NoAvailableHost",
+					"No available host matching selector %q", selector.String(),
+				)
+			}
 			return &RequeueAfterError{RequeueAfter: requeueAfter}
 		}
 		m.Log.Info("Associating machine with host", "host", host.Name)
+		m.recordEvent(host, corev1.EventTypeNormal, "AssociatedHost",
+			"Associated host %s with Metal3Machine %s", host.Name, m.Metal3Machine.Name,
+		)
 	} else {
 		m.Log.Info("Machine already associated with host", "host", host.Name)
 	}
 
+	m.setFailureDomain(host)
+
 	// A machine bootstrap not ready case is caught in the controller
 	// ReconcileNormal function
 	err = m.getUserDataSecretName(ctx, host)
@@ -589,6 +664,9 @@ func (m *MachineManager) Delete(ctx context.Context) error {
 			}
 
 			m.Log.Info("Deprovisioning BaremetalHost, requeuing")
+			m.recordEvent(host, corev1.EventTypeNormal, "DeprovisioningHost",
+				"Deprovisioning host %s", host.Name,
+			)
 			return &RequeueAfterError{}
 		}
 
@@ -714,6 +792,9 @@ func (m *MachineManager) Delete(ctx context.Context) error {
 		if err := patchIfFound(ctx, helper, host); err != nil {
 			return err
 		}
+		m.recordEvent(host, corev1.EventTypeNormal, "ReleasedHost",
+			"Released host %s from Metal3Machine %s", host.Name, m.Metal3Machine.Name,
+		)
 	}
 
 	m.Log.Info("finished deleting metal3 machine")
@@ -756,15 +837,21 @@ func (m *MachineManager) Update(ctx context.Context) error {
 		return err
 	}
 
-	// ensure that the BMH specs are correctly set.
-	err = m.setHostSpec(ctx, host)
-	if err != nil {
-		if ok := errors.As(err, &hasRequeueAfterError); !ok {
-			m.SetError("Failed to associate the BaremetalHost to the Metal3Machine",
-				capierrors.CreateMachineError,
-			)
+	// deprovision the host if its image must be upgraded.
+	deprovisioning := m.reconcileImageUpgrade(host)
+
+	// ensure that the BMH specs are correctly set, unless the host is being
+	// deprovisioned to be provisioned again with a new image.
+	if !deprovisioning {
+		err = m.setHostSpec(ctx, host)
+		if err != nil {
+			if ok := errors.As(err, &hasRequeueAfterError); !ok {
+				m.SetError("Failed to associate the BaremetalHost to the Metal3Machine",
+					capierrors.CreateMachineError,
+				)
+			}
+			return err
 		}
-		return err
 	}
 
 	err = helper.Patch(ctx, host)
@@ -862,29 +949,22 @@ func (m *MachineManager) chooseHost(ctx context.Context) (*bmov1alpha1.BareMetal
 
 	// Using the label selector on ListOptions above doesn't seem to work.
 	// I think it's because we have a local cache of all BareMetalHosts.
-	labelSelector := labels.NewSelector()
-	var reqs labels.Requirements
-
-	for labelKey, labelVal := range m.Metal3Machine.Spec.HostSelector.MatchLabels {
-		m.Log.Info("Adding requirement to match label",
-			"label key", labelKey,
-			"label value", labelVal)
-		r, err := labels.NewRequirement(labelKey, selection.Equals, []string{labelVal})
-		if err != nil {
-			m.Log.Error(err, "Failed to create MatchLabel requirement, not choosing host")
-			return nil, nil, err
-		}
-		reqs = append(reqs, *r)
+	labelSelector, err := hostSelectorToLabelSelector(m.Metal3Machine.Spec.HostSelector)
+	if err != nil {
+		m.Log.Error(err, "Failed to create the host selector, not choosing host")
+		return nil, nil, err
 	}
-	for _, req := range m.Metal3Machine.Spec.HostSelector.MatchExpressions {
-		m.Log.Info("Adding requirement to match label",
-			"label key", req.Key,
-			"label operator", req.Operator,
-			"label value", req.Values)
-		lowercaseOperator := selection.Operator(strings.ToLower(string(req.Operator)))
-		r, err := labels.NewRequirement(req.Key, lowercaseOperator, req.Values)
+	m.Log.Info("Using host selector", "selector", labelSelector.String())
+	var reqs labels.Requirements
+	// Only consider the hosts of the failure domain requested by the Machine.
+	fdLabel := m.failureDomainLabel()
+	if fdLabel != "" && m.Machine != nil && m.Machine.Spec.FailureDomain != nil && *m.Machine.Spec.FailureDomain != "" {
+		m.Log.Info("Adding requirement to match failure domain",
+			"label key", fdLabel,
+			"failure domain", *m.Machine.Spec.FailureDomain)
+		r, err := labels.NewRequirement(fdLabel, selection.Equals, []string{*m.Machine.Spec.FailureDomain})
 		if err != nil {
-			m.Log.Error(err, "Failed to create MatchExpression requirement, not choosing host")
+			m.Log.Error(err, "Failed to create failure domain requirement, not choosing host")
 			return nil, nil, err
 		}
 		reqs = append(reqs, *r)
@@ -977,17 +1057,40 @@ func (m *MachineManager) chooseHost(ctx context.Context) (*bmov1alpha1.BareMetal
 		}
 	} else {
 		// If there are no hosts with nodeReuseLabelName, fall back
-		// to the current flow and select hosts randomly.
-		m.Log.Info("host(s) count available, choosing a random host", "availabeHostCount", len(availableHosts))
-		rHost, _ := rand.Int(rand.Reader, big.NewInt(int64(len(availableHosts))))
-		randomHost := rHost.Int64()
-		chosenHost = availableHosts[randomHost]
+		// to the current flow and select hosts according to the host
+		// selection policy.
+		chosenHost, err = m.selectHost(ctx, availableHosts, hosts.Items)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	helper, err := patch.NewHelper(chosenHost, m.client)
 	return chosenHost, helper, err
 }
 
+// failureDomainLabel returns the key of the BareMetalHost label holding the
+// failure domain of the hosts, or an empty string if the Metal3Cluster does
+// not define failure domains.
+func (m *MachineManager) failureDomainLabel() string {
+	if m.Metal3Cluster == nil {
+		return ""
+	}
+	return m.Metal3Cluster.Spec.FailureDomainLabel
+}
+
+// setFailureDomain sets the failure domain of the host on the Metal3Machine,
+// so that it can be reported on the Machine by Cluster API.
+func (m *MachineManager) setFailureDomain(host *bmov1alpha1.BareMetalHost) {
+	fdLabel := m.failureDomainLabel()
+	if fdLabel == "" {
+		return
+	}
+	if failureDomain, ok := host.Labels[fdLabel]; ok {
+		m.Metal3Machine.Spec.FailureDomain = pointer.StringPtr(failureDomain)
+	}
+}
+
 // consumerRefMatches returns a boolean based on whether the consumer
 // reference and bare metal machine metadata match.
 func consumerRefMatches(consumer *corev1.ObjectReference, m3machine *infrav1.Metal3Machine) bool {
@@ -1107,15 +1210,20 @@ func (m *MachineManager) setHostSpec(ctx context.Context, host *bmov1alpha1.Bare
 	// We only want to update the image setting if the host does not
 	// already have an image.
 	//
-	// A host with an existing image is already provisioned and
-	// upgrades are not supported at this time. To re-provision a
-	// host, we must fully deprovision it and then provision it again.
-	// Not provisioning while we do not have the UserData.
-	if host.Spec.Image == nil && m.Metal3Machine.Status.UserData != nil {
+	// A host with an existing image is already provisioned. To upgrade
+	// its image, reconcileImageUpgrade deprovisions the host first, and it is
+	// then provisioned again here with the new image.
+	// Not provisioning while we do not have the UserData, nor while the host
+	// is being deprovisioned, for example by the remediation controller.
+	if host.Spec.Image == nil && host.Status.Provisioning.Image.URL == "" &&
+		m.Metal3Machine.Status.UserData != nil {
 		checksumType := ""
 		if m.Metal3Machine.Spec.Image.ChecksumType != nil {
 			checksumType = *m.Metal3Machine.Spec.Image.ChecksumType
 		}
+		m.recordEvent(host, corev1.EventTypeNormal, "ProvisioningHost",
+			"Provisioning host %s with image %s", host.Name, m.Metal3Machine.Spec.Image.URL,
+		)
 		host.Spec.Image = &bmov1alpha1.Image{
 			URL:          m.Metal3Machine.Spec.Image.URL,
 			Checksum:     m.Metal3Machine.Spec.Image.Checksum,
@@ -1140,6 +1248,17 @@ func (m *MachineManager) setHostSpec(ctx context.Context, host *bmov1alpha1.Bare
 		if host.Spec.NetworkData != nil && host.Spec.NetworkData.Namespace == "" {
 			host.Spec.NetworkData.Namespace = m.Machine.Namespace
 		}
+
+		// The Ignition network data is merged into the user data, the host
+		// does not get any network data.
+		ignitionUserData, err := m.mergeIgnitionUserData(ctx)
+		if err != nil {
+			return err
+		}
+		if ignitionUserData != nil {
+			host.Spec.UserData = ignitionUserData
+			host.Spec.NetworkData = nil
+		}
 	}
 	// Set automatedCleaningMode from metal3Machine.spec.automatedCleaningMode.
 	if m.Metal3Machine.Spec.AutomatedCleaningMode != nil {
@@ -1153,6 +1272,147 @@ func (m *MachineManager) setHostSpec(ctx context.Context, host *bmov1alpha1.Bare
 	return nil
 }
 
+// mergeIgnitionUserData creates a secret containing an Ignition config merging
+// the user data and the network data, when the network data was rendered in
+// the Ignition format. It returns the reference to that secret, or nil if the
+// network data is in a different format.
+func (m *MachineManager) mergeIgnitionUserData(ctx context.Context) (*corev1.SecretReference, error) {
+	if m.Metal3Machine.Status.NetworkData == nil || m.Metal3Machine.Status.UserData == nil {
+		return nil, nil
+	}
+	namespace := m.Metal3Machine.Status.NetworkData.Namespace
+	if namespace == "" {
+		namespace = m.Machine.Namespace
+	}
+	networkDataSecret, err := checkSecretExists(ctx, m.client,
+		m.Metal3Machine.Status.NetworkData.Name, namespace,
+	)
+	if apierrors.IsNotFound(err) {
+		// Without the secret, the format of the network data is unknown.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get the network data secret")
+	}
+	if string(networkDataSecret.Data[networkDataFormatKey]) != infrav1.NetworkDataFormatIgnition {
+		return nil, nil
+	}
+
+	namespace = m.Metal3Machine.Status.UserData.Namespace
+	if namespace == "" {
+		namespace = m.Metal3Machine.Namespace
+	}
+	userDataSecret, err := checkSecretExists(ctx, m.client,
+		m.Metal3Machine.Status.UserData.Name, namespace,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the user data secret")
+	}
+	// The bootstrap providers set the value and the format keys, while the
+	// user data secrets given to the BareMetalHost use the userData key.
+	userData, ok := userDataSecret.Data["userData"]
+	if !ok {
+		userData, ok = userDataSecret.Data["value"]
+	}
+	if !ok {
+		return nil, errors.Errorf("user data secret %s has neither a userData nor a value key",
+			userDataSecret.Name,
+		)
+	}
+	if format, ok := userDataSecret.Data["format"]; ok && string(format) != bootstrapFormatIgnition {
+		return nil, errors.Errorf("user data of format %s can not be merged with Ignition network data", format)
+	}
+
+	mergedUserData, err := mergeIgnitionConfigs(userData, networkDataSecret.Data["networkData"])
+	if err != nil {
+		return nil, err
+	}
+	name := m.Metal3Machine.Name + "-ignition-userdata"
+	ownerRefs := []metav1.OwnerReference{
+		{
+			APIVersion: m.Metal3Machine.APIVersion,
+			Kind:       m.Metal3Machine.Kind,
+			Name:       m.Metal3Machine.Name,
+			UID:        m.Metal3Machine.UID,
+			Controller: pointer.BoolPtr(true),
+		},
+	}
+	if err := createSecret(ctx, m.client, name, m.Metal3Machine.Namespace,
+		m.Machine.Spec.ClusterName, ownerRefs,
+		map[string][]byte{"userData": mergedUserData},
+	); err != nil {
+		return nil, err
+	}
+	return &corev1.SecretReference{Name: name, Namespace: m.Metal3Machine.Namespace}, nil
+}
+
+// reconcileImageUpgrade deprovisions the host when the image of the
+// Metal3Machine changed and the ImageUpgradeStrategy is Reprovision, and
+// reports the progress of the upgrade in the ImageUpgradedCondition. It returns
+// true while the host is being deprovisioned. Once the host is deprovisioned,
+// setHostSpec sets the new image and the host is provisioned again, keeping
+// its consumerRef, user data, metadata and network data.
+func (m *MachineManager) reconcileImageUpgrade(host *bmov1alpha1.BareMetalHost) bool {
+	if m.Metal3Machine.Spec.ImageUpgradeStrategy == nil ||
+		*m.Metal3Machine.Spec.ImageUpgradeStrategy != infrav1.ImageUpgradeStrategyReprovision {
+		return false
+	}
+
+	switch {
+	case host.Spec.Image != nil && !imageMatches(host.Spec.Image, &m.Metal3Machine.Spec.Image):
+		// Removing the image triggers the deprovisioning of the host.
+		m.Log.Info("Deprovisioning host to upgrade its image", "host", host.Name,
+			"current image", host.Spec.Image.URL, "new image", m.Metal3Machine.Spec.Image.URL,
+		)
+		host.Spec.Image = nil
+		conditions.MarkFalse(m.Metal3Machine, infrav1.ImageUpgradedCondition,
+			infrav1.DeprovisioningForImageUpgradeReason, clusterv1.ConditionSeverityInfo,
+			"Deprovisioning host %s", host.Name,
+		)
+		m.recordEvent(host, corev1.EventTypeNormal, "DeprovisioningHost",
+			"Deprovisioning host %s to upgrade its image to %s", host.Name, m.Metal3Machine.Spec.Image.URL,
+		)
+		return true
+	case host.Spec.Image == nil && host.Status.Provisioning.Image.URL != "":
+		m.Log.Info("Waiting for host to be deprovisioned", "host", host.Name,
+			"state", host.Status.Provisioning.State,
+		)
+		return true
+	case host.Spec.Image == nil:
+		if conditions.GetReason(m.Metal3Machine, infrav1.ImageUpgradedCondition) ==
+			infrav1.DeprovisioningForImageUpgradeReason {
+			m.Log.Info("Provisioning host with the new image", "host", host.Name,
+				"image", m.Metal3Machine.Spec.Image.URL,
+			)
+			conditions.MarkFalse(m.Metal3Machine, infrav1.ImageUpgradedCondition,
+				infrav1.ProvisioningNewImageReason, clusterv1.ConditionSeverityInfo,
+				"Provisioning host %s with image %s", host.Name, m.Metal3Machine.Spec.Image.URL,
+			)
+		}
+	default:
+		if conditions.IsFalse(m.Metal3Machine, infrav1.ImageUpgradedCondition) &&
+			host.Status.Provisioning.State == bmov1alpha1.StateProvisioned {
+			m.Log.Info("Host provisioned with the new image", "host", host.Name,
+				"image", host.Spec.Image.URL,
+			)
+			conditions.MarkTrue(m.Metal3Machine, infrav1.ImageUpgradedCondition)
+		}
+	}
+	return false
+}
+
+// imageMatches returns true if the image set on the host is the image of the
+// Metal3Machine.
+func imageMatches(hostImage *bmov1alpha1.Image, image *infrav1.Image) bool {
+	checksumType := ""
+	if image.ChecksumType != nil {
+		checksumType = *image.ChecksumType
+	}
+	return hostImage.URL == image.URL &&
+		hostImage.Checksum == image.Checksum &&
+		hostImage.ChecksumType == bmov1alpha1.ChecksumType(checksumType) &&
+		pointer.StringEqual(hostImage.DiskFormat, image.DiskFormat)
+}
+
 // setHostConsumerRef will ensure the host's Spec is set to link to this
 // Metal3Machine.
 func (m *MachineManager) setHostConsumerRef(ctx context.Context, host *bmov1alpha1.BareMetalHost) error {
@@ -1230,6 +1490,15 @@ func (m *MachineManager) hasTemplateAnnotation() bool {
 	return ok
 }
 
+// recordEvent records an event on the Metal3Machine and, if not nil, on the
+// BareMetalHost.
+func (m *MachineManager) recordEvent(host *bmov1alpha1.BareMetalHost, eventType, reason, messageFmt string, args ...interface{}) {
+	m.recorder.Eventf(m.Metal3Machine, eventType, reason, messageFmt, args...)
+	if host != nil {
+		m.recorder.Eventf(host, eventType, reason, messageFmt, args...)
+	}
+}
+
 // SetError sets the ErrorMessage and ErrorReason fields on the machine and logs
 // the message. It assumes the reason is invalid configuration, since that is
 // currently the only relevant MachineStatusError choice.
@@ -1418,6 +1687,9 @@ func (m *MachineManager) SetNodeProviderID(ctx context.Context, bmhID *string, p
 		if err != nil {
 			return errors.Wrap(err, "unable to update the target node with providerID")
 		}
+		m.recordEvent(nil, corev1.EventTypeNormal, "SetNodeProviderID",
+			"Set providerID %s on node %s", nodeVar.Spec.ProviderID, nodeVar.Name,
+		)
 	}
 	m.Log.Info("ProviderID set on target node")
 	return nil
@@ -1426,6 +1698,9 @@ func (m *MachineManager) SetNodeProviderID(ctx context.Context, bmhID *string, p
 // SetProviderID sets the metal3 provider ID on the Metal3Machine.
 func (m *MachineManager) SetProviderID(providerID string) {
 	m.Metal3Machine.Spec.ProviderID = &providerID
+	if !m.Metal3Machine.Status.Ready {
+		observeMetal3MachineReady(m.Metal3Machine, m.Machine)
+	}
 	m.Metal3Machine.Status.Ready = true
 	m.SetConditionMetal3MachineToTrue(infrav1.KubernetesNodeReadyCondition)
 }
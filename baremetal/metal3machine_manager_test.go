@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -39,10 +40,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientfake "k8s.io/client-go/kubernetes/fake"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -317,7 +320,7 @@ func bmhStatus() *bmov1alpha1.BareMetalHostStatus {
 var _ = Describe("Metal3Machine manager", func() {
 	DescribeTable("Test Finalizers",
 		func(bmMachine infrav1.Metal3Machine) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &bmMachine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &bmMachine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -344,7 +347,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test SetProviderID",
 		func(bmMachine infrav1.Metal3Machine) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &bmMachine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &bmMachine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -372,7 +375,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test IsProvisioned",
 		func(tc testCaseProvisioned) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &tc.M3Machine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -421,7 +424,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test BootstrapReady",
 		func(tc testCaseBootstrapReady) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, &tc.Machine, nil,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, &tc.Machine, nil,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -446,7 +449,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test setting and clearing errors",
 		func(bmMachine infrav1.Metal3Machine) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &bmMachine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &bmMachine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -639,7 +642,7 @@ var _ = Describe("Metal3Machine manager", func() {
 					objects = append(objects, tc.M3Machine)
 				}
 				fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 					tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -834,7 +837,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				tc.Host,
 				tc.M3Machine).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, nil, tc.M3Machine, logr.Discard())
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, nil, tc.M3Machine, logr.Discard())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = machineMgr.SetPauseAnnotation(context.TODO())
@@ -875,6 +878,9 @@ var _ = Describe("Metal3Machine manager", func() {
 				Expect(err).To(BeNil())
 				annotation, _ = json.Marshal(obj)
 				Expect(status).To(Equal(string(annotation)))
+				snapshot, err := parseStatusSnapshot(savedHost.Annotations[StatusSnapshotAnnotation])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(*snapshot).To(Equal(tc.Host.Status))
 			} else {
 				Expect(statusPresent).To(BeFalse())
 			}
@@ -937,11 +943,39 @@ var _ = Describe("Metal3Machine manager", func() {
 	)
 
 	type testCaseRemovePauseAnnotation struct {
-		Cluster       *clusterv1.Cluster
-		M3Machine     *infrav1.Metal3Machine
-		Host          *bmov1alpha1.BareMetalHost
-		ExpectPresent bool
-		ExpectError   bool
+		Cluster          *clusterv1.Cluster
+		M3Machine        *infrav1.Metal3Machine
+		Host             *bmov1alpha1.BareMetalHost
+		ExpectPresent    bool
+		ExpectError      bool
+		ExpectedHardware *bmov1alpha1.HardwareDetails
+	}
+
+	pausedHostWithSnapshot := func(status bmov1alpha1.BareMetalHostStatus, snapshotStatus bmov1alpha1.BareMetalHostStatus) *bmov1alpha1.BareMetalHost {
+		host := &bmov1alpha1.BareMetalHost{
+			ObjectMeta: *bmhObjectMetaWithValidCAPM3PausedAnnotations(),
+			Spec: bmov1alpha1.BareMetalHostSpec{
+				ConsumerRef: &corev1.ObjectReference{
+					Name:       metal3machineName,
+					Namespace:  namespaceName,
+					Kind:       "M3Machine",
+					APIVersion: infrav1.GroupVersion.String(),
+				},
+			},
+			Status: status,
+		}
+		snapshot, err := newStatusSnapshot(&snapshotStatus, true)
+		Expect(err).NotTo(HaveOccurred())
+		host.Annotations[StatusSnapshotAnnotation] = snapshot
+		return host
+	}
+	lastUpdated := metav1.Now()
+	snapshotStatus := bmov1alpha1.BareMetalHostStatus{
+		Provisioning: bmov1alpha1.ProvisionStatus{
+			State: bmov1alpha1.StateProvisioned,
+			ID:    "abc",
+		},
+		HardwareDetails: &bmov1alpha1.HardwareDetails{RAMMebibytes: 16384},
 	}
 
 	DescribeTable("Test Remove BMH Pause Annotation",
@@ -950,7 +984,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				tc.Host,
 				tc.M3Machine,
 				tc.Cluster).Build()
-			machineMgr, err := NewMachineManager(fakeClient,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{},
 				tc.Cluster,
 				nil,
 				nil,
@@ -980,7 +1014,65 @@ var _ = Describe("Metal3Machine manager", func() {
 			} else {
 				Expect(savedHost.Annotations).To(BeNil())
 			}
+			Expect(savedHost.Status.HardwareDetails).To(Equal(tc.ExpectedHardware))
 		},
+		Entry("Remove BMH Pause Annotation, restoring the hardware details from the status snapshot", testCaseRemovePauseAnnotation{
+			Cluster: newCluster(clusterName),
+			Host: pausedHostWithSnapshot(bmov1alpha1.BareMetalHostStatus{
+				LastUpdated: &lastUpdated,
+				Provisioning: bmov1alpha1.ProvisionStatus{
+					State: bmov1alpha1.StateProvisioned,
+					ID:    "abc",
+				},
+			}, snapshotStatus),
+			M3Machine: newMetal3Machine(metal3machineName, nil, m3mSpec(), nil,
+				m3mObjectMetaWithValidAnnotations()),
+			ExpectPresent:    false,
+			ExpectError:      false,
+			ExpectedHardware: &bmov1alpha1.HardwareDetails{RAMMebibytes: 16384},
+		}),
+		Entry("Do not Remove Annotation, status drifted from the snapshot", testCaseRemovePauseAnnotation{
+			Cluster: newCluster(clusterName),
+			Host: pausedHostWithSnapshot(bmov1alpha1.BareMetalHostStatus{
+				LastUpdated: &lastUpdated,
+				Provisioning: bmov1alpha1.ProvisionStatus{
+					State: bmov1alpha1.StateProvisioned,
+					ID:    "def",
+				},
+			}, snapshotStatus),
+			M3Machine: newMetal3Machine(metal3machineName, nil, m3mSpec(), nil,
+				m3mObjectMetaWithValidAnnotations()),
+			ExpectPresent: true,
+			ExpectError:   true,
+		}),
+		Entry("Remove BMH Pause Annotation, provisioning state changed since the snapshot", testCaseRemovePauseAnnotation{
+			Cluster: newCluster(clusterName),
+			Host: pausedHostWithSnapshot(bmov1alpha1.BareMetalHostStatus{
+				LastUpdated: &lastUpdated,
+				Provisioning: bmov1alpha1.ProvisionStatus{
+					State: bmov1alpha1.StateDeprovisioning,
+					ID:    "abc",
+				},
+				PoweredOn: true,
+			}, snapshotStatus),
+			M3Machine: newMetal3Machine(metal3machineName, nil, m3mSpec(), nil,
+				m3mObjectMetaWithValidAnnotations()),
+			ExpectPresent:    false,
+			ExpectError:      false,
+			ExpectedHardware: &bmov1alpha1.HardwareDetails{RAMMebibytes: 16384},
+		}),
+		Entry("Do not Remove Annotation, status not restored yet", testCaseRemovePauseAnnotation{
+			Cluster: newCluster(clusterName),
+			Host: func() *bmov1alpha1.BareMetalHost {
+				host := pausedHostWithSnapshot(bmov1alpha1.BareMetalHostStatus{}, snapshotStatus)
+				host.Annotations[bmov1alpha1.StatusAnnotation] = "{}"
+				return host
+			}(),
+			M3Machine: newMetal3Machine(metal3machineName, nil, m3mSpec(), nil,
+				m3mObjectMetaWithValidAnnotations()),
+			ExpectPresent: true,
+			ExpectError:   true,
+		}),
 		Entry("Remove BMH Pause Annotation, with valid CAPM3 Paused annotations", testCaseRemovePauseAnnotation{
 			Cluster: newCluster(clusterName),
 			Host: &bmov1alpha1.BareMetalHost{
@@ -1055,7 +1147,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			)
 			machine := newMachine(machineName, "", infrastructureRef)
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, machine, m3mconfig,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, machine, m3mconfig,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -1133,6 +1225,205 @@ var _ = Describe("Metal3Machine manager", func() {
 				ExpectUserData: false,
 			},
 		),
+		Entry("Being deprovisioned", testCaseSetHostSpec{
+			UserDataNamespace:         "",
+			ExpectedUserDataNamespace: namespaceName,
+			Host: newBareMetalHost("host2", &bmov1alpha1.BareMetalHostSpec{},
+				bmov1alpha1.StateDeprovisioning, &bmov1alpha1.BareMetalHostStatus{
+					Provisioning: bmov1alpha1.ProvisionStatus{
+						Image: *expectedImgTest(),
+					},
+				}, false, "metadata", false,
+			),
+			ExpectedImage:  nil,
+			ExpectUserData: false,
+		}),
+	)
+
+	It("Merges the Ignition network data into the user data", func() {
+		host := newBareMetalHost("host2", nil, bmov1alpha1.StateNone,
+			nil, false, "metadata", false,
+		)
+		userDataSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testUserDataSecretName,
+				Namespace: namespaceName,
+			},
+			Data: map[string][]byte{
+				"value":  []byte(`{"ignition":{"version":"3.3.0"}}`),
+				"format": []byte("ignition"),
+			},
+		}
+		networkDataSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testNetworkDataSecretName,
+				Namespace: namespaceName,
+			},
+			Data: map[string][]byte{
+				"networkData":        []byte(`{"ignition":{"version":"3.3.0"},"storage":{"files":[]}}`),
+				networkDataFormatKey: []byte(infrav1.NetworkDataFormatIgnition),
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(
+			host, userDataSecret, networkDataSecret,
+		).Build()
+
+		m3mconfig, infrastructureRef := newConfig("", map[string]string{},
+			[]infrav1.HostSelectorRequirement{},
+		)
+		m3mconfig.Name = metal3machineName
+		machine := newMachine(machineName, "", infrastructureRef)
+
+		machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, machine, m3mconfig,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = machineMgr.setHostSpec(context.TODO(), host)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(host.Spec.NetworkData).To(BeNil())
+		Expect(host.Spec.MetaData).NotTo(BeNil())
+		Expect(host.Spec.UserData).To(Equal(&corev1.SecretReference{
+			Name:      metal3machineName + "-ignition-userdata",
+			Namespace: namespaceName,
+		}))
+		// The status still references the bootstrap user data.
+		Expect(m3mconfig.Status.UserData.Name).To(Equal(testUserDataSecretName))
+
+		mergedSecret := corev1.Secret{}
+		err = fakeClient.Get(context.TODO(), client.ObjectKey{
+			Name:      metal3machineName + "-ignition-userdata",
+			Namespace: namespaceName,
+		}, &mergedSecret)
+		Expect(err).NotTo(HaveOccurred())
+		expected, err := mergeIgnitionConfigs(userDataSecret.Data["value"],
+			networkDataSecret.Data["networkData"],
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mergedSecret.Data["userData"]).To(Equal(expected))
+
+		// Cloud-init user data can not be merged.
+		host.Spec.Image = nil
+		userDataSecret.Data["format"] = []byte("cloud-config")
+		Expect(fakeClient.Update(context.TODO(), userDataSecret)).To(Succeed())
+		err = machineMgr.setHostSpec(context.TODO(), host)
+		Expect(err).To(HaveOccurred())
+
+		// A user data secret without user data is an error.
+		host.Spec.Image = nil
+		userDataSecret.Data = map[string][]byte{"format": []byte("ignition")}
+		Expect(fakeClient.Update(context.TODO(), userDataSecret)).To(Succeed())
+		err = machineMgr.setHostSpec(context.TODO(), host)
+		Expect(err).To(MatchError(ContainSubstring("neither a userData nor a value key")))
+	})
+
+	type testCaseReconcileImageUpgrade struct {
+		Strategy                *string
+		Host                    *bmov1alpha1.BareMetalHost
+		Conditions              clusterv1.Conditions
+		ExpectDeprovisioning    bool
+		ExpectedImage           *bmov1alpha1.Image
+		ExpectedConditionStatus corev1.ConditionStatus
+		ExpectedReason          string
+	}
+
+	DescribeTable("Test reconcileImageUpgrade",
+		func(tc testCaseReconcileImageUpgrade) {
+			m3mSpec := m3mSpecAll()
+			m3mSpec.Image.ChecksumType = nil
+			m3mSpec.ImageUpgradeStrategy = tc.Strategy
+			m3m := newMetal3Machine(metal3machineName, nil, m3mSpec,
+				&infrav1.Metal3MachineStatus{Conditions: tc.Conditions}, nil,
+			)
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, m3m,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			deprovisioning := machineMgr.reconcileImageUpgrade(tc.Host)
+			Expect(deprovisioning).To(Equal(tc.ExpectDeprovisioning))
+			Expect(tc.Host.Spec.Image).To(Equal(tc.ExpectedImage))
+
+			condition := conditions.Get(m3m, infrav1.ImageUpgradedCondition)
+			if tc.ExpectedConditionStatus == "" {
+				Expect(condition).To(BeNil())
+				return
+			}
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(tc.ExpectedConditionStatus))
+			Expect(condition.Reason).To(Equal(tc.ExpectedReason))
+		},
+		Entry("No upgrade strategy", testCaseReconcileImageUpgrade{
+			Host: newBareMetalHost("host2", bmhSpecTestImg(),
+				bmov1alpha1.StateProvisioned, &bmov1alpha1.BareMetalHostStatus{}, true, "metadata", false,
+			),
+			ExpectedImage: expectedImgTest(),
+		}),
+		Entry("None upgrade strategy", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyNone),
+			Host: newBareMetalHost("host2", bmhSpecTestImg(),
+				bmov1alpha1.StateProvisioned, &bmov1alpha1.BareMetalHostStatus{}, true, "metadata", false,
+			),
+			ExpectedImage: expectedImgTest(),
+		}),
+		Entry("Image changed, host deprovisioned", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyReprovision),
+			Host: newBareMetalHost("host2", bmhSpecTestImg(),
+				bmov1alpha1.StateProvisioned, &bmov1alpha1.BareMetalHostStatus{}, true, "metadata", false,
+			),
+			ExpectDeprovisioning:    true,
+			ExpectedConditionStatus: corev1.ConditionFalse,
+			ExpectedReason:          infrav1.DeprovisioningForImageUpgradeReason,
+		}),
+		Entry("Host still deprovisioning", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyReprovision),
+			Host: newBareMetalHost("host2", &bmov1alpha1.BareMetalHostSpec{},
+				bmov1alpha1.StateDeprovisioning, &bmov1alpha1.BareMetalHostStatus{
+					Provisioning: bmov1alpha1.ProvisionStatus{
+						Image: *expectedImgTest(),
+					},
+				}, true, "metadata", false,
+			),
+			Conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(infrav1.ImageUpgradedCondition,
+					infrav1.DeprovisioningForImageUpgradeReason, clusterv1.ConditionSeverityInfo, ""),
+			},
+			ExpectDeprovisioning:    true,
+			ExpectedConditionStatus: corev1.ConditionFalse,
+			ExpectedReason:          infrav1.DeprovisioningForImageUpgradeReason,
+		}),
+		Entry("Host deprovisioned, provisioning new image", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyReprovision),
+			Host: newBareMetalHost("host2", &bmov1alpha1.BareMetalHostSpec{},
+				bmov1alpha1.StateAvailable, &bmov1alpha1.BareMetalHostStatus{}, false, "metadata", false,
+			),
+			Conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(infrav1.ImageUpgradedCondition,
+					infrav1.DeprovisioningForImageUpgradeReason, clusterv1.ConditionSeverityInfo, ""),
+			},
+			ExpectedConditionStatus: corev1.ConditionFalse,
+			ExpectedReason:          infrav1.ProvisioningNewImageReason,
+		}),
+		Entry("Host not provisioned yet, no upgrade", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyReprovision),
+			Host: newBareMetalHost("host2", &bmov1alpha1.BareMetalHostSpec{},
+				bmov1alpha1.StateAvailable, &bmov1alpha1.BareMetalHostStatus{}, false, "metadata", false,
+			),
+		}),
+		Entry("Host provisioned with the new image", testCaseReconcileImageUpgrade{
+			Strategy: pointer.StringPtr(infrav1.ImageUpgradeStrategyReprovision),
+			Host: newBareMetalHost("host2", &bmov1alpha1.BareMetalHostSpec{
+				Image: expectedImg(),
+			}, bmov1alpha1.StateProvisioned, &bmov1alpha1.BareMetalHostStatus{}, true, "metadata", false,
+			),
+			Conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(infrav1.ImageUpgradedCondition,
+					infrav1.ProvisioningNewImageReason, clusterv1.ConditionSeverityInfo, ""),
+			},
+			ExpectedImage:           expectedImg(),
+			ExpectedConditionStatus: corev1.ConditionTrue,
+		}),
 	)
 
 	DescribeTable("Test SetHostConsumerRef",
@@ -1144,7 +1435,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			)
 			machine := newMachine(machineName, "", infrastructureRef)
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, machine, m3mconfig,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, machine, m3mconfig,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -1222,7 +1513,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 		DescribeTable("Test Exists function",
 			func(tc testCaseExists) {
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 					tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -1274,7 +1565,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 		DescribeTable("Test GetHost",
 			func(tc testCaseGetHost) {
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 					tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -1324,7 +1615,7 @@ var _ = Describe("Metal3Machine manager", func() {
 	DescribeTable("Test Get and Set Provider ID",
 		func(tc testCaseGetSetProviderID) {
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(tc.Host).Build()
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -1419,7 +1710,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 		DescribeTable("Test small functions",
 			func(tc testCaseSmallFunctions) {
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 					tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -1477,7 +1768,7 @@ var _ = Describe("Metal3Machine manager", func() {
 	DescribeTable("Test EnsureAnnotation",
 		func(tc testCaseEnsureAnnotation) {
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(tc.M3Machine).Build()
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, &tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, &tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -1587,7 +1878,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, tc.Cluster, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, tc.Cluster, nil, tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -2218,7 +2509,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			func(tc testCaseUpdateMachineStatus) {
 				fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(&tc.M3Machine).Build()
 
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 					&tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -2436,7 +2727,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				var nodeAddresses []clusterv1.MachineAddress
 
 				fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).Build()
-				machineMgr, err := NewMachineManager(fakeClient, nil, nil, &tc.Machine,
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, &tc.Machine,
 					&tc.M3Machine, logr.Discard(),
 				)
 				Expect(err).NotTo(HaveOccurred())
@@ -2509,7 +2800,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				},
 			}
 
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &m3m, logr.Discard())
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &m3m, logr.Discard())
 			Expect(err).NotTo(HaveOccurred())
 
 			providerID, bmhID := machineMgr.GetProviderIDAndBMHID()
@@ -2559,14 +2850,14 @@ var _ = Describe("Metal3Machine manager", func() {
 					return corev1Client, nil
 				}
 
-				machineMgr, err := NewMachineManager(fakeClient, newCluster(clusterName),
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, newCluster(clusterName),
 					newMetal3Cluster(metal3ClusterName, bmcOwnerRef,
 						&infrav1.Metal3ClusterSpec{NoCloudProvider: true}, nil,
 					),
 					&clusterv1.Machine{}, &infrav1.Metal3Machine{}, logr.Discard(),
 				)
 				if tc.M3MHasHostAnnotation {
-					machineMgr, err = NewMachineManager(fakeClient, newCluster(clusterName),
+					machineMgr, err = NewMachineManager(fakeClient, &record.FakeRecorder{}, newCluster(clusterName),
 						newMetal3Cluster(metal3ClusterName, bmcOwnerRef,
 							&infrav1.Metal3ClusterSpec{NoCloudProvider: true}, nil,
 						),
@@ -2660,7 +2951,7 @@ var _ = Describe("Metal3Machine manager", func() {
 					return corev1Client, nil
 				}
 
-				machineMgr, err := NewMachineManager(fakeClient, newCluster(clusterName),
+				machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, newCluster(clusterName),
 					newMetal3Cluster(metal3ClusterName, bmcOwnerRef,
 						&infrav1.Metal3ClusterSpec{NoCloudProvider: false}, nil,
 					),
@@ -2772,7 +3063,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -2948,6 +3239,7 @@ var _ = Describe("Metal3Machine manager", func() {
 		ExpectRequeue      bool
 		ExpectClusterLabel bool
 		ExpectOwnerRef     bool
+		ExpectedEvents     []string
 	}
 
 	DescribeTable("Test Associate function",
@@ -2970,7 +3262,8 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			recorder := record.NewFakeRecorder(10)
+			machineMgr, err := NewMachineManager(fakeClient, recorder, nil, nil, tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -2984,6 +3277,17 @@ var _ = Describe("Metal3Machine manager", func() {
 				Expect(err).NotTo(HaveOccurred())
 			}
 
+			close(recorder.Events)
+			reasons := []string{}
+			for event := range recorder.Events {
+				reasons = append(reasons, strings.Fields(event)[1])
+			}
+			if tc.ExpectedEvents == nil {
+				Expect(reasons).To(BeEmpty())
+			} else {
+				Expect(reasons).To(Equal(tc.ExpectedEvents))
+			}
+
 			if tc.Host == nil {
 				return
 			}
@@ -3043,6 +3347,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				BMCSecret:      newBMCSecret("mycredentials", false),
 				ExpectRequeue:  false,
 				ExpectOwnerRef: true,
+				ExpectedEvents: []string{"ProvisioningHost", "ProvisioningHost"},
 			},
 		),
 		Entry("Associate empty machine, host empty, Metal3 machine spec set",
@@ -3054,6 +3359,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				Host:           newBareMetalHost("", nil, bmov1alpha1.StateNone, nil, false, "metadata", false),
 				ExpectRequeue:  true,
 				ExpectOwnerRef: false,
+				ExpectedEvents: []string{"NoAvailableHost"},
 			},
 		),
 		Entry("Associate machine, host nil, Metal3 machine spec set, requeue",
@@ -3062,8 +3368,9 @@ var _ = Describe("Metal3Machine manager", func() {
 				M3Machine: newMetal3Machine(metal3machineName, nil, m3mSpecAll(), nil,
 					m3mObjectMetaWithValidAnnotations(),
 				),
-				Host:          nil,
-				ExpectRequeue: true,
+				Host:           nil,
+				ExpectRequeue:  true,
+				ExpectedEvents: []string{"NoAvailableHost"},
 			},
 		),
 		Entry("Associate machine, host set, Metal3 machine spec set, set clusterLabel",
@@ -3075,6 +3382,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				ExpectClusterLabel: true,
 				ExpectRequeue:      false,
 				ExpectOwnerRef:     true,
+				ExpectedEvents:     []string{"AssociatedHost", "AssociatedHost", "ProvisioningHost", "ProvisioningHost"},
 			},
 		),
 		Entry("Associate machine with DataTemplate missing",
@@ -3102,6 +3410,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				ExpectClusterLabel: true,
 				ExpectRequeue:      true,
 				ExpectOwnerRef:     true,
+				ExpectedEvents:     []string{"AssociatedHost", "AssociatedHost"},
 			},
 		),
 		Entry("Associate machine with DataTemplate and Data ready",
@@ -3148,6 +3457,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				ExpectClusterLabel: true,
 				ExpectRequeue:      false,
 				ExpectOwnerRef:     true,
+				ExpectedEvents:     []string{"AssociatedHost", "AssociatedHost", "ProvisioningHost", "ProvisioningHost"},
 			},
 		),
 	)
@@ -3168,7 +3478,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 				tc.M3Machine, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3211,7 +3521,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test FindOwnerRef",
 		func(tc testCaseFindOwnerRef) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &tc.M3Machine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3327,7 +3637,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test DeleteOwnerRef",
 		func(tc testCaseOwnerRef) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &tc.M3Machine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3401,7 +3711,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 	DescribeTable("Test SetOwnerRef",
 		func(tc testCaseOwnerRef) {
-			machineMgr, err := NewMachineManager(nil, nil, nil, nil, &tc.M3Machine,
+			machineMgr, err := NewMachineManager(nil, &record.FakeRecorder{}, nil, nil, nil, &tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3482,7 +3792,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				objects = append(objects, tc.DataClaim)
 			}
 			fakeCleint := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineManager(fakeCleint, nil, nil, tc.Machine, tc.M3Machine,
+			machineMgr, err := NewMachineManager(fakeCleint, &record.FakeRecorder{}, nil, nil, tc.Machine, tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3603,7 +3913,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				objects = append(objects, tc.Data)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine, tc.M3Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine, tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3788,7 +4098,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				objects = append(objects, tc.DataClaim)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine, tc.M3Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine, tc.M3Machine,
 				logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -3899,7 +4209,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 				nil, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -4063,7 +4373,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
 
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, nil,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, nil,
 				nil, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -4123,7 +4433,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				objects = append(objects, tc.Machine)
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineManager(fakeClient, nil, nil, tc.Machine,
+			machineMgr, err := NewMachineManager(fakeClient, &record.FakeRecorder{}, nil, nil, tc.Machine,
 				nil, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -4218,7 +4528,7 @@ var _ = Describe("Metal3Machine manager", func() {
 				}
 			}
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineSetManager(fakeClient, tc.Machine,
+			machineMgr, err := NewMachineSetManager(fakeClient, &record.FakeRecorder{}, tc.Machine,
 				tc.MachineSetList, logr.Discard(),
 			)
 			Expect(err).NotTo(HaveOccurred())
@@ -4420,7 +4730,7 @@ var _ = Describe("Metal3Machine manager", func() {
 			}
 
 			fakeClient := fake.NewClientBuilder().WithScheme(setupSchemeMm()).WithObjects(objects...).Build()
-			machineMgr, err := NewMachineSetManager(fakeClient, tc.Machine,
+			machineMgr, err := NewMachineSetManager(fakeClient, &record.FakeRecorder{}, tc.Machine,
 				tc.MachineSetList,
 				logr.Discard(),
 			)
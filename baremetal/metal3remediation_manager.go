@@ -28,8 +28,10 @@ import (
 	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	v1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -44,6 +46,9 @@ const (
 	powerOffAnnotation              = "reboot.metal3.io/metal3-remediation-%s"
 	nodeAnnotationsBackupAnnotation = "remediation.metal3.io/node-annotations-backup"
 	nodeLabelsBackupAnnotation      = "remediation.metal3.io/node-labels-backup"
+	releasedHostAnnotation          = "remediation.metal3.io/released-host"
+	defaultNodeDrainTimeout         = 300 * time.Second
+	defaultRemediationTimeout       = 600 * time.Second
 )
 
 // RemediationManagerInterface is an interface for a RemediationManager.
@@ -56,12 +61,23 @@ type RemediationManagerInterface interface {
 	RemovePowerOffAnnotation(ctx context.Context) error
 	IsPowerOffRequested(ctx context.Context) (bool, error)
 	IsPoweredOn(ctx context.Context) (bool, error)
+	DeprovisionHost(ctx context.Context) error
+	IsDeprovisionRequested(ctx context.Context) (bool, error)
+	GetProvisioningState(ctx context.Context) (bmov1alpha1.ProvisioningState, error)
+	IsHostReleased(ctx context.Context) (bool, error)
+	ReleaseUnhealthyHost(ctx context.Context) error
+	DissociateMetal3Machine(ctx context.Context) error
 	SetUnhealthyAnnotation(ctx context.Context) error
 	GetUnhealthyHost(ctx context.Context) (*bmov1alpha1.BareMetalHost, *patch.Helper, error)
 	OnlineStatus(host *bmov1alpha1.BareMetalHost) bool
 	GetRemediationType() infrav1.RemediationType
 	RetryLimitIsSet() bool
 	HasReachRetryLimit() bool
+	HasNextStep() bool
+	StartNextStep()
+	RecordAttempt(endTime *metav1.Time)
+	AcquireRemediationBudget(ctx context.Context) (bool, error)
+	ReleaseRemediationBudget(ctx context.Context) error
 	SetRemediationPhase(phase string)
 	GetRemediationPhase() string
 	GetLastRemediatedTime() *metav1.Time
@@ -73,6 +89,11 @@ type RemediationManagerInterface interface {
 	GetNode(ctx context.Context, clusterClient v1.CoreV1Interface) (*corev1.Node, error)
 	UpdateNode(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) error
 	DeleteNode(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) error
+	GetNodeDrainTimeout() *metav1.Duration
+	GetNodeDrainStartTime() *metav1.Time
+	SetNodeDrainStartTime(drainStartTime *metav1.Time)
+	CordonNode(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) error
+	EvictPods(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) (int, error)
 	GetClusterClient(ctx context.Context) (v1.CoreV1Interface, error)
 	SetNodeBackupAnnotations(annotations string, labels string) bool
 	GetNodeBackupAnnotations() (annotations, labels string)
@@ -153,6 +174,9 @@ func (r *RemediationManager) SetPowerOffAnnotation(ctx context.Context) error {
 	r.Log.Info("Adding PowerOff annotation to host", "host", host.Name)
 	rebootMode := bmov1alpha1.RebootAnnotationArguments{}
 	rebootMode.Mode = bmov1alpha1.RebootModeHard
+	if r.GetRemediationType() == infrav1.SoftRebootRemediationStrategy {
+		rebootMode.Mode = bmov1alpha1.RebootModeSoft
+	}
 	marshalledMode, err := json.Marshal(rebootMode)
 
 	if err != nil {
@@ -210,6 +234,170 @@ func (r *RemediationManager) IsPoweredOn(ctx context.Context) (bool, error) {
 	return host.Status.PoweredOn, nil
 }
 
+// DeprovisionHost removes the image of the unhealthy host, which triggers its
+// deprovisioning. Once the host is deprovisioned, the Metal3Machine controller
+// sets the image again and the host is provisioned with the same image, user
+// data, metadata and network data.
+func (r *RemediationManager) DeprovisionHost(ctx context.Context) error {
+	host, helper, err := r.GetUnhealthyHost(ctx)
+	if err != nil {
+		return err
+	}
+	if host == nil {
+		return errors.New("Unable to deprovision host, Host not found")
+	}
+
+	r.Log.Info("Removing image from host to deprovision it", "host", host.Name)
+	host.Spec.Image = nil
+	return helper.Patch(ctx, host)
+}
+
+// IsDeprovisionRequested returns true if the image of the unhealthy host was
+// removed.
+func (r *RemediationManager) IsDeprovisionRequested(ctx context.Context) (bool, error) {
+	host, _, err := r.GetUnhealthyHost(ctx)
+	if err != nil {
+		return false, err
+	}
+	if host == nil {
+		return false, errors.New("Unable to check host image, Host not found")
+	}
+
+	return host.Spec.Image == nil, nil
+}
+
+// GetProvisioningState returns the provisioning state of the unhealthy host.
+func (r *RemediationManager) GetProvisioningState(ctx context.Context) (bmov1alpha1.ProvisioningState, error) {
+	host, _, err := r.GetUnhealthyHost(ctx)
+	if err != nil {
+		return "", err
+	}
+	if host == nil {
+		return "", errors.New("Unable to check provisioning state, Host not found")
+	}
+
+	return host.Status.Provisioning.State, nil
+}
+
+// IsHostReleased returns true if the unhealthy host is not consumed by the
+// Metal3Machine anymore.
+func (r *RemediationManager) IsHostReleased(ctx context.Context) (bool, error) {
+	host, _, err := r.GetUnhealthyHost(ctx)
+	if err != nil {
+		return false, err
+	}
+	if host == nil {
+		return false, errors.New("Unable to check host consumer, Host not found")
+	}
+
+	consumer := host.Spec.ConsumerRef
+	return consumer == nil || consumer.Kind != "Metal3Machine" ||
+		consumer.Name != r.Metal3Machine.Name || consumer.Namespace != r.Metal3Machine.Namespace, nil
+}
+
+// ReleaseUnhealthyHost removes the association between the unhealthy host and
+// the Metal3Machine, which triggers the deprovisioning of the host. The host
+// is marked with capm3.UnhealthyAnnotation so that it is not chosen again
+// until a human looks at it.
+func (r *RemediationManager) ReleaseUnhealthyHost(ctx context.Context) error {
+	host, helper, err := r.GetUnhealthyHost(ctx)
+	if err != nil {
+		return err
+	}
+	if host == nil {
+		return errors.New("Unable to release host, Host not found")
+	}
+
+	r.Log.Info("Releasing unhealthy host", "host", host.Name)
+	rem := r.Metal3Remediation
+	if rem.Annotations == nil {
+		rem.Annotations = make(map[string]string)
+	}
+	rem.Annotations[releasedHostAnnotation] = host.Namespace + "/" + host.Name
+
+	if host.Annotations == nil {
+		host.Annotations = make(map[string]string, 1)
+	}
+	host.Annotations[infrav1.UnhealthyAnnotation] = "capm3/UnhealthyNode"
+	host.Spec.ConsumerRef = nil
+	host.Spec.Image = nil
+	host.Spec.UserData = nil
+	host.Spec.MetaData = nil
+	host.Spec.NetworkData = nil
+	host.OwnerReferences, err = deleteOwnerRefFromList(host.OwnerReferences,
+		metav1.TypeMeta{Kind: "Metal3Machine", APIVersion: infrav1.GroupVersion.String()},
+		r.Metal3Machine.ObjectMeta,
+	)
+	if err != nil {
+		return err
+	}
+	if host.Labels != nil && host.Labels[clusterv1.ClusterLabelName] == r.Metal3Machine.Labels[clusterv1.ClusterLabelName] {
+		delete(host.Labels, clusterv1.ClusterLabelName)
+	}
+	return helper.Patch(ctx, host)
+}
+
+// DissociateMetal3Machine removes the host annotation and the providerID of
+// the Metal3Machine, so that the Metal3Machine controller associates it with a
+// spare host chosen through the same host selector. The Metal3DataClaim is
+// kept, preserving the index and the IP address claims of the Metal3Data, but
+// the Metal3Data secrets are rendered again for the spare host. The
+// Metal3Machine is patched first, so that the secrets are not rendered again
+// for the released host.
+func (r *RemediationManager) DissociateMetal3Machine(ctx context.Context) error {
+	r.Log.Info("Dissociating Metal3Machine from the unhealthy host")
+	m3mHelper, err := patch.NewHelper(r.Metal3Machine, r.Client)
+	if err != nil {
+		r.Log.Info("Unable to create patch helper for Metal3Machine")
+		return err
+	}
+	delete(r.Metal3Machine.Annotations, HostAnnotation)
+	r.Metal3Machine.Spec.ProviderID = nil
+	r.Metal3Machine.Status.Ready = false
+	r.Metal3Machine.Status.Addresses = nil
+	if err := m3mHelper.Patch(ctx, r.Metal3Machine); err != nil {
+		return err
+	}
+
+	return r.renderDataAgain(ctx)
+}
+
+// renderDataAgain deletes the secrets rendered by the Metal3Data of the
+// Metal3Machine and marks it as not ready, so that they are rendered again
+// with the details of the spare host.
+func (r *RemediationManager) renderDataAgain(ctx context.Context) error {
+	if r.Metal3Machine.Status.RenderedData == nil {
+		return nil
+	}
+	m3d, err := fetchM3Data(ctx, r.Client, r.Log, r.Metal3Machine.Status.RenderedData.Name,
+		r.Metal3Machine.Namespace,
+	)
+	if err != nil {
+		return err
+	}
+
+	if m3d.Spec.MetaData != nil && r.Metal3Machine.Spec.MetaData == nil {
+		if err := deleteSecret(ctx, r.Client, m3d.Spec.MetaData.Name, m3d.Namespace); err != nil {
+			return err
+		}
+	}
+	if m3d.Spec.NetworkData != nil && r.Metal3Machine.Spec.NetworkData == nil {
+		if err := deleteSecret(ctx, r.Client, m3d.Spec.NetworkData.Name, m3d.Namespace); err != nil {
+			return err
+		}
+	}
+
+	if !m3d.Status.Ready {
+		return nil
+	}
+	m3dHelper, err := patch.NewHelper(m3d, r.Client)
+	if err != nil {
+		return err
+	}
+	m3d.Status.Ready = false
+	return m3dHelper.Patch(ctx, m3d)
+}
+
 // SetUnhealthyAnnotation sets capm3.UnhealthyAnnotation on unhealthy host.
 func (r *RemediationManager) SetUnhealthyAnnotation(ctx context.Context) error {
 	host, helper, err := r.GetUnhealthyHost(ctx)
@@ -231,7 +419,21 @@ func (r *RemediationManager) SetUnhealthyAnnotation(ctx context.Context) error {
 // GetUnhealthyHost gets the associated host for unhealthy machine. Returns nil if not found. Assumes the
 // host is in the same namespace as the unhealthy machine.
 func (r *RemediationManager) GetUnhealthyHost(ctx context.Context) (*bmov1alpha1.BareMetalHost, *patch.Helper, error) {
-	host, err := getUnhealthyHost(ctx, r.Metal3Machine, r.Client, r.Log)
+	m3Machine := r.Metal3Machine
+	// Once dissociated by the host swap strategy, the Metal3Machine is not
+	// associated with any host until a spare one is chosen. The released
+	// host is returned meanwhile.
+	if r.Metal3Remediation != nil && m3Machine != nil {
+		hostKey, released := r.Metal3Remediation.Annotations[releasedHostAnnotation]
+		if _, ok := m3Machine.Annotations[HostAnnotation]; !ok && released {
+			m3Machine = m3Machine.DeepCopy()
+			if m3Machine.Annotations == nil {
+				m3Machine.Annotations = make(map[string]string)
+			}
+			m3Machine.Annotations[HostAnnotation] = hostKey
+		}
+	}
+	host, err := getUnhealthyHost(ctx, m3Machine, r.Client, r.Log)
 	if err != nil || host == nil {
 		return host, nil, err
 	}
@@ -278,11 +480,15 @@ func (r *RemediationManager) OnlineStatus(host *bmov1alpha1.BareMetalHost) bool
 	return host.Spec.Online
 }
 
-// GetRemediationType return type of remediation strategy.
+// GetRemediationType return type of remediation strategy, or the type of the
+// current step when the strategy defines steps.
 func (r *RemediationManager) GetRemediationType() infrav1.RemediationType {
 	if r.Metal3Remediation.Spec.Strategy == nil {
 		return ""
 	}
+	if step := r.currentStep(); step != nil {
+		return step.Type
+	}
 	return r.Metal3Remediation.Spec.Strategy.Type
 }
 
@@ -291,6 +497,9 @@ func (r *RemediationManager) RetryLimitIsSet() bool {
 	if r.Metal3Remediation.Spec.Strategy == nil {
 		return false
 	}
+	if step := r.currentStep(); step != nil {
+		return step.RetryLimit > 0
+	}
 	return r.Metal3Remediation.Spec.Strategy.RetryLimit > 0
 }
 
@@ -299,9 +508,55 @@ func (r *RemediationManager) HasReachRetryLimit() bool {
 	if r.Metal3Remediation.Spec.Strategy == nil {
 		return false
 	}
+	if step := r.currentStep(); step != nil {
+		return step.RetryLimit <= r.Metal3Remediation.Status.RetryCount
+	}
 	return r.Metal3Remediation.Spec.Strategy.RetryLimit == r.Metal3Remediation.Status.RetryCount
 }
 
+// HasNextStep returns true if the strategy defines a step after the current
+// one.
+func (r *RemediationManager) HasNextStep() bool {
+	if r.Metal3Remediation.Spec.Strategy == nil {
+		return false
+	}
+	return r.Metal3Remediation.Status.CurrentStep+1 < len(r.Metal3Remediation.Spec.Strategy.Steps)
+}
+
+// StartNextStep moves the remediation to the next step and resets the retry
+// count.
+func (r *RemediationManager) StartNextStep() {
+	r.Metal3Remediation.Status.CurrentStep++
+	r.Metal3Remediation.Status.RetryCount = 0
+	r.Log.Info("Escalating remediation", "step", r.Metal3Remediation.Status.CurrentStep,
+		"remediationType", r.GetRemediationType(),
+	)
+}
+
+// RecordAttempt adds the attempt which started at the last remediation time
+// to the history of the remediation.
+func (r *RemediationManager) RecordAttempt(endTime *metav1.Time) {
+	status := &r.Metal3Remediation.Status
+	status.History = append(status.History, infrav1.RemediationAttempt{
+		Step:       status.CurrentStep,
+		Type:       r.GetRemediationType(),
+		RetryCount: status.RetryCount,
+		StartTime:  status.LastRemediated,
+		EndTime:    endTime,
+	})
+}
+
+// currentStep returns the remediation step being attempted, or nil if the
+// strategy does not define steps.
+func (r *RemediationManager) currentStep() *infrav1.RemediationStep {
+	steps := r.Metal3Remediation.Spec.Strategy.Steps
+	index := r.Metal3Remediation.Status.CurrentStep
+	if index < 0 || index >= len(steps) {
+		return nil
+	}
+	return &steps[index]
+}
+
 // SetRemediationPhase setting the state of the remediation.
 func (r *RemediationManager) SetRemediationPhase(phase string) {
 	r.Log.Info("Switching remediation phase", "remediationPhase", phase)
@@ -324,9 +579,17 @@ func (r *RemediationManager) SetLastRemediationTime(remediationTime *metav1.Time
 	r.Metal3Remediation.Status.LastRemediated = remediationTime
 }
 
-// GetTimeout returns timeout duration from remediation request Spec.
+// GetTimeout returns timeout duration from remediation request Spec, or the
+// timeout of the current step when it sets one. It falls back to a default
+// timeout when neither sets it.
 func (r *RemediationManager) GetTimeout() *metav1.Duration {
-	return r.Metal3Remediation.Spec.Strategy.Timeout
+	if step := r.currentStep(); step != nil && step.Timeout != nil {
+		return step.Timeout
+	}
+	if r.Metal3Remediation.Spec.Strategy != nil && r.Metal3Remediation.Spec.Strategy.Timeout != nil {
+		return r.Metal3Remediation.Spec.Strategy.Timeout
+	}
+	return &metav1.Duration{Duration: defaultRemediationTimeout}
 }
 
 // IncreaseRetryCount increases the retry count on Status.
@@ -375,6 +638,13 @@ func (r *RemediationManager) GetNode(ctx context.Context, clusterClient v1.CoreV
 		return nil, errors.Wrapf(err, "metal3Remediation's node could not be retrieved")
 	}
 	if capiMachine.Status.NodeRef == nil {
+		// A Machine whose host never joined the cluster has no nodeRef. The
+		// host swap strategy can still move it to a spare host, so this is
+		// handled like a deleted Node.
+		if r.GetRemediationType() == infrav1.HostSwapRemediationStrategy {
+			r.Log.Info("metal3Remediation's node could not be retrieved, machine's nodeRef is nil")
+			return nil, apierrors.NewNotFound(corev1.Resource("nodes"), "")
+		}
 		r.Log.Error(nil, "metal3Remediation's node could not be retrieved, machine's nodeRef is nil")
 		return nil, errors.Errorf("metal3Remediation's node could not be retrieved, machine's nodeRef is nil")
 	}
@@ -413,6 +683,100 @@ func (r *RemediationManager) DeleteNode(ctx context.Context, clusterClient v1.Co
 	return nil
 }
 
+// GetNodeDrainTimeout returns the timeout of the drain of the node, or nil if
+// the node should not be drained.
+func (r *RemediationManager) GetNodeDrainTimeout() *metav1.Duration {
+	nodeDrain := r.Metal3Remediation.Spec.NodeDrain
+	if nodeDrain == nil {
+		return nil
+	}
+	if nodeDrain.Timeout == nil {
+		return &metav1.Duration{Duration: defaultNodeDrainTimeout}
+	}
+	return nodeDrain.Timeout
+}
+
+// GetNodeDrainStartTime returns when the drain of the node started.
+func (r *RemediationManager) GetNodeDrainStartTime() *metav1.Time {
+	return r.Metal3Remediation.Status.NodeDrainStartTime
+}
+
+// SetNodeDrainStartTime sets when the drain of the node started on Status.
+func (r *RemediationManager) SetNodeDrainStartTime(drainStartTime *metav1.Time) {
+	r.Metal3Remediation.Status.NodeDrainStartTime = drainStartTime
+}
+
+// CordonNode marks the given node as unschedulable.
+func (r *RemediationManager) CordonNode(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) error {
+	if node.Spec.Unschedulable {
+		return nil
+	}
+	node.Spec.Unschedulable = true
+	return r.UpdateNode(ctx, clusterClient, node)
+}
+
+// EvictPods requests the eviction of the pods running on the given node, and
+// returns the number of pods left to evict. Evictions are refused by the API
+// server while they would violate a PodDisruptionBudget, in which case they
+// are requested again on the next call. DaemonSet and static pods are not
+// evicted, since they would be recreated on the node right away.
+func (r *RemediationManager) EvictPods(ctx context.Context, clusterClient v1.CoreV1Interface, node *corev1.Node) (int, error) {
+	pods, err := clusterClient.Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node.Name).String(),
+	})
+	if err != nil {
+		r.Log.Error(err, "Could not list the pods of the node")
+		return 0, errors.Wrapf(err, "Could not list the pods of the node")
+	}
+
+	remaining := 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Spec.NodeName != node.Name || !isPodEvictable(pod) {
+			continue
+		}
+		remaining++
+		if !pod.DeletionTimestamp.IsZero() {
+			// Already evicted, waiting for the pod to terminate
+			continue
+		}
+
+		err := clusterClient.Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		})
+		switch {
+		case err == nil:
+			r.Log.Info("Evicted pod", "pod", pod.Namespace+"/"+pod.Name)
+		case apierrors.IsNotFound(err):
+			remaining--
+		case apierrors.IsTooManyRequests(err):
+			r.Log.Info("Pod eviction refused by a PodDisruptionBudget, will retry", "pod", pod.Namespace+"/"+pod.Name)
+		default:
+			r.Log.Error(err, "Could not evict pod", "pod", pod.Namespace+"/"+pod.Name)
+			return 0, errors.Wrapf(err, "Could not evict pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
+	return remaining, nil
+}
+
+// isPodEvictable returns false for the pods which should not be evicted from a
+// node being drained: finished pods, static pods and DaemonSet pods.
+func isPodEvictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
 // GetClusterClient returns the client for interacting with the target cluster.
 func (r *RemediationManager) GetClusterClient(ctx context.Context) (v1.CoreV1Interface, error) {
 	capiMachine, err := r.GetCapiMachine(ctx)
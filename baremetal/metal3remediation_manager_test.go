@@ -26,11 +26,15 @@ import (
 	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	_ "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	clientfake "k8s.io/client-go/kubernetes/fake"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -618,7 +622,7 @@ var _ = Describe("Metal3Remediation manager", func() {
 
 	type testCaseGetTimeout struct {
 		Metal3Remediation *infrav1.Metal3Remediation
-		TimeoutSet        bool
+		ExpectedTimeout   time.Duration
 	}
 
 	DescribeTable("Test GetTimeout",
@@ -629,11 +633,8 @@ var _ = Describe("Metal3Remediation manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			timeout := remediationMgr.GetTimeout()
-			if tc.TimeoutSet {
-				Expect(timeout).NotTo(BeNil())
-			} else {
-				Expect(timeout).To(BeNil())
-			}
+			Expect(timeout).NotTo(BeNil())
+			Expect(timeout.Duration).To(Equal(tc.ExpectedTimeout))
 		},
 		Entry("Timeout is not set", testCaseGetTimeout{
 			Metal3Remediation: &infrav1.Metal3Remediation{
@@ -641,7 +642,19 @@ var _ = Describe("Metal3Remediation manager", func() {
 					Strategy: &infrav1.RemediationStrategy{},
 				},
 			},
-			TimeoutSet: false,
+			ExpectedTimeout: defaultRemediationTimeout,
+		}),
+		Entry("Steps without timeout", testCaseGetTimeout{
+			Metal3Remediation: &infrav1.Metal3Remediation{
+				Spec: infrav1.Metal3RemediationSpec{
+					Strategy: &infrav1.RemediationStrategy{
+						Steps: []infrav1.RemediationStep{
+							{Type: infrav1.RebootRemediationStrategy, RetryLimit: 1},
+						},
+					},
+				},
+			},
+			ExpectedTimeout: defaultRemediationTimeout,
 		}),
 		Entry("Timeout is set", testCaseGetTimeout{
 			Metal3Remediation: &infrav1.Metal3Remediation{
@@ -649,11 +662,11 @@ var _ = Describe("Metal3Remediation manager", func() {
 					Strategy: &infrav1.RemediationStrategy{
 						Type:       "",
 						RetryLimit: 0,
-						Timeout:    &metav1.Duration{},
+						Timeout:    &metav1.Duration{Duration: time.Minute},
 					},
 				},
 			},
-			TimeoutSet: true,
+			ExpectedTimeout: time.Minute,
 		}),
 	)
 
@@ -869,6 +882,317 @@ var _ = Describe("Metal3Remediation manager", func() {
 		})
 	})
 
+	Describe("Test RemediationSteps", func() {
+		threeMinutes := metav1.Duration{Duration: 3 * time.Minute}
+		tenMinutes := metav1.Duration{Duration: 10 * time.Minute}
+
+		bmhost := &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myhost",
+				Namespace: namespaceName,
+			},
+		}
+
+		m3machine := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "mym3machine",
+				Namespace:       namespaceName,
+				OwnerReferences: []metav1.OwnerReference{},
+				Annotations: map[string]string{
+					HostAnnotation: namespaceName + "/myhost",
+				},
+			},
+		}
+
+		var remediation *infrav1.Metal3Remediation
+
+		BeforeEach(func() {
+			lastRemediated := metav1.NewTime(time.Now().Add(-5 * time.Minute))
+			remediation = &infrav1.Metal3Remediation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "myremediation",
+					Namespace: namespaceName,
+					UID:       "123",
+				},
+				Spec: infrav1.Metal3RemediationSpec{
+					Strategy: &infrav1.RemediationStrategy{
+						Type:    infrav1.RebootRemediationStrategy,
+						Timeout: &tenMinutes,
+						Steps: []infrav1.RemediationStep{
+							{Type: infrav1.SoftRebootRemediationStrategy, RetryLimit: 1, Timeout: &threeMinutes},
+							{Type: infrav1.ReprovisionRemediationStrategy},
+						},
+					},
+				},
+				Status: infrav1.Metal3RemediationStatus{
+					LastRemediated: &lastRemediated,
+				},
+			}
+		})
+
+		It("should escalate from one step to the next one", func() {
+			remediationMgr, err := NewRemediationManager(nil, nil, remediation, nil, nil,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Attempting the first step")
+			Expect(remediationMgr.GetRemediationType()).To(Equal(infrav1.SoftRebootRemediationStrategy))
+			Expect(remediationMgr.GetTimeout()).To(Equal(&threeMinutes))
+			Expect(remediationMgr.RetryLimitIsSet()).To(BeTrue())
+			Expect(remediationMgr.HasReachRetryLimit()).To(BeFalse())
+			Expect(remediationMgr.HasNextStep()).To(BeTrue())
+
+			By("Retrying the first step")
+			remediationMgr.IncreaseRetryCount()
+			Expect(remediationMgr.HasReachRetryLimit()).To(BeTrue())
+
+			By("Recording the attempt")
+			now := metav1.Now()
+			remediationMgr.RecordAttempt(&now)
+			Expect(remediation.Status.History).To(Equal([]infrav1.RemediationAttempt{{
+				Step:       0,
+				Type:       infrav1.SoftRebootRemediationStrategy,
+				RetryCount: 1,
+				StartTime:  remediation.Status.LastRemediated,
+				EndTime:    &now,
+			}}))
+
+			By("Escalating to the last step")
+			remediationMgr.StartNextStep()
+			Expect(remediation.Status.CurrentStep).To(Equal(1))
+			Expect(remediation.Status.RetryCount).To(Equal(0))
+			Expect(remediationMgr.GetRemediationType()).To(Equal(infrav1.ReprovisionRemediationStrategy))
+			Expect(remediationMgr.GetTimeout()).To(Equal(&tenMinutes), "the timeout of the strategy should be used")
+			Expect(remediationMgr.RetryLimitIsSet()).To(BeFalse())
+			Expect(remediationMgr.HasReachRetryLimit()).To(BeTrue())
+			Expect(remediationMgr.HasNextStep()).To(BeFalse())
+		})
+
+		It("should request a soft power off for the SoftReboot step", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(bmhost, m3machine, remediation).Build()
+
+			remediationMgr, err := NewRemediationManager(fakeClient, nil, remediation, m3machine, nil,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remediationMgr.SetPowerOffAnnotation(context.TODO())).To(Succeed(), "SetPowerOffAnnotation should succeed")
+			savedHost := &bmov1alpha1.BareMetalHost{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bmhost), savedHost)).To(Succeed())
+			Expect(savedHost.Annotations).To(HaveKeyWithValue(
+				remediationMgr.getPowerOffAnnotationKey(),
+				ContainSubstring(string(bmov1alpha1.RebootModeSoft)),
+			), "bmh should have a soft power off annotation")
+		})
+	})
+
+	Describe("Test DeprovisionHost", func() {
+		bmhost := &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myhost",
+				Namespace: namespaceName,
+			},
+			Spec: bmov1alpha1.BareMetalHostSpec{
+				Image: &bmov1alpha1.Image{
+					URL: "myimage",
+				},
+				UserData: &corev1.SecretReference{
+					Name: "myuserdata",
+				},
+			},
+			Status: bmov1alpha1.BareMetalHostStatus{
+				Provisioning: bmov1alpha1.ProvisionStatus{
+					State: bmov1alpha1.StateProvisioned,
+				},
+			},
+		}
+
+		m3machine := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "mym3machine",
+				Namespace:       namespaceName,
+				OwnerReferences: []metav1.OwnerReference{},
+				Annotations: map[string]string{
+					HostAnnotation: namespaceName + "/myhost",
+				},
+			},
+		}
+
+		It("should remove the image of the host", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(bmhost, m3machine).Build()
+
+			remediationMgr, err := NewRemediationManager(fakeClient, nil, nil, m3machine, nil,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remediationMgr.GetProvisioningState(context.TODO())).To(Equal(bmov1alpha1.StateProvisioned))
+			Expect(remediationMgr.IsDeprovisionRequested(context.TODO())).To(BeFalse(), "IsDeprovisionRequested should return false")
+
+			By("Deprovisioning the host")
+			Expect(remediationMgr.DeprovisionHost(context.TODO())).To(Succeed(), "DeprovisionHost should succeed")
+			Expect(remediationMgr.IsDeprovisionRequested(context.TODO())).To(BeTrue(), "IsDeprovisionRequested should return true")
+
+			savedHost := &bmov1alpha1.BareMetalHost{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bmhost), savedHost)).To(Succeed())
+			Expect(savedHost.Spec.Image).To(BeNil())
+			Expect(savedHost.Spec.UserData).To(Equal(bmhost.Spec.UserData), "user data should be kept")
+		})
+	})
+
+	Describe("Test HostSwap", func() {
+		m3machine := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mym3machine",
+				Namespace: namespaceName,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName: clusterName,
+				},
+				Annotations: map[string]string{
+					HostAnnotation: namespaceName + "/myhost",
+				},
+			},
+			Spec: infrav1.Metal3MachineSpec{
+				ProviderID: pointer.StringPtr("metal3://myhost"),
+			},
+			Status: infrav1.Metal3MachineStatus{
+				Ready: true,
+				RenderedData: &corev1.ObjectReference{
+					Name:      "mym3data",
+					Namespace: namespaceName,
+				},
+			},
+		}
+		bmhost := &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myhost",
+				Namespace: namespaceName,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName: clusterName,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: infrav1.GroupVersion.String(),
+						Kind:       "Metal3Machine",
+						Name:       "mym3machine",
+					},
+				},
+			},
+			Spec: bmov1alpha1.BareMetalHostSpec{
+				ConsumerRef: &corev1.ObjectReference{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "Metal3Machine",
+					Name:       "mym3machine",
+					Namespace:  namespaceName,
+				},
+				Image: &bmov1alpha1.Image{
+					URL: "myimage",
+				},
+				MetaData: &corev1.SecretReference{
+					Name: "mym3machine-metadata",
+				},
+			},
+		}
+		m3data := &infrav1.Metal3Data{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mym3data",
+				Namespace: namespaceName,
+			},
+			Spec: infrav1.Metal3DataSpec{
+				Index: 3,
+				MetaData: &corev1.SecretReference{
+					Name: "mym3machine-metadata",
+				},
+			},
+			Status: infrav1.Metal3DataStatus{
+				Ready: true,
+			},
+		}
+		metadataSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mym3machine-metadata",
+				Namespace: namespaceName,
+			},
+		}
+		capiMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mymachine",
+				Namespace: namespaceName,
+			},
+			Status: clusterv1.MachineStatus{
+				NodeRef: &corev1.ObjectReference{
+					Name: "mynode",
+				},
+			},
+		}
+		remediation := &infrav1.Metal3Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myremediation",
+				Namespace: namespaceName,
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Machine",
+						Name:       "mymachine",
+					},
+				},
+			},
+		}
+
+		It("should release the host and dissociate the Metal3Machine", func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(
+				bmhost, m3machine, m3data, metadataSecret, capiMachine, remediation,
+			).Build()
+
+			remediationMgr, err := NewRemediationManager(fakeClient, nil, remediation, m3machine, capiMachine,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(remediationMgr.IsHostReleased(context.TODO())).To(BeFalse(), "IsHostReleased should return false")
+
+			By("Releasing the host")
+			Expect(remediationMgr.ReleaseUnhealthyHost(context.TODO())).To(Succeed(), "ReleaseUnhealthyHost should succeed")
+			Expect(remediationMgr.IsHostReleased(context.TODO())).To(BeTrue(), "IsHostReleased should return true")
+
+			savedHost := &bmov1alpha1.BareMetalHost{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(bmhost), savedHost)).To(Succeed())
+			Expect(savedHost.Spec.ConsumerRef).To(BeNil())
+			Expect(savedHost.Spec.Image).To(BeNil())
+			Expect(savedHost.Spec.MetaData).To(BeNil())
+			Expect(savedHost.OwnerReferences).To(BeEmpty())
+			Expect(savedHost.Labels).NotTo(HaveKey(clusterv1.ClusterLabelName))
+			Expect(savedHost.Annotations).To(HaveKey(infrav1.UnhealthyAnnotation))
+
+			By("Dissociating the Metal3Machine")
+			Expect(remediationMgr.DissociateMetal3Machine(context.TODO())).To(Succeed(), "DissociateMetal3Machine should succeed")
+
+			savedM3Machine := &infrav1.Metal3Machine{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(m3machine), savedM3Machine)).To(Succeed())
+			Expect(savedM3Machine.Annotations).NotTo(HaveKey(HostAnnotation))
+			Expect(savedM3Machine.Spec.ProviderID).To(BeNil())
+			Expect(savedM3Machine.Status.Ready).To(BeFalse())
+			Expect(savedM3Machine.Status.RenderedData).NotTo(BeNil(), "rendered data should be kept")
+
+			savedM3Data := &infrav1.Metal3Data{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(m3data), savedM3Data)).To(Succeed())
+			Expect(savedM3Data.Spec.Index).To(Equal(3))
+			Expect(savedM3Data.Status.Ready).To(BeFalse())
+			err = fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(metadataSecret), &corev1.Secret{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "metadata secret should be deleted")
+
+			savedMachine := &clusterv1.Machine{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(capiMachine), savedMachine)).To(Succeed())
+			Expect(savedMachine.Status.NodeRef).NotTo(BeNil(), "the NodeRef is owned by Cluster API")
+
+			By("Getting the released host while no spare host is associated")
+			host, _, err := remediationMgr.GetUnhealthyHost(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(host.Name).To(Equal(bmhost.Name))
+		})
+	})
+
 	Describe("Test NodeBackupAnnotation", func() {
 		It("should set and remove the node backup annotation as requested", func() {
 			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).Build()
@@ -913,6 +1237,90 @@ var _ = Describe("Metal3Remediation manager", func() {
 		})
 	})
 
+	Describe("Test NodeDrain", func() {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mynode",
+			},
+		}
+		newPod := func(name string, nodeName string) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+				},
+				Spec: corev1.PodSpec{
+					NodeName: nodeName,
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+				},
+			}
+		}
+
+		It("Should return the drain timeout", func() {
+			remediation := &infrav1.Metal3Remediation{}
+			remediationMgr, err := NewRemediationManager(nil, nil, remediation, nil, nil,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remediationMgr.GetNodeDrainTimeout()).To(BeNil(), "node should not be drained")
+
+			remediation.Spec.NodeDrain = &infrav1.NodeDrain{}
+			Expect(remediationMgr.GetNodeDrainTimeout()).To(Equal(&metav1.Duration{Duration: defaultNodeDrainTimeout}))
+
+			remediation.Spec.NodeDrain.Timeout = &metav1.Duration{Duration: time.Minute}
+			Expect(remediationMgr.GetNodeDrainTimeout()).To(Equal(&metav1.Duration{Duration: time.Minute}))
+		})
+
+		It("Should cordon the node and evict its pods", func() {
+			daemonSetPod := newPod("daemonset-pod", "mynode")
+			daemonSetPod.OwnerReferences = []metav1.OwnerReference{
+				{Kind: "DaemonSet", Name: "mydaemonset", Controller: pointer.Bool(true)},
+			}
+			staticPod := newPod("static-pod", "mynode")
+			staticPod.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: "hash"}
+			succeededPod := newPod("succeeded-pod", "mynode")
+			succeededPod.Status.Phase = corev1.PodSucceeded
+			protectedPod := newPod("protected-pod", "mynode")
+
+			clientset := clientfake.NewSimpleClientset(node.DeepCopy(),
+				newPod("evictable-pod", "mynode"), newPod("other-node-pod", "othernode"),
+				daemonSetPod, staticPod, succeededPod, protectedPod,
+			)
+			evicted := []string{}
+			clientset.PrependReactor("create", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "eviction" {
+					return false, nil, nil
+				}
+				eviction := action.(clienttesting.CreateAction).GetObject().(*policyv1.Eviction)
+				if eviction.Name == protectedPod.Name {
+					return true, nil, apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+				}
+				evicted = append(evicted, eviction.Name)
+				return true, nil, nil
+			})
+
+			remediationMgr, err := NewRemediationManager(nil, nil, &infrav1.Metal3Remediation{}, nil, nil,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Cordoning the node")
+			cordoned := node.DeepCopy()
+			Expect(remediationMgr.CordonNode(context.TODO(), clientset.CoreV1(), cordoned)).To(Succeed())
+			savedNode, err := clientset.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(savedNode.Spec.Unschedulable).To(BeTrue(), "node should be unschedulable")
+
+			By("Evicting the pods")
+			remaining, err := remediationMgr.EvictPods(context.TODO(), clientset.CoreV1(), cordoned)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(evicted).To(ConsistOf("evictable-pod"))
+			Expect(remaining).To(Equal(2), "the evicted pod and the pod protected by a PodDisruptionBudget should be left")
+		})
+	})
+
 	Describe("Test Nodes", func() {
 		cluster := &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -988,5 +1396,28 @@ var _ = Describe("Metal3Remediation manager", func() {
 			Expect(apierrors.IsNotFound(err)).To(BeTrue(), "expected NotFound error")
 		})
 
+		DescribeTable("Test GetNode without nodeRef",
+			func(remediationType infrav1.RemediationType, expectNotFound bool) {
+				rem := m3Remediation.DeepCopy()
+				rem.Spec.Strategy = &infrav1.RemediationStrategy{Type: remediationType}
+				machine := capiMachine.DeepCopy()
+				machine.Status.NodeRef = nil
+				fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(cluster, rem, machine).Build()
+				corev1Client := clientfake.NewSimpleClientset().CoreV1()
+				remediationMgr, err := NewRemediationManager(fakeClient, nil, rem, nil, machine,
+					logr.Discard(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				node, err := remediationMgr.GetNode(context.TODO(), corev1Client)
+				Expect(err).To(HaveOccurred())
+				Expect(node).To(BeNil())
+				Expect(apierrors.IsNotFound(err)).To(Equal(expectNotFound))
+			},
+			Entry("Reboot", infrav1.RebootRemediationStrategy, false),
+			Entry("SoftReboot", infrav1.SoftRebootRemediationStrategy, false),
+			Entry("Reprovision", infrav1.ReprovisionRemediationStrategy, false),
+			Entry("HostSwap", infrav1.HostSwapRemediationStrategy, true),
+		)
 	})
 })
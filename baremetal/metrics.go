@@ -0,0 +1,304 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "capm3"
+
+	// RemediationOutcomeStarted is the outcome of a remediation entering the
+	// Running phase for the first time.
+	RemediationOutcomeStarted = "started"
+	// RemediationOutcomeRetried is the outcome of a remediation step retried
+	// after timing out.
+	RemediationOutcomeRetried = "retried"
+	// RemediationOutcomeEscalated is the outcome of a remediation moving to
+	// its next step after exhausting the retries of the current one.
+	RemediationOutcomeEscalated = "escalated"
+	// RemediationOutcomeSucceeded is the outcome of a remediation whose node
+	// came back.
+	RemediationOutcomeSucceeded = "succeeded"
+	// RemediationOutcomeFailed is the outcome of a remediation that gave up.
+	RemediationOutcomeFailed = "failed"
+)
+
+var (
+	chooseHostMissesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "choose_host_misses_total",
+			Help:      "Number of times no BareMetalHost was available for a Metal3Machine.",
+		},
+		[]string{"namespace"},
+	)
+
+	metal3MachineReadySeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "metal3machine_ready_seconds",
+			Help:      "Time from the creation of a Metal3Machine until it is ready.",
+			Buckets:   prometheus.ExponentialBuckets(60, 2, 8),
+		},
+		[]string{"namespace"},
+	)
+
+	remediationsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "remediations_total",
+			Help:      "Number of Metal3Remediation phase changes, by phase and outcome.",
+		},
+		[]string{"phase", "outcome"},
+	)
+
+	hostsAvailableDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "baremetalhosts_available"),
+		"Number of BareMetalHosts available for the host selector of a Metal3MachineTemplate.",
+		[]string{"namespace", "selector"}, nil,
+	)
+	hostsConsumedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "baremetalhosts_consumed"),
+		"Number of BareMetalHosts consumed by the Metal3Machines of a cluster.",
+		[]string{"namespace", "cluster"}, nil,
+	)
+	hostOperationSecondsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "baremetalhost_provisioning_state_seconds"),
+		"Time spent by the BareMetalHosts in a provisioning state during their last operation of that kind.",
+		[]string{"namespace", "state"}, nil,
+	)
+	hostOperationSecondsBuckets = prometheus.ExponentialBuckets(30, 2, 8)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		chooseHostMissesTotal,
+		metal3MachineReadySeconds,
+		remediationsTotal,
+	)
+}
+
+// RecordRemediationOutcome counts a phase change of a Metal3Remediation.
+func RecordRemediationOutcome(phase, outcome string) {
+	remediationsTotal.WithLabelValues(phase, outcome).Inc()
+}
+
+// HostPoolCollector reports the BareMetalHosts available for the host
+// selectors of the Metal3MachineTemplates, the hosts consumed by each cluster
+// and the duration of the provisioning operations of the hosts. The values
+// are computed from the cache of the client when the metrics are scraped.
+type HostPoolCollector struct {
+	client client.Reader
+}
+
+// NewHostPoolCollector returns a new HostPoolCollector reading the objects
+// through the given client.
+func NewHostPoolCollector(client client.Reader) *HostPoolCollector {
+	return &HostPoolCollector{client: client}
+}
+
+// Describe implements prometheus.Collector.
+func (c *HostPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- hostsAvailableDesc
+	ch <- hostsConsumedDesc
+	ch <- hostOperationSecondsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *HostPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+
+	hosts := bmov1alpha1.BareMetalHostList{}
+	if err := c.client.List(ctx, &hosts); err != nil {
+		err = errors.Wrap(err, "failed to list the BareMetalHosts")
+		ch <- prometheus.NewInvalidMetric(hostsAvailableDesc, err)
+		ch <- prometheus.NewInvalidMetric(hostsConsumedDesc, err)
+		ch <- prometheus.NewInvalidMetric(hostOperationSecondsDesc, err)
+		return
+	}
+
+	consumed := map[[2]string]int{}
+	operations := map[[2]string]*hostOperationsHistogram{}
+	for i := range hosts.Items {
+		host := &hosts.Items[i]
+		if host.Spec.ConsumerRef != nil && host.Spec.ConsumerRef.Kind == "Metal3Machine" {
+			if cluster, ok := host.Labels[clusterv1.ClusterLabelName]; ok {
+				consumed[[2]string{host.Namespace, cluster}]++
+			}
+		}
+		observeHostOperations(operations, host)
+	}
+	for key, histogram := range operations {
+		ch <- prometheus.MustNewConstHistogram(hostOperationSecondsDesc, histogram.count, histogram.sum,
+			histogram.buckets, key[0], key[1],
+		)
+	}
+	for key, count := range consumed {
+		ch <- prometheus.MustNewConstMetric(hostsConsumedDesc, prometheus.GaugeValue,
+			float64(count), key[0], key[1],
+		)
+	}
+
+	templates := infrav1.Metal3MachineTemplateList{}
+	if err := c.client.List(ctx, &templates); err != nil {
+		ch <- prometheus.NewInvalidMetric(hostsAvailableDesc,
+			errors.Wrap(err, "failed to list the Metal3MachineTemplates"),
+		)
+		return
+	}
+	available := map[[2]string]int{}
+	for _, template := range templates.Items {
+		selector, err := hostSelectorToLabelSelector(template.Spec.Template.Spec.HostSelector)
+		if err != nil {
+			continue
+		}
+		key := [2]string{template.Namespace, selector.String()}
+		if _, ok := available[key]; ok {
+			continue
+		}
+		available[key] = 0
+		for i := range hosts.Items {
+			host := &hosts.Items[i]
+			if host.Namespace == template.Namespace && isHostAvailable(host) &&
+				selector.Matches(labels.Set(host.Labels)) {
+				available[key]++
+			}
+		}
+	}
+	for key, count := range available {
+		ch <- prometheus.MustNewConstMetric(hostsAvailableDesc, prometheus.GaugeValue,
+			float64(count), key[0], key[1],
+		)
+	}
+}
+
+// hostOperationsHistogram is the distribution of the durations of the
+// operations of the hosts of a namespace in a provisioning state.
+type hostOperationsHistogram struct {
+	count   uint64
+	sum     float64
+	buckets map[float64]uint64
+}
+
+// observeHostOperations adds the duration of the completed operations of the
+// operation history of the host to the histograms of its namespace, keyed by
+// namespace and provisioning state.
+func observeHostOperations(histograms map[[2]string]*hostOperationsHistogram, host *bmov1alpha1.BareMetalHost) {
+	operations := map[bmov1alpha1.ProvisioningState]bmov1alpha1.OperationMetric{
+		bmov1alpha1.StateRegistering:    host.Status.OperationHistory.Register,
+		bmov1alpha1.StateInspecting:     host.Status.OperationHistory.Inspect,
+		bmov1alpha1.StateProvisioning:   host.Status.OperationHistory.Provision,
+		bmov1alpha1.StateDeprovisioning: host.Status.OperationHistory.Deprovision,
+	}
+	for state, operation := range operations {
+		if operation.Start.IsZero() || operation.End.IsZero() {
+			continue
+		}
+		key := [2]string{host.Namespace, string(state)}
+		histogram, ok := histograms[key]
+		if !ok {
+			histogram = &hostOperationsHistogram{buckets: map[float64]uint64{}}
+			for _, bound := range hostOperationSecondsBuckets {
+				histogram.buckets[bound] = 0
+			}
+			histograms[key] = histogram
+		}
+		seconds := operation.Duration().Seconds()
+		histogram.count++
+		histogram.sum += seconds
+		for _, bound := range hostOperationSecondsBuckets {
+			if seconds <= bound {
+				histogram.buckets[bound]++
+			}
+		}
+	}
+}
+
+// isHostAvailable returns true if the host could be chosen for a new
+// Metal3Machine, using the same criteria as chooseHost.
+func isHostAvailable(host *bmov1alpha1.BareMetalHost) bool {
+	if host.Spec.ConsumerRef != nil || host.GetDeletionTimestamp() != nil ||
+		host.Status.ErrorMessage != "" {
+		return false
+	}
+	if _, ok := host.Labels[nodeReuseLabelName]; ok {
+		return false
+	}
+	if _, ok := host.Annotations[bmov1alpha1.PausedAnnotation]; ok {
+		return false
+	}
+	if _, ok := host.Annotations[infrav1.UnhealthyAnnotation]; ok {
+		return false
+	}
+	switch host.Status.Provisioning.State {
+	case bmov1alpha1.StateReady, bmov1alpha1.StateAvailable:
+		return true
+	}
+	return false
+}
+
+// hostSelectorToLabelSelector converts the HostSelector of a Metal3Machine to
+// a label selector.
+func hostSelectorToLabelSelector(hostSelector infrav1.HostSelector) (labels.Selector, error) {
+	var reqs labels.Requirements
+	for labelKey, labelVal := range hostSelector.MatchLabels {
+		r, err := labels.NewRequirement(labelKey, selection.Equals, []string{labelVal})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create MatchLabel requirement")
+		}
+		reqs = append(reqs, *r)
+	}
+	for _, req := range hostSelector.MatchExpressions {
+		lowercaseOperator := selection.Operator(strings.ToLower(string(req.Operator)))
+		r, err := labels.NewRequirement(req.Key, lowercaseOperator, req.Values)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create MatchExpression requirement")
+		}
+		reqs = append(reqs, *r)
+	}
+	return labels.NewSelector().Add(reqs...), nil
+}
+
+// observeMetal3MachineReady records the time it took for the Metal3Machine to
+// become ready. It is only recorded the first time, before the Machine has a
+// Node, and not when the Metal3Machine is ready again, for example after its
+// host was swapped.
+func observeMetal3MachineReady(m3Machine *infrav1.Metal3Machine, machine *clusterv1.Machine) {
+	if m3Machine.CreationTimestamp.IsZero() {
+		return
+	}
+	if machine != nil && machine.Status.NodeRef != nil {
+		return
+	}
+	metal3MachineReadySeconds.WithLabelValues(m3Machine.Namespace).Observe(
+		time.Since(m3Machine.CreationTimestamp.Time).Seconds(),
+	)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"strings"
+	"time"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Metrics", func() {
+	newHost := func(name string, hostLabels map[string]string, state bmov1alpha1.ProvisioningState) *bmov1alpha1.BareMetalHost {
+		return &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespaceName,
+				Labels:    hostLabels,
+			},
+			Status: bmov1alpha1.BareMetalHostStatus{
+				Provisioning: bmov1alpha1.ProvisionStatus{
+					State: state,
+				},
+			},
+		}
+	}
+
+	It("Collects the host pool metrics", func() {
+		start := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+		end := metav1.NewTime(start.Add(5 * time.Minute))
+		consumedHost := newHost("consumed", map[string]string{
+			"size":                     "large",
+			clusterv1.ClusterLabelName: "mycluster",
+		}, bmov1alpha1.StateProvisioned)
+		consumedHost.Spec.ConsumerRef = &corev1.ObjectReference{
+			Kind: "Metal3Machine",
+			Name: "mymachine",
+		}
+		consumedHost.Status.OperationHistory.Provision = bmov1alpha1.OperationMetric{
+			Start: start,
+			End:   end,
+		}
+		// The inspection is still in progress, it is not reported.
+		consumedHost.Status.OperationHistory.Inspect = bmov1alpha1.OperationMetric{
+			Start: start,
+		}
+		// The operations of the hosts are aggregated by provisioning state.
+		provisionedHost := newHost("provisioned", nil, bmov1alpha1.StateProvisioned)
+		provisionedHost.Status.OperationHistory.Provision = consumedHost.Status.OperationHistory.Provision
+		objects := []client.Object{
+			consumedHost,
+			provisionedHost,
+			newHost("large", map[string]string{"size": "large"}, bmov1alpha1.StateAvailable),
+			newHost("small", map[string]string{"size": "small"}, bmov1alpha1.StateReady),
+			newHost("inspecting", map[string]string{"size": "large"}, bmov1alpha1.StateInspecting),
+			&infrav1.Metal3MachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "large",
+					Namespace: namespaceName,
+				},
+				Spec: infrav1.Metal3MachineTemplateSpec{
+					Template: infrav1.Metal3MachineTemplateResource{
+						Spec: infrav1.Metal3MachineSpec{
+							HostSelector: infrav1.HostSelector{
+								MatchLabels: map[string]string{"size": "large"},
+							},
+						},
+					},
+				},
+			},
+			&infrav1.Metal3MachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "any",
+					Namespace: namespaceName,
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+
+		expected := `
+# HELP capm3_baremetalhost_provisioning_state_seconds Time spent by the BareMetalHosts in a provisioning state during their last operation of that kind.
+# TYPE capm3_baremetalhost_provisioning_state_seconds histogram
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="30"} 0
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="60"} 0
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="120"} 0
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="240"} 0
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="480"} 2
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="960"} 2
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="1920"} 2
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="3840"} 2
+capm3_baremetalhost_provisioning_state_seconds_bucket{namespace="baremetalns-testns",state="provisioning",le="+Inf"} 2
+capm3_baremetalhost_provisioning_state_seconds_sum{namespace="baremetalns-testns",state="provisioning"} 600
+capm3_baremetalhost_provisioning_state_seconds_count{namespace="baremetalns-testns",state="provisioning"} 2
+# HELP capm3_baremetalhosts_available Number of BareMetalHosts available for the host selector of a Metal3MachineTemplate.
+# TYPE capm3_baremetalhosts_available gauge
+capm3_baremetalhosts_available{namespace="baremetalns-testns",selector=""} 2
+capm3_baremetalhosts_available{namespace="baremetalns-testns",selector="size=large"} 1
+# HELP capm3_baremetalhosts_consumed Number of BareMetalHosts consumed by the Metal3Machines of a cluster.
+# TYPE capm3_baremetalhosts_consumed gauge
+capm3_baremetalhosts_consumed{cluster="mycluster",namespace="baremetalns-testns"} 1
+`
+		Expect(testutil.CollectAndCompare(NewHostPoolCollector(fakeClient), strings.NewReader(expected))).To(Succeed())
+	})
+
+	DescribeTable("Test isHostAvailable",
+		func(host *bmov1alpha1.BareMetalHost, expected bool) {
+			Expect(isHostAvailable(host)).To(Equal(expected))
+		},
+		Entry("Available host", newHost("host", nil, bmov1alpha1.StateAvailable), true),
+		Entry("Ready host", newHost("host", nil, bmov1alpha1.StateReady), true),
+		Entry("Provisioned host", newHost("host", nil, bmov1alpha1.StateProvisioned), false),
+		Entry("Host with node reuse label", newHost("host",
+			map[string]string{nodeReuseLabelName: "mydeployment"}, bmov1alpha1.StateAvailable,
+		), false),
+		Entry("Unhealthy host", &bmov1alpha1.BareMetalHost{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{infrav1.UnhealthyAnnotation: ""},
+			},
+			Status: bmov1alpha1.BareMetalHostStatus{
+				Provisioning: bmov1alpha1.ProvisionStatus{State: bmov1alpha1.StateAvailable},
+			},
+		}, false),
+		Entry("Host in error", &bmov1alpha1.BareMetalHost{
+			Status: bmov1alpha1.BareMetalHostStatus{
+				ErrorMessage: "failed",
+				Provisioning: bmov1alpha1.ProvisionStatus{State: bmov1alpha1.StateAvailable},
+			},
+		}, false),
+	)
+
+	It("Counts the remediation outcomes", func() {
+		before := testutil.ToFloat64(remediationsTotal.WithLabelValues(infrav1.PhaseRunning, RemediationOutcomeRetried))
+		RecordRemediationOutcome(infrav1.PhaseRunning, RemediationOutcomeRetried)
+		Expect(testutil.ToFloat64(remediationsTotal.WithLabelValues(infrav1.PhaseRunning, RemediationOutcomeRetried))).To(Equal(before + 1))
+	})
+
+	It("Observes the time until the Metal3Machine is ready", func() {
+		m3Machine := &infrav1.Metal3Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "mymachine",
+				Namespace:         "metrics-ns",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+			},
+		}
+		observeMetal3MachineReady(m3Machine, &clusterv1.Machine{})
+		Expect(testutil.CollectAndCount(metal3MachineReadySeconds)).To(BeNumerically(">=", 1))
+
+		// Once the Machine has a Node, the Metal3Machine was ready before.
+		count := testutil.CollectAndCount(metal3MachineReadySeconds)
+		m3Machine.Namespace = "metrics-ready-again-ns"
+		observeMetal3MachineReady(m3Machine, &clusterv1.Machine{
+			Status: clusterv1.MachineStatus{
+				NodeRef: &corev1.ObjectReference{Name: "mynode"},
+			},
+		})
+		Expect(testutil.CollectAndCount(metal3MachineReadySeconds)).To(Equal(count))
+	})
+})
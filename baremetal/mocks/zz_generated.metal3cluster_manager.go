@@ -132,3 +132,17 @@ func (mr *MockClusterManagerInterfaceMockRecorder) UpdateClusterStatus() *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterStatus", reflect.TypeOf((*MockClusterManagerInterface)(nil).UpdateClusterStatus))
 }
+
+// UpdateFailureDomains mocks base method.
+func (m *MockClusterManagerInterface) UpdateFailureDomains(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFailureDomains", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFailureDomains indicates an expected call of UpdateFailureDomains.
+func (mr *MockClusterManagerInterfaceMockRecorder) UpdateFailureDomains(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFailureDomains", reflect.TypeOf((*MockClusterManagerInterface)(nil).UpdateFailureDomains), arg0)
+}
@@ -60,6 +60,35 @@ func (m *MockRemediationManagerInterface) EXPECT() *MockRemediationManagerInterf
 	return m.recorder
 }
 
+// AcquireRemediationBudget mocks base method.
+func (m *MockRemediationManagerInterface) AcquireRemediationBudget(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireRemediationBudget", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireRemediationBudget indicates an expected call of AcquireRemediationBudget.
+func (mr *MockRemediationManagerInterfaceMockRecorder) AcquireRemediationBudget(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireRemediationBudget", reflect.TypeOf((*MockRemediationManagerInterface)(nil).AcquireRemediationBudget), ctx)
+}
+
+// CordonNode mocks base method.
+func (m *MockRemediationManagerInterface) CordonNode(ctx context.Context, clusterClient v11.CoreV1Interface, node *v1.Node) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CordonNode", ctx, clusterClient, node)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CordonNode indicates an expected call of CordonNode.
+func (mr *MockRemediationManagerInterfaceMockRecorder) CordonNode(ctx, clusterClient, node interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CordonNode", reflect.TypeOf((*MockRemediationManagerInterface)(nil).CordonNode), ctx, clusterClient, node)
+}
+
 // DeleteNode mocks base method.
 func (m *MockRemediationManagerInterface) DeleteNode(ctx context.Context, clusterClient v11.CoreV1Interface, node *v1.Node) error {
 	m.ctrl.T.Helper()
@@ -74,6 +103,49 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) DeleteNode(ctx, clusterCl
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNode", reflect.TypeOf((*MockRemediationManagerInterface)(nil).DeleteNode), ctx, clusterClient, node)
 }
 
+// DeprovisionHost mocks base method.
+func (m *MockRemediationManagerInterface) DeprovisionHost(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeprovisionHost", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeprovisionHost indicates an expected call of DeprovisionHost.
+func (mr *MockRemediationManagerInterfaceMockRecorder) DeprovisionHost(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeprovisionHost", reflect.TypeOf((*MockRemediationManagerInterface)(nil).DeprovisionHost), ctx)
+}
+
+// DissociateMetal3Machine mocks base method.
+func (m *MockRemediationManagerInterface) DissociateMetal3Machine(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DissociateMetal3Machine", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DissociateMetal3Machine indicates an expected call of DissociateMetal3Machine.
+func (mr *MockRemediationManagerInterfaceMockRecorder) DissociateMetal3Machine(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DissociateMetal3Machine", reflect.TypeOf((*MockRemediationManagerInterface)(nil).DissociateMetal3Machine), ctx)
+}
+
+// EvictPods mocks base method.
+func (m *MockRemediationManagerInterface) EvictPods(ctx context.Context, clusterClient v11.CoreV1Interface, node *v1.Node) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EvictPods", ctx, clusterClient, node)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EvictPods indicates an expected call of EvictPods.
+func (mr *MockRemediationManagerInterfaceMockRecorder) EvictPods(ctx, clusterClient, node interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictPods", reflect.TypeOf((*MockRemediationManagerInterface)(nil).EvictPods), ctx, clusterClient, node)
+}
+
 // GetCapiMachine mocks base method.
 func (m *MockRemediationManagerInterface) GetCapiMachine(ctx context.Context) (*v1beta10.Machine, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +220,49 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) GetNodeBackupAnnotations(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeBackupAnnotations", reflect.TypeOf((*MockRemediationManagerInterface)(nil).GetNodeBackupAnnotations))
 }
 
+// GetNodeDrainStartTime mocks base method.
+func (m *MockRemediationManagerInterface) GetNodeDrainStartTime() *v10.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeDrainStartTime")
+	ret0, _ := ret[0].(*v10.Time)
+	return ret0
+}
+
+// GetNodeDrainStartTime indicates an expected call of GetNodeDrainStartTime.
+func (mr *MockRemediationManagerInterfaceMockRecorder) GetNodeDrainStartTime() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeDrainStartTime", reflect.TypeOf((*MockRemediationManagerInterface)(nil).GetNodeDrainStartTime))
+}
+
+// GetNodeDrainTimeout mocks base method.
+func (m *MockRemediationManagerInterface) GetNodeDrainTimeout() *v10.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNodeDrainTimeout")
+	ret0, _ := ret[0].(*v10.Duration)
+	return ret0
+}
+
+// GetNodeDrainTimeout indicates an expected call of GetNodeDrainTimeout.
+func (mr *MockRemediationManagerInterfaceMockRecorder) GetNodeDrainTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNodeDrainTimeout", reflect.TypeOf((*MockRemediationManagerInterface)(nil).GetNodeDrainTimeout))
+}
+
+// GetProvisioningState mocks base method.
+func (m *MockRemediationManagerInterface) GetProvisioningState(ctx context.Context) (v1alpha1.ProvisioningState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProvisioningState", ctx)
+	ret0, _ := ret[0].(v1alpha1.ProvisioningState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProvisioningState indicates an expected call of GetProvisioningState.
+func (mr *MockRemediationManagerInterfaceMockRecorder) GetProvisioningState(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProvisioningState", reflect.TypeOf((*MockRemediationManagerInterface)(nil).GetProvisioningState), ctx)
+}
+
 // GetRemediationPhase mocks base method.
 func (m *MockRemediationManagerInterface) GetRemediationPhase() string {
 	m.ctrl.T.Helper()
@@ -220,6 +335,20 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) HasFinalizer() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasFinalizer", reflect.TypeOf((*MockRemediationManagerInterface)(nil).HasFinalizer))
 }
 
+// HasNextStep mocks base method.
+func (m *MockRemediationManagerInterface) HasNextStep() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasNextStep")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasNextStep indicates an expected call of HasNextStep.
+func (mr *MockRemediationManagerInterfaceMockRecorder) HasNextStep() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasNextStep", reflect.TypeOf((*MockRemediationManagerInterface)(nil).HasNextStep))
+}
+
 // HasReachRetryLimit mocks base method.
 func (m *MockRemediationManagerInterface) HasReachRetryLimit() bool {
 	m.ctrl.T.Helper()
@@ -246,6 +375,36 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) IncreaseRetryCount() *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseRetryCount", reflect.TypeOf((*MockRemediationManagerInterface)(nil).IncreaseRetryCount))
 }
 
+// IsDeprovisionRequested mocks base method.
+func (m *MockRemediationManagerInterface) IsDeprovisionRequested(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsDeprovisionRequested", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsDeprovisionRequested indicates an expected call of IsDeprovisionRequested.
+func (mr *MockRemediationManagerInterfaceMockRecorder) IsDeprovisionRequested(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDeprovisionRequested", reflect.TypeOf((*MockRemediationManagerInterface)(nil).IsDeprovisionRequested), ctx)
+}
+
+// IsHostReleased mocks base method.
+func (m *MockRemediationManagerInterface) IsHostReleased(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHostReleased", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsHostReleased indicates an expected call of IsHostReleased.
+func (mr *MockRemediationManagerInterfaceMockRecorder) IsHostReleased(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHostReleased", reflect.TypeOf((*MockRemediationManagerInterface)(nil).IsHostReleased), ctx)
+}
+
 // IsPowerOffRequested mocks base method.
 func (m *MockRemediationManagerInterface) IsPowerOffRequested(ctx context.Context) (bool, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +449,46 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) OnlineStatus(host interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnlineStatus", reflect.TypeOf((*MockRemediationManagerInterface)(nil).OnlineStatus), host)
 }
 
+// RecordAttempt mocks base method.
+func (m *MockRemediationManagerInterface) RecordAttempt(endTime *v10.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordAttempt", endTime)
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockRemediationManagerInterfaceMockRecorder) RecordAttempt(endTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockRemediationManagerInterface)(nil).RecordAttempt), endTime)
+}
+
+// ReleaseRemediationBudget mocks base method.
+func (m *MockRemediationManagerInterface) ReleaseRemediationBudget(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseRemediationBudget", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseRemediationBudget indicates an expected call of ReleaseRemediationBudget.
+func (mr *MockRemediationManagerInterfaceMockRecorder) ReleaseRemediationBudget(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseRemediationBudget", reflect.TypeOf((*MockRemediationManagerInterface)(nil).ReleaseRemediationBudget), ctx)
+}
+
+// ReleaseUnhealthyHost mocks base method.
+func (m *MockRemediationManagerInterface) ReleaseUnhealthyHost(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseUnhealthyHost", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseUnhealthyHost indicates an expected call of ReleaseUnhealthyHost.
+func (mr *MockRemediationManagerInterfaceMockRecorder) ReleaseUnhealthyHost(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseUnhealthyHost", reflect.TypeOf((*MockRemediationManagerInterface)(nil).ReleaseUnhealthyHost), ctx)
+}
+
 // RemoveNodeBackupAnnotations mocks base method.
 func (m *MockRemediationManagerInterface) RemoveNodeBackupAnnotations() {
 	m.ctrl.T.Helper()
@@ -368,6 +567,18 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) SetNodeBackupAnnotations(
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeBackupAnnotations", reflect.TypeOf((*MockRemediationManagerInterface)(nil).SetNodeBackupAnnotations), annotations, labels)
 }
 
+// SetNodeDrainStartTime mocks base method.
+func (m *MockRemediationManagerInterface) SetNodeDrainStartTime(drainStartTime *v10.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetNodeDrainStartTime", drainStartTime)
+}
+
+// SetNodeDrainStartTime indicates an expected call of SetNodeDrainStartTime.
+func (mr *MockRemediationManagerInterfaceMockRecorder) SetNodeDrainStartTime(drainStartTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNodeDrainStartTime", reflect.TypeOf((*MockRemediationManagerInterface)(nil).SetNodeDrainStartTime), drainStartTime)
+}
+
 // SetOwnerRemediatedConditionNew mocks base method.
 func (m *MockRemediationManagerInterface) SetOwnerRemediatedConditionNew(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -422,6 +633,18 @@ func (mr *MockRemediationManagerInterfaceMockRecorder) SetUnhealthyAnnotation(ct
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUnhealthyAnnotation", reflect.TypeOf((*MockRemediationManagerInterface)(nil).SetUnhealthyAnnotation), ctx)
 }
 
+// StartNextStep mocks base method.
+func (m *MockRemediationManagerInterface) StartNextStep() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartNextStep")
+}
+
+// StartNextStep indicates an expected call of StartNextStep.
+func (mr *MockRemediationManagerInterfaceMockRecorder) StartNextStep() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNextStep", reflect.TypeOf((*MockRemediationManagerInterface)(nil).StartNextStep))
+}
+
 // TimeToRemediate mocks base method.
 func (m *MockRemediationManagerInterface) TimeToRemediate(timeout time.Duration) (bool, time.Duration) {
 	m.ctrl.T.Helper()
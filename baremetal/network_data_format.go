@@ -0,0 +1,502 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+	"net"
+
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// networkDataFormatKey is the key of the network data secret holding the
+// format of the rendered network data.
+const networkDataFormatKey = "format"
+
+// The following types mirror the OpenStack network_data.json rendered by
+// renderNetworkData. The netplan and NMState formats are converted from it,
+// so that all formats describe the same links, networks and services.
+
+type networkDataModel struct {
+	Links    []networkDataLinkModel    `json:"links"`
+	Networks []networkDataNetworkModel `json:"networks"`
+	Services []networkDataServiceModel `json:"services"`
+}
+
+type networkDataLinkModel struct {
+	Type               string   `json:"type"`
+	ID                 string   `json:"id"`
+	MTU                int      `json:"mtu"`
+	EthernetMACAddress string   `json:"ethernet_mac_address,omitempty"`
+	BondMode           string   `json:"bond_mode,omitempty"`
+	BondLinks          []string `json:"bond_links,omitempty"`
+	BondMIIMon         *int     `json:"bond_miimon,omitempty"`
+	BondXmitHashPolicy string   `json:"bond_xmit_hash_policy,omitempty"`
+	BondLACPRate       string   `json:"bond_lacp_rate,omitempty"`
+	BondPrimary        string   `json:"bond_primary,omitempty"`
+	BondFailOverMac    string   `json:"bond_fail_over_mac,omitempty"`
+	VlanMACAddress     string   `json:"vlan_mac_address,omitempty"`
+	VlanID             int      `json:"vlan_id,omitempty"`
+	VlanLink           string   `json:"vlan_link,omitempty"`
+}
+
+type networkDataNetworkModel struct {
+	Type      string                  `json:"type"`
+	ID        string                  `json:"id"`
+	Link      string                  `json:"link"`
+	Netmask   string                  `json:"netmask,omitempty"`
+	IPAddress string                  `json:"ip_address,omitempty"`
+	Routes    []networkDataRouteModel `json:"routes"`
+	Rules     []networkDataRuleModel  `json:"rules,omitempty"`
+}
+
+type networkDataRouteModel struct {
+	Network  string                    `json:"network"`
+	Netmask  string                    `json:"netmask"`
+	Gateway  string                    `json:"gateway"`
+	Services []networkDataServiceModel `json:"services"`
+	Metric   *int                      `json:"metric,omitempty"`
+	Table    *int                      `json:"table,omitempty"`
+}
+
+type networkDataRuleModel struct {
+	From     string `json:"from"`
+	Table    int    `json:"table"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+type networkDataServiceModel struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// convertNetworkData converts the OpenStack network data to the given format.
+// The hostname is only used by the Ignition format.
+func convertNetworkData(networkData []byte, format string, hostname string) ([]byte, error) {
+	if format == infrav1.NetworkDataFormatOpenStack {
+		return networkData, nil
+	}
+	model := networkDataModel{}
+	if err := yaml.Unmarshal(networkData, &model); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the rendered network data")
+	}
+	switch format {
+	case infrav1.NetworkDataFormatNetplan:
+		return renderNetplan(model)
+	case infrav1.NetworkDataFormatNMState:
+		return renderNMState(model)
+	case infrav1.NetworkDataFormatIgnition:
+		return renderIgnition(model, hostname)
+	}
+	return nil, errors.Errorf("unsupported network data format %s", format)
+}
+
+// networkDataFormat returns the format of the network data of the template.
+func networkDataFormat(networkData *infrav1.NetworkData) string {
+	if networkData == nil || networkData.Format == nil {
+		return infrav1.NetworkDataFormatOpenStack
+	}
+	return *networkData.Format
+}
+
+// maskToPrefix returns the prefix length of a mask in dotted notation.
+func maskToPrefix(mask string) (int, error) {
+	ip := net.ParseIP(mask)
+	if ip == nil {
+		return 0, errors.Errorf("invalid netmask %s", mask)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	prefix, bits := net.IPMask(ip).Size()
+	if bits == 0 {
+		return 0, errors.Errorf("non canonical netmask %s", mask)
+	}
+	return prefix, nil
+}
+
+// cidr returns the address in CIDR notation.
+func cidr(address, mask string) (string, error) {
+	prefix, err := maskToPrefix(mask)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%d", address, prefix), nil
+}
+
+// dnsAddresses returns the addresses of the DNS services.
+func dnsAddresses(services []networkDataServiceModel) []string {
+	addresses := []string{}
+	for _, service := range services {
+		if service.Type == "dns" {
+			addresses = append(addresses, service.Address)
+		}
+	}
+	return addresses
+}
+
+// appendUnique appends the values that are not in the list yet.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+type netplanConfig struct {
+	Network netplanNetwork `json:"network"`
+}
+
+type netplanNetwork struct {
+	Version   int                       `json:"version"`
+	Ethernets map[string]*netplanDevice `json:"ethernets,omitempty"`
+	Bonds     map[string]*netplanDevice `json:"bonds,omitempty"`
+	Vlans     map[string]*netplanDevice `json:"vlans,omitempty"`
+}
+
+type netplanDevice struct {
+	Match         *netplanMatch          `json:"match,omitempty"`
+	SetName       string                 `json:"set-name,omitempty"`
+	MACAddress    string                 `json:"macaddress,omitempty"`
+	MTU           int                    `json:"mtu,omitempty"`
+	Interfaces    []string               `json:"interfaces,omitempty"`
+	Parameters    *netplanBondParameters `json:"parameters,omitempty"`
+	ID            *int                   `json:"id,omitempty"`
+	Link          string                 `json:"link,omitempty"`
+	DHCP4         bool                   `json:"dhcp4,omitempty"`
+	DHCP6         bool                   `json:"dhcp6,omitempty"`
+	AcceptRA      *bool                  `json:"accept-ra,omitempty"`
+	Addresses     []string               `json:"addresses,omitempty"`
+	Routes        []netplanRoute         `json:"routes,omitempty"`
+	RoutingPolicy []netplanRoutingPolicy `json:"routing-policy,omitempty"`
+	Nameservers   *netplanNameservers    `json:"nameservers,omitempty"`
+}
+
+type netplanMatch struct {
+	MACAddress string `json:"macaddress"`
+}
+
+type netplanBondParameters struct {
+	Mode               string `json:"mode,omitempty"`
+	MIIMonitorInterval *int   `json:"mii-monitor-interval,omitempty"`
+	TransmitHashPolicy string `json:"transmit-hash-policy,omitempty"`
+	LACPRate           string `json:"lacp-rate,omitempty"`
+	Primary            string `json:"primary,omitempty"`
+	FailOverMACPolicy  string `json:"fail-over-mac-policy,omitempty"`
+}
+
+type netplanRoute struct {
+	To     string `json:"to"`
+	Via    string `json:"via,omitempty"`
+	Metric *int   `json:"metric,omitempty"`
+	Table  *int   `json:"table,omitempty"`
+}
+
+type netplanRoutingPolicy struct {
+	From     string `json:"from"`
+	Table    int    `json:"table"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+type netplanNameservers struct {
+	Addresses []string `json:"addresses"`
+}
+
+// renderNetplan renders the network data as netplan version 2 YAML.
+func renderNetplan(model networkDataModel) ([]byte, error) {
+	network := netplanNetwork{Version: 2}
+	devices := map[string]*netplanDevice{}
+
+	for _, link := range model.Links {
+		device := &netplanDevice{MTU: link.MTU}
+		switch link.Type {
+		case "bond":
+			device.MACAddress = link.EthernetMACAddress
+			device.Interfaces = link.BondLinks
+			parameters := netplanBondParameters{
+				Mode:               link.BondMode,
+				MIIMonitorInterval: link.BondMIIMon,
+				TransmitHashPolicy: link.BondXmitHashPolicy,
+				LACPRate:           link.BondLACPRate,
+				Primary:            link.BondPrimary,
+				FailOverMACPolicy:  link.BondFailOverMac,
+			}
+			if parameters != (netplanBondParameters{}) {
+				device.Parameters = &parameters
+			}
+			if network.Bonds == nil {
+				network.Bonds = map[string]*netplanDevice{}
+			}
+			network.Bonds[link.ID] = device
+		case "vlan":
+			vlanID := link.VlanID
+			device.ID = &vlanID
+			device.Link = link.VlanLink
+			device.MACAddress = link.VlanMACAddress
+			if network.Vlans == nil {
+				network.Vlans = map[string]*netplanDevice{}
+			}
+			network.Vlans[link.ID] = device
+		default:
+			if link.EthernetMACAddress != "" {
+				device.Match = &netplanMatch{MACAddress: link.EthernetMACAddress}
+				device.SetName = link.ID
+			}
+			if network.Ethernets == nil {
+				network.Ethernets = map[string]*netplanDevice{}
+			}
+			network.Ethernets[link.ID] = device
+		}
+		devices[link.ID] = device
+	}
+
+	globalDNS := dnsAddresses(model.Services)
+	for _, ipNetwork := range model.Networks {
+		device, ok := devices[ipNetwork.Link]
+		if !ok {
+			return nil, errors.Errorf("network %s refers to unknown link %s", ipNetwork.ID, ipNetwork.Link)
+		}
+		switch ipNetwork.Type {
+		case "ipv4", "ipv6":
+			address, err := cidr(ipNetwork.IPAddress, ipNetwork.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			device.Addresses = append(device.Addresses, address)
+		case "ipv4_dhcp":
+			device.DHCP4 = true
+		case "ipv6_dhcp":
+			device.DHCP6 = true
+		case "ipv6_slaac":
+			acceptRA := true
+			device.AcceptRA = &acceptRA
+		}
+		dns := append([]string{}, globalDNS...)
+		for _, route := range ipNetwork.Routes {
+			to, err := cidr(route.Network, route.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			device.Routes = append(device.Routes, netplanRoute{
+				To:     to,
+				Via:    route.Gateway,
+				Metric: route.Metric,
+				Table:  route.Table,
+			})
+			dns = append(dns, dnsAddresses(route.Services)...)
+		}
+		for _, rule := range ipNetwork.Rules {
+			device.RoutingPolicy = append(device.RoutingPolicy, netplanRoutingPolicy(rule))
+		}
+		if len(dns) > 0 {
+			if device.Nameservers == nil {
+				device.Nameservers = &netplanNameservers{}
+			}
+			device.Nameservers.Addresses = appendUnique(device.Nameservers.Addresses, dns...)
+		}
+	}
+
+	return yaml.Marshal(netplanConfig{Network: network})
+}
+
+type nmstateConfig struct {
+	Interfaces  []*nmstateInterface `json:"interfaces"`
+	Routes      *nmstateRoutes      `json:"routes,omitempty"`
+	RouteRules  *nmstateRouteRules  `json:"route-rules,omitempty"`
+	DNSResolver *nmstateDNSResolver `json:"dns-resolver,omitempty"`
+}
+
+type nmstateInterface struct {
+	Name            string                  `json:"name"`
+	Type            string                  `json:"type"`
+	State           string                  `json:"state"`
+	MACAddress      string                  `json:"mac-address,omitempty"`
+	MTU             int                     `json:"mtu,omitempty"`
+	LinkAggregation *nmstateLinkAggregation `json:"link-aggregation,omitempty"`
+	Vlan            *nmstateVlan            `json:"vlan,omitempty"`
+	IPv4            nmstateIPConfig         `json:"ipv4"`
+	IPv6            nmstateIPConfig         `json:"ipv6"`
+}
+
+type nmstateLinkAggregation struct {
+	Mode    string              `json:"mode,omitempty"`
+	Options *nmstateBondOptions `json:"options,omitempty"`
+	Port    []string            `json:"port"`
+}
+
+type nmstateBondOptions struct {
+	MIIMon         *int   `json:"miimon,omitempty"`
+	XmitHashPolicy string `json:"xmit_hash_policy,omitempty"`
+	LACPRate       string `json:"lacp_rate,omitempty"`
+	Primary        string `json:"primary,omitempty"`
+	FailOverMac    string `json:"fail_over_mac,omitempty"`
+}
+
+type nmstateVlan struct {
+	BaseIface string `json:"base-iface"`
+	ID        int    `json:"id"`
+}
+
+type nmstateIPConfig struct {
+	Enabled  bool             `json:"enabled"`
+	DHCP     bool             `json:"dhcp,omitempty"`
+	Autoconf bool             `json:"autoconf,omitempty"`
+	Address  []nmstateAddress `json:"address,omitempty"`
+}
+
+type nmstateAddress struct {
+	IP           string `json:"ip"`
+	PrefixLength int    `json:"prefix-length"`
+}
+
+type nmstateRoutes struct {
+	Config []nmstateRoute `json:"config"`
+}
+
+type nmstateRoute struct {
+	Destination      string `json:"destination"`
+	NextHopAddress   string `json:"next-hop-address,omitempty"`
+	NextHopInterface string `json:"next-hop-interface"`
+	Metric           *int   `json:"metric,omitempty"`
+	TableID          *int   `json:"table-id,omitempty"`
+}
+
+type nmstateRouteRules struct {
+	Config []nmstateRouteRule `json:"config"`
+}
+
+type nmstateRouteRule struct {
+	IPFrom     string `json:"ip-from"`
+	RouteTable int    `json:"route-table"`
+	Priority   *int   `json:"priority,omitempty"`
+}
+
+type nmstateDNSResolver struct {
+	Config nmstateDNSConfig `json:"config"`
+}
+
+type nmstateDNSConfig struct {
+	Server []string `json:"server"`
+}
+
+// renderNMState renders the network data as NMState YAML.
+func renderNMState(model networkDataModel) ([]byte, error) {
+	config := nmstateConfig{Interfaces: []*nmstateInterface{}}
+	interfaces := map[string]*nmstateInterface{}
+
+	for _, link := range model.Links {
+		iface := &nmstateInterface{
+			Name:  link.ID,
+			State: "up",
+			MTU:   link.MTU,
+		}
+		switch link.Type {
+		case "bond":
+			iface.Type = "bond"
+			iface.MACAddress = link.EthernetMACAddress
+			iface.LinkAggregation = &nmstateLinkAggregation{
+				Mode: link.BondMode,
+				Port: link.BondLinks,
+			}
+			options := nmstateBondOptions{
+				MIIMon:         link.BondMIIMon,
+				XmitHashPolicy: link.BondXmitHashPolicy,
+				LACPRate:       link.BondLACPRate,
+				Primary:        link.BondPrimary,
+				FailOverMac:    link.BondFailOverMac,
+			}
+			if options != (nmstateBondOptions{}) {
+				iface.LinkAggregation.Options = &options
+			}
+		case "vlan":
+			iface.Type = "vlan"
+			iface.MACAddress = link.VlanMACAddress
+			iface.Vlan = &nmstateVlan{BaseIface: link.VlanLink, ID: link.VlanID}
+		default:
+			iface.Type = "ethernet"
+			iface.MACAddress = link.EthernetMACAddress
+		}
+		config.Interfaces = append(config.Interfaces, iface)
+		interfaces[link.ID] = iface
+	}
+
+	dns := dnsAddresses(model.Services)
+	for _, ipNetwork := range model.Networks {
+		iface, ok := interfaces[ipNetwork.Link]
+		if !ok {
+			return nil, errors.Errorf("network %s refers to unknown link %s", ipNetwork.ID, ipNetwork.Link)
+		}
+		switch ipNetwork.Type {
+		case "ipv4", "ipv6":
+			prefix, err := maskToPrefix(ipNetwork.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			ipConfig := &iface.IPv4
+			if ipNetwork.Type == "ipv6" {
+				ipConfig = &iface.IPv6
+			}
+			ipConfig.Enabled = true
+			ipConfig.Address = append(ipConfig.Address, nmstateAddress{IP: ipNetwork.IPAddress, PrefixLength: prefix})
+		case "ipv4_dhcp":
+			iface.IPv4.Enabled = true
+			iface.IPv4.DHCP = true
+		case "ipv6_dhcp":
+			iface.IPv6.Enabled = true
+			iface.IPv6.DHCP = true
+		case "ipv6_slaac":
+			iface.IPv6.Enabled = true
+			iface.IPv6.Autoconf = true
+		}
+		for _, route := range ipNetwork.Routes {
+			destination, err := cidr(route.Network, route.Netmask)
+			if err != nil {
+				return nil, err
+			}
+			if config.Routes == nil {
+				config.Routes = &nmstateRoutes{}
+			}
+			config.Routes.Config = append(config.Routes.Config, nmstateRoute{
+				Destination:      destination,
+				NextHopAddress:   route.Gateway,
+				NextHopInterface: ipNetwork.Link,
+				Metric:           route.Metric,
+				TableID:          route.Table,
+			})
+			dns = appendUnique(dns, dnsAddresses(route.Services)...)
+		}
+		for _, rule := range ipNetwork.Rules {
+			if config.RouteRules == nil {
+				config.RouteRules = &nmstateRouteRules{}
+			}
+			config.RouteRules.Config = append(config.RouteRules.Config, nmstateRouteRule{
+				IPFrom:     rule.From,
+				RouteTable: rule.Table,
+				Priority:   rule.Priority,
+			})
+		}
+	}
+	if len(dns) > 0 {
+		config.DNSResolver = &nmstateDNSResolver{Config: nmstateDNSConfig{Server: dns}}
+	}
+
+	return yaml.Marshal(config)
+}
@@ -0,0 +1,429 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/yaml"
+)
+
+const openStackNetworkData = `
+links:
+- ethernet_mac_address: "00:01:02:03:04:05"
+  id: eth0
+  mtu: 1500
+  type: phy
+- ethernet_mac_address: "00:01:02:03:04:06"
+  id: eth1
+  mtu: 1500
+  type: phy
+- bond_links:
+  - eth0
+  - eth1
+  bond_mode: active-backup
+  ethernet_mac_address: "00:01:02:03:04:05"
+  id: bond0
+  mtu: 1500
+  type: bond
+- id: vlan1
+  mtu: 1500
+  type: vlan
+  vlan_id: 1
+  vlan_link: bond0
+  vlan_mac_address: "00:01:02:03:04:05"
+networks:
+- id: abc
+  ip_address: 192.168.0.14
+  link: bond0
+  netmask: 255.255.255.0
+  routes:
+  - gateway: 192.168.0.1
+    netmask: 0.0.0.0
+    network: 0.0.0.0
+    services:
+    - address: 8.8.4.4
+      type: dns
+  type: ipv4
+- id: def
+  ip_address: "2001::10"
+  link: vlan1
+  netmask: "ffff:ffff:ffff:ffff::"
+  routes: []
+  type: ipv6
+- id: ghi
+  link: eth0
+  routes: []
+  type: ipv4_dhcp
+- id: jkl
+  link: vlan1
+  routes: []
+  type: ipv6_slaac
+services:
+- address: 8.8.8.8
+  type: dns
+`
+
+const policyRoutingNetworkData = `
+links:
+- ethernet_mac_address: "00:01:02:03:04:05"
+  id: eth0
+  mtu: 1500
+  type: phy
+networks:
+- id: abc
+  ip_address: 192.168.0.14
+  link: eth0
+  netmask: 255.255.255.0
+  routes:
+  - gateway: 192.168.0.1
+    metric: 200
+    netmask: 0.0.0.0
+    network: 0.0.0.0
+    services: []
+    table: 100
+  rules:
+  - from: 192.168.0.14/32
+    priority: 1000
+    table: 100
+  type: ipv4
+services: []
+`
+
+const bondOptionsNetworkData = `
+links:
+- ethernet_mac_address: "00:01:02:03:04:05"
+  id: eth0
+  mtu: 1500
+  type: phy
+- ethernet_mac_address: "00:01:02:03:04:06"
+  id: eth1
+  mtu: 1500
+  type: phy
+- bond_fail_over_mac: active
+  bond_links:
+  - eth0
+  - eth1
+  bond_miimon: 100
+  bond_mode: active-backup
+  bond_primary: eth1
+  ethernet_mac_address: "00:01:02:03:04:05"
+  id: bond0
+  mtu: 1500
+  type: bond
+networks: []
+services: []
+`
+
+var _ = Describe("Network data formats", func() {
+	DescribeTable("Test convertNetworkData",
+		func(format string, expected string) {
+			output, err := convertNetworkData([]byte(openStackNetworkData), format, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchYAML(expected))
+		},
+		Entry("OpenStack", infrav1.NetworkDataFormatOpenStack, openStackNetworkData),
+		Entry("Netplan", infrav1.NetworkDataFormatNetplan, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        macaddress: "00:01:02:03:04:05"
+      set-name: eth0
+      mtu: 1500
+      dhcp4: true
+      nameservers:
+        addresses:
+        - 8.8.8.8
+    eth1:
+      match:
+        macaddress: "00:01:02:03:04:06"
+      set-name: eth1
+      mtu: 1500
+  bonds:
+    bond0:
+      macaddress: "00:01:02:03:04:05"
+      mtu: 1500
+      interfaces:
+      - eth0
+      - eth1
+      parameters:
+        mode: active-backup
+      addresses:
+      - 192.168.0.14/24
+      routes:
+      - to: 0.0.0.0/0
+        via: 192.168.0.1
+      nameservers:
+        addresses:
+        - 8.8.8.8
+        - 8.8.4.4
+  vlans:
+    vlan1:
+      id: 1
+      link: bond0
+      macaddress: "00:01:02:03:04:05"
+      mtu: 1500
+      accept-ra: true
+      addresses:
+      - 2001::10/64
+      nameservers:
+        addresses:
+        - 8.8.8.8
+`),
+		Entry("NMState", infrav1.NetworkDataFormatNMState, `
+interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  ipv4:
+    enabled: true
+    dhcp: true
+  ipv6:
+    enabled: false
+- name: eth1
+  type: ethernet
+  state: up
+  mac-address: "00:01:02:03:04:06"
+  mtu: 1500
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+- name: bond0
+  type: bond
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  link-aggregation:
+    mode: active-backup
+    port:
+    - eth0
+    - eth1
+  ipv4:
+    enabled: true
+    address:
+    - ip: 192.168.0.14
+      prefix-length: 24
+  ipv6:
+    enabled: false
+- name: vlan1
+  type: vlan
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  vlan:
+    base-iface: bond0
+    id: 1
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: true
+    autoconf: true
+    address:
+    - ip: "2001::10"
+      prefix-length: 64
+routes:
+  config:
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.0.1
+    next-hop-interface: bond0
+dns-resolver:
+  config:
+    server:
+    - 8.8.8.8
+    - 8.8.4.4
+`),
+	)
+
+	DescribeTable("Test convertNetworkData with policy routing",
+		func(format string, expected string) {
+			output, err := convertNetworkData([]byte(policyRoutingNetworkData), format, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchYAML(expected))
+		},
+		Entry("Netplan", infrav1.NetworkDataFormatNetplan, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        macaddress: "00:01:02:03:04:05"
+      set-name: eth0
+      mtu: 1500
+      addresses:
+      - 192.168.0.14/24
+      routes:
+      - to: 0.0.0.0/0
+        via: 192.168.0.1
+        metric: 200
+        table: 100
+      routing-policy:
+      - from: 192.168.0.14/32
+        table: 100
+        priority: 1000
+`),
+		Entry("NMState", infrav1.NetworkDataFormatNMState, `
+interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  ipv4:
+    enabled: true
+    address:
+    - ip: 192.168.0.14
+      prefix-length: 24
+  ipv6:
+    enabled: false
+routes:
+  config:
+  - destination: 0.0.0.0/0
+    next-hop-address: 192.168.0.1
+    next-hop-interface: eth0
+    metric: 200
+    table-id: 100
+route-rules:
+  config:
+  - ip-from: 192.168.0.14/32
+    route-table: 100
+    priority: 1000
+`),
+	)
+
+	DescribeTable("Test convertNetworkData with bond options",
+		func(format string, expected string) {
+			output, err := convertNetworkData([]byte(bondOptionsNetworkData), format, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchYAML(expected))
+		},
+		Entry("Netplan", infrav1.NetworkDataFormatNetplan, `
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        macaddress: "00:01:02:03:04:05"
+      set-name: eth0
+      mtu: 1500
+    eth1:
+      match:
+        macaddress: "00:01:02:03:04:06"
+      set-name: eth1
+      mtu: 1500
+  bonds:
+    bond0:
+      macaddress: "00:01:02:03:04:05"
+      mtu: 1500
+      interfaces:
+      - eth0
+      - eth1
+      parameters:
+        mode: active-backup
+        mii-monitor-interval: 100
+        primary: eth1
+        fail-over-mac-policy: active
+`),
+		Entry("NMState", infrav1.NetworkDataFormatNMState, `
+interfaces:
+- name: eth0
+  type: ethernet
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+- name: eth1
+  type: ethernet
+  state: up
+  mac-address: "00:01:02:03:04:06"
+  mtu: 1500
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+- name: bond0
+  type: bond
+  state: up
+  mac-address: "00:01:02:03:04:05"
+  mtu: 1500
+  link-aggregation:
+    mode: active-backup
+    options:
+      miimon: 100
+      primary: eth1
+      fail_over_mac: active
+    port:
+    - eth0
+    - eth1
+  ipv4:
+    enabled: false
+  ipv6:
+    enabled: false
+`),
+	)
+
+	It("Fails on a network of an unknown link", func() {
+		networkData, err := yaml.Marshal(networkDataModel{
+			Networks: []networkDataNetworkModel{{Type: "ipv4_dhcp", ID: "abc", Link: "eth0"}},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = convertNetworkData(networkData, infrav1.NetworkDataFormatNetplan, "")
+		Expect(err).To(HaveOccurred())
+		_, err = convertNetworkData(networkData, infrav1.NetworkDataFormatNMState, "")
+		Expect(err).To(HaveOccurred())
+		_, err = convertNetworkData(networkData, infrav1.NetworkDataFormatIgnition, "")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("Test maskToPrefix",
+		func(mask string, expectedPrefix int, expectError bool) {
+			prefix, err := maskToPrefix(mask)
+			if expectError {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefix).To(Equal(expectedPrefix))
+		},
+		Entry("IPv4", "255.255.255.0", 24, false),
+		Entry("IPv4 default", "0.0.0.0", 0, false),
+		Entry("IPv6", "ffff:ffff:ffff:ffff::", 64, false),
+		Entry("Non canonical", "255.0.255.0", 0, true),
+		Entry("Invalid", "abc", 0, true),
+	)
+
+	DescribeTable("Test networkDataFormat",
+		func(networkData *infrav1.NetworkData, expected string) {
+			Expect(networkDataFormat(networkData)).To(Equal(expected))
+		},
+		Entry("No network data", nil, infrav1.NetworkDataFormatOpenStack),
+		Entry("Default", &infrav1.NetworkData{}, infrav1.NetworkDataFormatOpenStack),
+		Entry("Netplan", &infrav1.NetworkData{
+			Format: pointer.String(infrav1.NetworkDataFormatNetplan),
+		}, infrav1.NetworkDataFormatNetplan),
+	)
+})
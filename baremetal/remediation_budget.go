@@ -0,0 +1,227 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"fmt"
+
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AcquireRemediationBudget returns true if the remediation budget of the
+// cluster allows starting the remediation, in which case the remediation is
+// recorded as in flight and its start time is recorded on the Metal3Cluster.
+// Otherwise the RemediationAllowed condition of the Metal3Cluster is set to
+// false and the remediation should wait. Remediations are always allowed when
+// the cluster does not define a budget.
+func (r *RemediationManager) AcquireRemediationBudget(ctx context.Context) (bool, error) {
+	metal3Cluster, err := r.getMetal3Cluster(ctx)
+	if err != nil {
+		return false, err
+	}
+	if metal3Cluster == nil || metal3Cluster.Spec.RemediationBudget == nil {
+		return true, nil
+	}
+	budget := metal3Cluster.Spec.RemediationBudget
+
+	inFlight, err := r.remediationsInFlight(ctx, metal3Cluster)
+	if err != nil {
+		return false, err
+	}
+	for _, name := range inFlight {
+		if name == r.Metal3Remediation.Name {
+			// Acquired already, the phase of the remediation was not saved.
+			return true, nil
+		}
+	}
+
+	now := metav1.Now()
+	startTimes := remediationStartTimesInWindow(budget, metal3Cluster.Status.RemediationStartTimes, now)
+	message := remediationBudgetExceeded(budget, len(inFlight), len(startTimes))
+	allowed := message == ""
+	if allowed {
+		inFlight = append(inFlight, r.Metal3Remediation.Name)
+		if budget.MaxPerWindow != nil {
+			startTimes = append(startTimes, now)
+		}
+		conditions.MarkTrue(metal3Cluster, infrav1.RemediationAllowedCondition)
+	} else {
+		r.Log.Info("Remediation budget of the cluster exceeded", "reason", message)
+		conditions.MarkFalse(metal3Cluster, infrav1.RemediationAllowedCondition,
+			infrav1.RemediationBudgetExceededReason, clusterv1.ConditionSeverityWarning, message,
+		)
+	}
+	setRemediationBudgetStatus(metal3Cluster, inFlight, startTimes)
+
+	// The status is updated, not patched, so that concurrent remediations of
+	// the cluster conflict instead of overriding each other's record.
+	if err := r.Client.Status().Update(ctx, metal3Cluster); err != nil {
+		return false, errors.Wrap(err, "failed to update the remediation budget of the Metal3Cluster")
+	}
+	return allowed, nil
+}
+
+// ReleaseRemediationBudget removes the remediation from the remediations in
+// flight of the cluster once it is over, and sets the RemediationAllowed
+// condition of the Metal3Cluster back to true if the budget allows new
+// remediations.
+func (r *RemediationManager) ReleaseRemediationBudget(ctx context.Context) error {
+	metal3Cluster, err := r.getMetal3Cluster(ctx)
+	if err != nil {
+		return err
+	}
+	if metal3Cluster == nil || metal3Cluster.Spec.RemediationBudget == nil {
+		return nil
+	}
+	budget := metal3Cluster.Spec.RemediationBudget
+
+	inFlight := []string{}
+	for _, name := range metal3Cluster.Status.RemediationsInFlight {
+		if name != r.Metal3Remediation.Name {
+			inFlight = append(inFlight, name)
+		}
+	}
+	released := len(inFlight) != len(metal3Cluster.Status.RemediationsInFlight)
+
+	startTimes := remediationStartTimesInWindow(budget, metal3Cluster.Status.RemediationStartTimes, metav1.Now())
+	freed := conditions.IsFalse(metal3Cluster, infrav1.RemediationAllowedCondition) &&
+		remediationBudgetExceeded(budget, len(inFlight), len(startTimes)) == ""
+	if !released && !freed {
+		return nil
+	}
+	if freed {
+		conditions.MarkTrue(metal3Cluster, infrav1.RemediationAllowedCondition)
+	}
+	setRemediationBudgetStatus(metal3Cluster, inFlight, startTimes)
+
+	if err := r.Client.Status().Update(ctx, metal3Cluster); err != nil {
+		return errors.Wrap(err, "failed to release the remediation budget of the Metal3Cluster")
+	}
+	return nil
+}
+
+// remediationStartTimesInWindow returns the start times of the remediations
+// within the window of the budget.
+func remediationStartTimesInWindow(budget *infrav1.RemediationBudget, startTimes []metav1.Time,
+	now metav1.Time) []metav1.Time {
+	inWindow := []metav1.Time{}
+	if budget.MaxPerWindow == nil || budget.Window == nil {
+		return inWindow
+	}
+	windowStart := now.Add(-budget.Window.Duration)
+	for _, startTime := range startTimes {
+		if startTime.Time.After(windowStart) {
+			inWindow = append(inWindow, startTime)
+		}
+	}
+	return inWindow
+}
+
+// remediationBudgetExceeded returns why the budget does not allow one more
+// remediation, or an empty string if it does.
+func remediationBudgetExceeded(budget *infrav1.RemediationBudget, inFlight, started int) string {
+	if budget.MaxInFlight != nil && inFlight >= *budget.MaxInFlight {
+		return fmt.Sprintf("%d remediation(s) in progress, the maximum is %d", inFlight, *budget.MaxInFlight)
+	}
+	if budget.MaxPerWindow != nil && started >= *budget.MaxPerWindow {
+		return fmt.Sprintf("%d remediation(s) started in the last %s, the maximum is %d",
+			started, budget.Window.Duration, *budget.MaxPerWindow,
+		)
+	}
+	return ""
+}
+
+// setRemediationBudgetStatus sets the remediations in flight and the start
+// times of the remediations on the Metal3Cluster.
+func setRemediationBudgetStatus(metal3Cluster *infrav1.Metal3Cluster, inFlight []string, startTimes []metav1.Time) {
+	if len(inFlight) == 0 {
+		inFlight = nil
+	}
+	if len(startTimes) == 0 {
+		startTimes = nil
+	}
+	metal3Cluster.Status.RemediationsInFlight = inFlight
+	metal3Cluster.Status.RemediationStartTimes = startTimes
+}
+
+// getMetal3Cluster returns the Metal3Cluster of the cluster of the Machine, or
+// nil if the infrastructure of the cluster is not a Metal3Cluster, in which
+// case there is no remediation budget.
+func (r *RemediationManager) getMetal3Cluster(ctx context.Context) (*infrav1.Metal3Cluster, error) {
+	capiMachine, err := r.GetCapiMachine(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, capiMachine.ObjectMeta)
+	if err != nil {
+		r.Log.Error(err, "Machine is missing cluster label or cluster does not exist")
+		return nil, errors.Wrapf(err, "Machine is missing cluster label or cluster does not exist")
+	}
+	if cluster.Spec.InfrastructureRef == nil || cluster.Spec.InfrastructureRef.Kind != "Metal3Cluster" {
+		return nil, nil
+	}
+
+	metal3Cluster := &infrav1.Metal3Cluster{}
+	key := client.ObjectKey{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, key, metal3Cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get Metal3Cluster %s", key.Name)
+	}
+	return metal3Cluster, nil
+}
+
+// remediationsInFlight returns the remediations recorded as in flight on the
+// Metal3Cluster, without the ones that are over or deleted. The remediations
+// recorded are counted even before their phase is saved, so that concurrent
+// remediations cannot all start.
+func (r *RemediationManager) remediationsInFlight(ctx context.Context,
+	metal3Cluster *infrav1.Metal3Cluster) ([]string, error) {
+	inFlight := []string{}
+	for _, name := range metal3Cluster.Status.RemediationsInFlight {
+		if name == r.Metal3Remediation.Name {
+			inFlight = append(inFlight, name)
+			continue
+		}
+		remediation := &infrav1.Metal3Remediation{}
+		key := client.ObjectKey{Namespace: r.Metal3Remediation.Namespace, Name: name}
+		if err := r.Client.Get(ctx, key, remediation); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get Metal3Remediation %s", name)
+		}
+		switch remediation.Status.Phase {
+		case "", infrav1.PhaseRunning, infrav1.PhaseWaiting:
+			inFlight = append(inFlight, name)
+		}
+	}
+	return inFlight, nil
+}
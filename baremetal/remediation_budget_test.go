@@ -0,0 +1,251 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Remediation budget", func() {
+	type testCaseRemediationBudget struct {
+		Budget             *infrav1.RemediationBudget
+		StartTimes         []time.Duration
+		OtherPhases        []string
+		Acquired           bool
+		ExpectAllowed      bool
+		ExpectedStartTimes int
+		ExpectedInFlight   int
+	}
+
+	// budgetObjects returns the cluster, the Metal3Cluster, the Machine and
+	// the Metal3Remediation, with the other remediations of the given phases
+	// recorded as in flight on the Metal3Cluster.
+	budgetObjects := func(budget *infrav1.RemediationBudget, otherPhases []string) (
+		*infrav1.Metal3Cluster, *clusterv1.Machine, *infrav1.Metal3Remediation, []client.Object) {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mycluster",
+				Namespace: namespaceName,
+			},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: &corev1.ObjectReference{
+					Kind: "Metal3Cluster",
+					Name: "mym3cluster",
+				},
+			},
+		}
+		metal3Cluster := &infrav1.Metal3Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mym3cluster",
+				Namespace: namespaceName,
+			},
+			Spec: infrav1.Metal3ClusterSpec{
+				RemediationBudget: budget,
+			},
+		}
+		capiMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mymachine",
+				Namespace: namespaceName,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName: "mycluster",
+				},
+			},
+		}
+		remediation := &infrav1.Metal3Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mymachine",
+				Namespace: namespaceName,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName: "mycluster",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Machine",
+						Name:       "mymachine",
+					},
+				},
+			},
+		}
+		objects := []client.Object{cluster, capiMachine, remediation}
+		for i, phase := range otherPhases {
+			name := "othermachine-" + string(rune('a'+i))
+			metal3Cluster.Status.RemediationsInFlight = append(metal3Cluster.Status.RemediationsInFlight, name)
+			objects = append(objects, &infrav1.Metal3Remediation{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespaceName,
+					Labels: map[string]string{
+						clusterv1.ClusterLabelName: "mycluster",
+					},
+				},
+				Status: infrav1.Metal3RemediationStatus{
+					Phase: phase,
+				},
+			})
+		}
+		// A remediation recorded in flight that was deleted is not counted.
+		metal3Cluster.Status.RemediationsInFlight = append(metal3Cluster.Status.RemediationsInFlight, "deleted")
+		return metal3Cluster, capiMachine, remediation, objects
+	}
+
+	DescribeTable("Test AcquireRemediationBudget",
+		func(tc testCaseRemediationBudget) {
+			metal3Cluster, capiMachine, remediation, objects := budgetObjects(tc.Budget, tc.OtherPhases)
+			for _, age := range tc.StartTimes {
+				metal3Cluster.Status.RemediationStartTimes = append(metal3Cluster.Status.RemediationStartTimes,
+					metav1.NewTime(time.Now().Add(-age)),
+				)
+			}
+			if tc.Acquired {
+				metal3Cluster.Status.RemediationsInFlight = append(metal3Cluster.Status.RemediationsInFlight,
+					remediation.Name,
+				)
+			}
+			objects = append(objects, metal3Cluster)
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+
+			remediationMgr, err := NewRemediationManager(fakeClient, nil, remediation, nil, capiMachine,
+				logr.Discard(),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			allowed, err := remediationMgr.AcquireRemediationBudget(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).To(Equal(tc.ExpectAllowed))
+
+			savedCluster := &infrav1.Metal3Cluster{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(metal3Cluster), savedCluster)).To(Succeed())
+			Expect(savedCluster.Status.RemediationStartTimes).To(HaveLen(tc.ExpectedStartTimes))
+			if tc.Budget == nil || tc.Acquired {
+				Expect(conditions.Has(savedCluster, infrav1.RemediationAllowedCondition)).To(BeFalse())
+				return
+			}
+			if tc.ExpectAllowed {
+				Expect(conditions.IsTrue(savedCluster, infrav1.RemediationAllowedCondition)).To(BeTrue())
+				Expect(savedCluster.Status.RemediationsInFlight).To(ContainElement(remediation.Name))
+				Expect(savedCluster.Status.RemediationsInFlight).To(HaveLen(tc.ExpectedInFlight))
+			} else {
+				Expect(conditions.IsFalse(savedCluster, infrav1.RemediationAllowedCondition)).To(BeTrue())
+				Expect(conditions.GetReason(savedCluster, infrav1.RemediationAllowedCondition)).To(Equal(infrav1.RemediationBudgetExceededReason))
+			}
+		},
+		Entry("No budget", testCaseRemediationBudget{
+			OtherPhases:   []string{infrav1.PhaseRunning, infrav1.PhaseWaiting},
+			ExpectAllowed: true,
+		}),
+		Entry("Maximum in flight not reached", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxInFlight: pointer.Int(2),
+			},
+			OtherPhases:      []string{infrav1.PhaseRunning, infrav1.PhaseDeleting, infrav1.PhaseFailed},
+			ExpectAllowed:    true,
+			ExpectedInFlight: 2,
+		}),
+		Entry("Maximum in flight reached", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxInFlight: pointer.Int(2),
+			},
+			OtherPhases:   []string{infrav1.PhaseRunning, infrav1.PhaseWaiting},
+			ExpectAllowed: false,
+		}),
+		Entry("Maximum in flight reached by a remediation not running yet", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxInFlight: pointer.Int(2),
+			},
+			OtherPhases:   []string{infrav1.PhaseRunning, ""},
+			ExpectAllowed: false,
+		}),
+		Entry("Budget acquired already", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxInFlight: pointer.Int(1),
+			},
+			Acquired:      true,
+			ExpectAllowed: true,
+		}),
+		Entry("Maximum per window not reached", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxPerWindow: pointer.Int(2),
+				Window:       &metav1.Duration{Duration: time.Hour},
+			},
+			StartTimes:         []time.Duration{2 * time.Hour, 10 * time.Minute},
+			ExpectAllowed:      true,
+			ExpectedStartTimes: 2,
+			ExpectedInFlight:   1,
+		}),
+		Entry("Maximum per window reached", testCaseRemediationBudget{
+			Budget: &infrav1.RemediationBudget{
+				MaxPerWindow: pointer.Int(2),
+				Window:       &metav1.Duration{Duration: time.Hour},
+			},
+			StartTimes:         []time.Duration{20 * time.Minute, 10 * time.Minute},
+			ExpectAllowed:      false,
+			ExpectedStartTimes: 2,
+		}),
+	)
+
+	It("Releases the remediation budget", func() {
+		budget := &infrav1.RemediationBudget{MaxInFlight: pointer.Int(1)}
+		metal3Cluster, capiMachine, remediation, objects := budgetObjects(budget, nil)
+		metal3Cluster.Status.RemediationsInFlight = []string{remediation.Name}
+		conditions.MarkFalse(metal3Cluster, infrav1.RemediationAllowedCondition,
+			infrav1.RemediationBudgetExceededReason, clusterv1.ConditionSeverityWarning, "",
+		)
+		objects = append(objects, metal3Cluster)
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		remediationMgr, err := NewRemediationManager(fakeClient, nil, remediation, nil, capiMachine,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(remediationMgr.ReleaseRemediationBudget(context.TODO())).To(Succeed())
+		savedCluster := &infrav1.Metal3Cluster{}
+		Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(metal3Cluster), savedCluster)).To(Succeed())
+		Expect(savedCluster.Status.RemediationsInFlight).To(BeEmpty())
+		Expect(conditions.IsTrue(savedCluster, infrav1.RemediationAllowedCondition)).To(BeTrue())
+	})
+
+	It("Allows the remediations of a cluster without Metal3Cluster", func() {
+		_, capiMachine, remediation, objects := budgetObjects(nil, nil)
+		fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+		remediationMgr, err := NewRemediationManager(fakeClient, nil, remediation, nil, capiMachine,
+			logr.Discard(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		allowed, err := remediationMgr.AcquireRemediationBudget(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(allowed).To(BeTrue())
+		Expect(remediationMgr.ReleaseRemediationBudget(context.TODO())).To(Succeed())
+	})
+})
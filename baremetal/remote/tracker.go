@@ -0,0 +1,341 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1typed "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// nodeResyncPeriod is the resync period of the Node informers. The resyncs
+	// do not change the Nodes, so they do not call the handlers.
+	nodeResyncPeriod = 10 * time.Minute
+	// healthCheckInterval is the interval of the health checks of the tracked
+	// clusters. The clusters deleted are removed at the same interval.
+	healthCheckInterval = 10 * time.Second
+	// healthCheckTimeout is the timeout of a health check.
+	healthCheckTimeout = 10 * time.Second
+	// healthCheckFailureThreshold is the number of consecutive failed health
+	// checks after which a cluster is removed from the tracker.
+	healthCheckFailureThreshold = 3
+)
+
+// NodeHandler is called with the cluster and the Node when a Node is added to
+// the cache of the cluster, or when its labels, annotations or taints change.
+type NodeHandler func(cluster types.NamespacedName, node *corev1.Node)
+
+// ClusterClientTracker caches the clients and the Node informers of the
+// workload clusters, keyed by the kubeconfig secret of the cluster. The entry
+// of a cluster is replaced when its kubeconfig secret changes, and removed
+// when the cluster is deleted or fails its health checks.
+type ClusterClientTracker struct {
+	client   client.Client
+	lock     sync.Mutex
+	clusters map[types.NamespacedName]*trackedCluster
+	// newClientset creates the clientset of a workload cluster.
+	newClientset func(*rest.Config) (kubernetes.Interface, error)
+}
+
+type trackedCluster struct {
+	cluster         types.NamespacedName
+	resourceVersion string
+	clientset       kubernetes.Interface
+	// healthClientset has a request timeout, unlike clientset that runs the
+	// watches.
+	healthClientset kubernetes.Interface
+	nodeInformer    cache.SharedIndexInformer
+	stop            chan struct{}
+	healthErr       error
+	failedChecks    int
+}
+
+// NewClusterClientTracker creates a new ClusterClientTracker. The kubeconfig
+// secrets are read with the client of the management cluster.
+func NewClusterClientTracker(c client.Client) *ClusterClientTracker {
+	return &ClusterClientTracker{
+		client:   c,
+		clusters: make(map[types.NamespacedName]*trackedCluster),
+		newClientset: func(config *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(config)
+		},
+	}
+}
+
+// kubeconfigSecretKey returns the key of the kubeconfig secret of the cluster.
+func kubeconfigSecretKey(cluster types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      secret.Name(cluster.Name, secret.Kubeconfig),
+	}
+}
+
+// GetClient returns the client of the cluster, creating it if the cluster is
+// not tracked yet or if its kubeconfig secret changed. It is a
+// baremetal.ClientGetter, the kubeconfig secrets being read with the client
+// of the tracker rather than the client given. It fails with the error of the
+// last health check of the cluster if it failed, so that the callers do not
+// wait for an unreachable cluster until it is removed from the tracker.
+func (t *ClusterClientTracker) GetClient(ctx context.Context, _ client.Client, cluster *clusterv1.Cluster) (corev1typed.CoreV1Interface, error) {
+	tc, err := t.getTrackedCluster(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Healthy(tc.cluster); err != nil {
+		return nil, err
+	}
+	return tc.clientset.CoreV1(), nil
+}
+
+// getTrackedCluster returns the entry of the cluster, replacing it when the
+// kubeconfig secret of the cluster changed.
+func (t *ClusterClientTracker) getTrackedCluster(ctx context.Context, cluster *clusterv1.Cluster) (*trackedCluster, error) {
+	clusterKey := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+	secretKey := kubeconfigSecretKey(clusterKey)
+	kubeconfigSecret := &corev1.Secret{}
+	if err := t.client.Get(ctx, secretKey, kubeconfigSecret); err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve kubeconfig secret for Cluster %q in namespace %q",
+			cluster.Name, cluster.Namespace)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if tc, ok := t.clusters[secretKey]; ok {
+		if tc.resourceVersion == kubeconfigSecret.ResourceVersion {
+			return tc, nil
+		}
+		t.removeLocked(secretKey)
+	}
+
+	kubeconfig, ok := kubeconfigSecret.Data[secret.KubeconfigDataName]
+	if !ok {
+		return nil, errors.Errorf("missing key %q in kubeconfig secret for Cluster %q in namespace %q",
+			secret.KubeconfigDataName, cluster.Name, cluster.Namespace)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create client configuration for Cluster %q in namespace %q",
+			cluster.Name, cluster.Namespace)
+	}
+	clientset, err := t.newClientset(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create clientset for Cluster %q in namespace %q",
+			cluster.Name, cluster.Namespace)
+	}
+	healthConfig := rest.CopyConfig(restConfig)
+	healthConfig.Timeout = healthCheckTimeout
+	healthClientset, err := t.newClientset(healthConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create clientset for Cluster %q in namespace %q",
+			cluster.Name, cluster.Namespace)
+	}
+
+	tc := &trackedCluster{
+		cluster:         clusterKey,
+		resourceVersion: kubeconfigSecret.ResourceVersion,
+		clientset:       clientset,
+		healthClientset: healthClientset,
+		stop:            make(chan struct{}),
+	}
+	t.clusters[secretKey] = tc
+	return tc, nil
+}
+
+// WatchNodes starts the Node informer of the cluster, unless it is already
+// running with the current kubeconfig secret of the cluster. The handler is
+// registered when the informer starts.
+func (t *ClusterClientTracker) WatchNodes(ctx context.Context, cluster *clusterv1.Cluster, handler NodeHandler) error {
+	tc, err := t.getTrackedCluster(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if tc.nodeInformer != nil {
+		return nil
+	}
+	tc.nodeInformer = informers.NewSharedInformerFactory(tc.clientset, nodeResyncPeriod).Core().V1().Nodes().Informer()
+	tc.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if node, ok := obj.(*corev1.Node); ok {
+				handler(tc.cluster, node)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok := oldObj.(*corev1.Node)
+			if !ok {
+				return
+			}
+			newNode, ok := newObj.(*corev1.Node)
+			if !ok {
+				return
+			}
+			if nodeChanged(oldNode, newNode) {
+				handler(tc.cluster, newNode)
+			}
+		},
+	})
+	go tc.nodeInformer.Run(tc.stop)
+	return nil
+}
+
+// nodeChanged returns true if the labels, annotations or taints of the Node
+// changed. The status updates of the Node are ignored.
+func nodeChanged(oldNode, newNode *corev1.Node) bool {
+	return !reflect.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!reflect.DeepEqual(oldNode.Annotations, newNode.Annotations) ||
+		!reflect.DeepEqual(oldNode.Spec.Taints, newNode.Spec.Taints)
+}
+
+// GetNode returns a copy of the Node from the cache of the cluster. It returns
+// false if the Node informer of the cluster is not running or not synced yet.
+func (t *ClusterClientTracker) GetNode(cluster *clusterv1.Cluster, name string) (*corev1.Node, bool, error) {
+	secretKey := kubeconfigSecretKey(types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name})
+	t.lock.Lock()
+	tc, ok := t.clusters[secretKey]
+	var nodeInformer cache.SharedIndexInformer
+	if ok {
+		nodeInformer = tc.nodeInformer
+	}
+	t.lock.Unlock()
+	if nodeInformer == nil || !nodeInformer.HasSynced() {
+		return nil, false, nil
+	}
+	obj, exists, err := nodeInformer.GetStore().GetByKey(name)
+	if err != nil {
+		return nil, false, err
+	}
+	if !exists {
+		return nil, true, apierrors.NewNotFound(corev1.Resource("nodes"), name)
+	}
+	node, ok := obj.(*corev1.Node)
+	if !ok {
+		return nil, false, errors.Errorf("expected a Node but got a %T", obj)
+	}
+	return node.DeepCopy(), true, nil
+}
+
+// HealthCheck checks that the API server of the cluster answers with the
+// client of the tracker. The cluster is removed from the tracker after
+// healthCheckFailureThreshold consecutive failures.
+func (t *ClusterClientTracker) HealthCheck(ctx context.Context, cluster *clusterv1.Cluster) error {
+	tc, err := t.getTrackedCluster(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	return t.healthCheck(tc)
+}
+
+// Healthy returns the result of the last health check of the cluster, or an
+// error if the cluster is not tracked.
+func (t *ClusterClientTracker) Healthy(cluster types.NamespacedName) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	tc, ok := t.clusters[kubeconfigSecretKey(cluster)]
+	if !ok {
+		return errors.Errorf("Cluster %q in namespace %q is not tracked", cluster.Name, cluster.Namespace)
+	}
+	return tc.healthErr
+}
+
+func (t *ClusterClientTracker) healthCheck(tc *trackedCluster) error {
+	_, err := tc.healthClientset.Discovery().ServerVersion()
+	if err != nil {
+		err = errors.Wrapf(err, "health check failed for Cluster %q in namespace %q",
+			tc.cluster.Name, tc.cluster.Namespace)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	secretKey := kubeconfigSecretKey(tc.cluster)
+	if t.clusters[secretKey] != tc {
+		// Replaced or removed meanwhile.
+		return err
+	}
+	tc.healthErr = err
+	if err == nil {
+		tc.failedChecks = 0
+		return nil
+	}
+	tc.failedChecks++
+	if tc.failedChecks >= healthCheckFailureThreshold {
+		t.removeLocked(secretKey)
+	}
+	return err
+}
+
+// Remove stops the Node informer of the cluster and removes the cluster from
+// the tracker.
+func (t *ClusterClientTracker) Remove(cluster types.NamespacedName) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.removeLocked(kubeconfigSecretKey(cluster))
+}
+
+func (t *ClusterClientTracker) removeLocked(secretKey types.NamespacedName) {
+	if tc, ok := t.clusters[secretKey]; ok {
+		close(tc.stop)
+		delete(t.clusters, secretKey)
+	}
+}
+
+// Start runs until the context is done, checking the health of the tracked
+// clusters and removing the deleted clusters periodically, and removes all
+// the clusters at the end. It implements the controller-runtime Runnable
+// interface.
+func (t *ClusterClientTracker) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, t.checkClusters, healthCheckInterval)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for secretKey := range t.clusters {
+		t.removeLocked(secretKey)
+	}
+	return nil
+}
+
+// checkClusters removes the clusters that do not exist anymore and checks the
+// health of the others.
+func (t *ClusterClientTracker) checkClusters(ctx context.Context) {
+	t.lock.Lock()
+	clusters := []*trackedCluster{}
+	for _, tc := range t.clusters {
+		clusters = append(clusters, tc)
+	}
+	t.lock.Unlock()
+	for _, tc := range clusters {
+		if err := t.client.Get(ctx, tc.cluster, &clusterv1.Cluster{}); apierrors.IsNotFound(err) {
+			t.Remove(tc.cluster)
+			continue
+		}
+		_ = t.healthCheck(tc)
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
+	clientfake "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// unhealthyClientset is a fake clientset whose API server does not answer.
+type unhealthyClientset struct {
+	*clientfake.Clientset
+}
+
+func (c unhealthyClientset) Discovery() discovery.DiscoveryInterface {
+	return unhealthyDiscovery{c.Clientset.Discovery()}
+}
+
+type unhealthyDiscovery struct {
+	discovery.DiscoveryInterface
+}
+
+func (unhealthyDiscovery) ServerVersion() (*version.Info, error) {
+	return nil, errors.New("connection refused")
+}
+
+var _ = Describe("Metal3 baremetal remote ClusterClientTracker", func() {
+	var (
+		cluster       *clusterv1.Cluster
+		clusterKey    types.NamespacedName
+		kubeconfig    *corev1.Secret
+		clientsets    int
+		unhealthy     bool
+		lock          sync.Mutex
+		handledNodes  []string
+		nodeHandler   NodeHandler
+		newTracker    func(c client.Client) *ClusterClientTracker
+		testScheme    *runtime.Scheme
+		testNodeLabel = map[string]string{"foo.metal3.io/bar": "blue"}
+	)
+
+	BeforeEach(func() {
+		cluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test1",
+				Namespace: "test",
+			},
+		}
+		clusterKey = types.NamespacedName{Namespace: "test", Name: "test1"}
+		kubeconfig = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test1-kubeconfig",
+				Namespace: "test",
+			},
+			Data: map[string][]byte{
+				secret.KubeconfigDataName: []byte(`
+clusters:
+- cluster:
+    server: https://test-cluster-api:6443
+  name: test-cluster-api
+contexts:
+- context:
+    cluster: test-cluster-api
+    user: kubernetes-admin
+  name: kubernetes-admin@test-cluster-api
+current-context: kubernetes-admin@test-cluster-api
+kind: Config
+preferences: {}
+users:
+- name: kubernetes-admin
+`),
+			},
+		}
+		testScheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(testScheme)).To(Succeed())
+		Expect(clusterv1.AddToScheme(testScheme)).To(Succeed())
+		clientsets = 0
+		unhealthy = false
+		handledNodes = []string{}
+		nodeHandler = func(cluster types.NamespacedName, node *corev1.Node) {
+			lock.Lock()
+			defer lock.Unlock()
+			handledNodes = append(handledNodes, cluster.Name+"/"+node.Name)
+		}
+		newTracker = func(c client.Client) *ClusterClientTracker {
+			tracker := NewClusterClientTracker(c)
+			tracker.newClientset = func(*rest.Config) (kubernetes.Interface, error) {
+				clientsets++
+				clientset := clientfake.NewSimpleClientset(&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "node-1",
+						Labels: testNodeLabel,
+					},
+				})
+				if unhealthy {
+					return unhealthyClientset{clientset}, nil
+				}
+				return clientset, nil
+			}
+			return tracker
+		}
+	})
+
+	It("caches the client until the kubeconfig secret changes", func() {
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(kubeconfig).Build()
+		tracker := newTracker(c)
+		defer tracker.Remove(clusterKey)
+		_, err := tracker.GetClient(context.TODO(), c, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = tracker.GetClient(context.TODO(), c, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		// The client and the health check client.
+		Expect(clientsets).To(Equal(2))
+
+		Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(kubeconfig), kubeconfig)).To(Succeed())
+		kubeconfig.Labels = map[string]string{"rotated": "true"}
+		Expect(c.Update(context.TODO(), kubeconfig)).To(Succeed())
+		_, err = tracker.GetClient(context.TODO(), c, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clientsets).To(Equal(4))
+	})
+
+	It("fails without kubeconfig secret", func() {
+		c := fake.NewClientBuilder().WithScheme(testScheme).Build()
+		tracker := newTracker(c)
+		_, err := tracker.GetClient(context.TODO(), c, cluster)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not found"))
+		Expect(tracker.WatchNodes(context.TODO(), cluster, nodeHandler)).NotTo(Succeed())
+	})
+
+	It("serves the Nodes of the cluster and calls the handler", func() {
+		tracker := newTracker(fake.NewClientBuilder().WithScheme(testScheme).WithObjects(kubeconfig).Build())
+		node, cached, err := tracker.GetNode(cluster, "node-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(BeFalse())
+		Expect(node).To(BeNil())
+
+		Expect(tracker.WatchNodes(context.TODO(), cluster, nodeHandler)).To(Succeed())
+		Expect(tracker.WatchNodes(context.TODO(), cluster, nodeHandler)).To(Succeed())
+		Eventually(func() bool {
+			_, cached, _ := tracker.GetNode(cluster, "node-1")
+			return cached
+		}).Should(BeTrue())
+		node, _, err = tracker.GetNode(cluster, "node-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(node.Labels).To(Equal(testNodeLabel))
+		Eventually(func() []string {
+			lock.Lock()
+			defer lock.Unlock()
+			return handledNodes
+		}).Should(Equal([]string{"test1/node-1"}))
+
+		_, cached, err = tracker.GetNode(cluster, "node-2")
+		Expect(cached).To(BeTrue())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		tracker.Remove(clusterKey)
+		_, cached, err = tracker.GetNode(cluster, "node-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cached).To(BeFalse())
+	})
+
+	It("removes the cluster after failed health checks", func() {
+		c := fake.NewClientBuilder().WithScheme(testScheme).WithObjects(kubeconfig, cluster).Build()
+		tracker := newTracker(c)
+		Expect(tracker.HealthCheck(context.TODO(), cluster)).To(Succeed())
+		Expect(tracker.Healthy(clusterKey)).To(Succeed())
+		tracker.Remove(clusterKey)
+		Expect(tracker.Healthy(clusterKey)).NotTo(Succeed())
+
+		unhealthy = true
+		Expect(tracker.HealthCheck(context.TODO(), cluster)).NotTo(Succeed())
+		Expect(tracker.Healthy(clusterKey)).NotTo(Succeed())
+		// The client of an unhealthy cluster is not returned.
+		_, err := tracker.GetClient(context.TODO(), nil, cluster)
+		Expect(err).To(HaveOccurred())
+		for i := 1; i < healthCheckFailureThreshold; i++ {
+			tracker.checkClusters(context.TODO())
+		}
+		Expect(tracker.clusters).To(BeEmpty())
+	})
+
+	It("removes the deleted clusters", func() {
+		tracker := newTracker(fake.NewClientBuilder().WithScheme(testScheme).WithObjects(kubeconfig).Build())
+		Expect(tracker.WatchNodes(context.TODO(), cluster, nodeHandler)).To(Succeed())
+		tracker.checkClusters(context.TODO())
+		Expect(tracker.clusters).To(BeEmpty())
+	})
+
+	DescribeTable("nodeChanged",
+		func(newNode *corev1.Node, expected bool) {
+			oldNode := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "node-1",
+					Labels: testNodeLabel,
+				},
+			}
+			Expect(nodeChanged(oldNode, newNode)).To(Equal(expected))
+		},
+		Entry("Status only", &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: testNodeLabel,
+			},
+			Status: corev1.NodeStatus{Phase: corev1.NodeRunning},
+		}, false),
+		Entry("Labels", &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+			},
+		}, true),
+		Entry("Annotations", &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "node-1",
+				Labels:      testNodeLabel,
+				Annotations: map[string]string{"foo": "bar"},
+			},
+		}, true),
+		Entry("Taints", &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node-1",
+				Labels: testNodeLabel,
+			},
+			Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "foo", Effect: corev1.TaintEffectNoSchedule}}},
+		}, true),
+	)
+})
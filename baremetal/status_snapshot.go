@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	// StatusSnapshotAnnotation is the annotation holding the versioned
+	// snapshot of the BareMetalHost status, hardware details included, taken
+	// when CAPM3 pauses the BareMetalHost.
+	StatusSnapshotAnnotation = "metal3.io/status-snapshot"
+	// statusSnapshotVersion is the version of the snapshot format.
+	statusSnapshotVersion = 1
+	// statusSnapshotEncodingGzip is the encoding of the status compressed with
+	// gzip, then base64 encoded.
+	statusSnapshotEncodingGzip = "gzip"
+)
+
+// Capm3StatusSnapshotCompression is the variable fetched from the
+// CAPM3_STATUS_SNAPSHOT_COMPRESSION environment variable. The status snapshots
+// are compressed unless it is "false", to keep the hardware details of large
+// hosts within the size limit of the annotations.
+var Capm3StatusSnapshotCompression = os.Getenv("CAPM3_STATUS_SNAPSHOT_COMPRESSION")
+
+// statusSnapshot is the content of the StatusSnapshotAnnotation.
+type statusSnapshot struct {
+	// Version is the version of the snapshot format.
+	Version int `json:"version"`
+	// Encoding is empty for a JSON status, or gzip.
+	Encoding string `json:"encoding,omitempty"`
+	// Checksum is the hex encoded sha256 of the JSON status.
+	Checksum string `json:"checksum"`
+	// Status is the encoded status.
+	Status string `json:"status"`
+}
+
+// newStatusSnapshot returns the snapshot of the status, compressed or not.
+func newStatusSnapshot(status *bmov1alpha1.BareMetalHostStatus, compress bool) (string, error) {
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the status")
+	}
+	checksum := sha256.Sum256(statusJSON)
+	snapshot := statusSnapshot{
+		Version:  statusSnapshotVersion,
+		Checksum: hex.EncodeToString(checksum[:]),
+		Status:   string(statusJSON),
+	}
+	if compress {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(statusJSON); err != nil {
+			return "", errors.Wrap(err, "failed to compress the status")
+		}
+		if err := zw.Close(); err != nil {
+			return "", errors.Wrap(err, "failed to compress the status")
+		}
+		snapshot.Encoding = statusSnapshotEncodingGzip
+		snapshot.Status = base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the status snapshot")
+	}
+	return string(snapshotJSON), nil
+}
+
+// parseStatusSnapshot returns the status of the snapshot, after checking its
+// version, encoding and checksum.
+func parseStatusSnapshot(annotation string) (*bmov1alpha1.BareMetalHostStatus, error) {
+	snapshot := statusSnapshot{}
+	if err := json.Unmarshal([]byte(annotation), &snapshot); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the status snapshot")
+	}
+	if snapshot.Version != statusSnapshotVersion {
+		return nil, errors.Errorf("unsupported status snapshot version %d", snapshot.Version)
+	}
+	statusJSON := []byte(snapshot.Status)
+	switch snapshot.Encoding {
+	case "":
+	case statusSnapshotEncodingGzip:
+		compressed, err := base64.StdEncoding.DecodeString(snapshot.Status)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode the status snapshot")
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress the status snapshot")
+		}
+		statusJSON, err = io.ReadAll(zr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decompress the status snapshot")
+		}
+	default:
+		return nil, errors.Errorf("unsupported status snapshot encoding %q", snapshot.Encoding)
+	}
+	checksum := sha256.Sum256(statusJSON)
+	if hex.EncodeToString(checksum[:]) != snapshot.Checksum {
+		return nil, errors.New("status snapshot checksum mismatch")
+	}
+	status := &bmov1alpha1.BareMetalHostStatus{}
+	if err := json.Unmarshal(statusJSON, status); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the status snapshot")
+	}
+	return status, nil
+}
+
+// statusSnapshotCompressed returns true unless the compression of the status
+// snapshots is disabled.
+func statusSnapshotCompressed() bool {
+	return Capm3StatusSnapshotCompression != "false"
+}
+
+// statusSnapshotDrift returns the fields of the live status that differ from
+// the snapshot, split between the fields identifying the provisioned host,
+// that is its provisioning ID, image and hardware details, and the fields that
+// can change while the host is paused, such as its provisioning state or power
+// state. The hardware details are compared only when the live status has them.
+func statusSnapshotDrift(snapshot, live *bmov1alpha1.BareMetalHostStatus) (identity, other []string) {
+	identity = []string{}
+	other = []string{}
+	if snapshot.Provisioning.ID != live.Provisioning.ID {
+		identity = append(identity, "provisioning.ID")
+	}
+	if !equality.Semantic.DeepEqual(snapshot.Provisioning.Image, live.Provisioning.Image) {
+		identity = append(identity, "provisioning.image")
+	}
+	if live.HardwareDetails != nil && !equality.Semantic.DeepEqual(snapshot.HardwareDetails, live.HardwareDetails) {
+		identity = append(identity, "hardware")
+	}
+	if snapshot.Provisioning.State != live.Provisioning.State {
+		other = append(other, "provisioning.state")
+	}
+	if snapshot.Provisioning.BootMode != live.Provisioning.BootMode {
+		other = append(other, "provisioning.bootMode")
+	}
+	if snapshot.HardwareProfile != live.HardwareProfile {
+		other = append(other, "hardwareProfile")
+	}
+	if snapshot.PoweredOn != live.PoweredOn {
+		other = append(other, "poweredOn")
+	}
+	return identity, other
+}
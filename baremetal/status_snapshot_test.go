@@ -0,0 +1,123 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"encoding/json"
+
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Status snapshot", func() {
+	status := bmov1alpha1.BareMetalHostStatus{
+		OperationalStatus: bmov1alpha1.OperationalStatusOK,
+		PoweredOn:         true,
+		Provisioning: bmov1alpha1.ProvisionStatus{
+			State: bmov1alpha1.StateProvisioned,
+			ID:    "abc",
+			Image: bmov1alpha1.Image{URL: "http://example.com/image.qcow2"},
+		},
+		HardwareDetails: &bmov1alpha1.HardwareDetails{
+			RAMMebibytes: 16384,
+			NIC: []bmov1alpha1.NIC{{
+				Name: "eth0",
+				MAC:  "00:00:00:00:00:01",
+			}},
+		},
+	}
+
+	DescribeTable("Test snapshot round trip",
+		func(compress bool, expectedEncoding string) {
+			annotation, err := newStatusSnapshot(&status, compress)
+			Expect(err).NotTo(HaveOccurred())
+			snapshot := statusSnapshot{}
+			Expect(json.Unmarshal([]byte(annotation), &snapshot)).To(Succeed())
+			Expect(snapshot.Version).To(Equal(statusSnapshotVersion))
+			Expect(snapshot.Encoding).To(Equal(expectedEncoding))
+
+			parsed, err := parseStatusSnapshot(annotation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*parsed).To(Equal(status))
+		},
+		Entry("Not compressed", false, ""),
+		Entry("Compressed", true, statusSnapshotEncodingGzip),
+	)
+
+	DescribeTable("Test statusSnapshotCompressed",
+		func(value string, expected bool) {
+			defer func(previous string) { Capm3StatusSnapshotCompression = previous }(Capm3StatusSnapshotCompression)
+			Capm3StatusSnapshotCompression = value
+			Expect(statusSnapshotCompressed()).To(Equal(expected))
+		},
+		Entry("Compressed by default", "", true),
+		Entry("Compressed", "true", true),
+		Entry("Not compressed", "false", false),
+	)
+
+	DescribeTable("Test invalid snapshots",
+		func(modify func(*statusSnapshot), expectedError string) {
+			annotation, err := newStatusSnapshot(&status, false)
+			Expect(err).NotTo(HaveOccurred())
+			snapshot := statusSnapshot{}
+			Expect(json.Unmarshal([]byte(annotation), &snapshot)).To(Succeed())
+			modify(&snapshot)
+			modified, err := json.Marshal(snapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = parseStatusSnapshot(string(modified))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(expectedError))
+		},
+		Entry("Unsupported version", func(s *statusSnapshot) {
+			s.Version = 2
+		}, "unsupported status snapshot version 2"),
+		Entry("Unsupported encoding", func(s *statusSnapshot) {
+			s.Encoding = "zstd"
+		}, "unsupported status snapshot encoding"),
+		Entry("Checksum mismatch", func(s *statusSnapshot) {
+			s.Status = `{"poweredOn":false}`
+		}, "checksum mismatch"),
+		Entry("Invalid compressed status", func(s *statusSnapshot) {
+			s.Encoding = statusSnapshotEncodingGzip
+		}, "failed to decode the status snapshot"),
+	)
+
+	DescribeTable("Test statusSnapshotDrift",
+		func(modify func(*bmov1alpha1.BareMetalHostStatus), expectedIdentityDrift, expectedOtherDrift []string) {
+			live := status.DeepCopy()
+			modify(live)
+			identityDrift, otherDrift := statusSnapshotDrift(&status, live)
+			Expect(identityDrift).To(Equal(expectedIdentityDrift))
+			Expect(otherDrift).To(Equal(expectedOtherDrift))
+		},
+		Entry("No drift", func(s *bmov1alpha1.BareMetalHostStatus) {}, []string{}, []string{}),
+		Entry("Hardware details missing", func(s *bmov1alpha1.BareMetalHostStatus) {
+			s.HardwareDetails = nil
+		}, []string{}, []string{}),
+		Entry("Other hardware details", func(s *bmov1alpha1.BareMetalHostStatus) {
+			s.HardwareDetails.RAMMebibytes = 8192
+		}, []string{"hardware"}, []string{}),
+		Entry("Provisioning drift", func(s *bmov1alpha1.BareMetalHostStatus) {
+			s.Provisioning.State = bmov1alpha1.StateDeprovisioning
+			s.Provisioning.Image.URL = "http://example.com/other.qcow2"
+			s.PoweredOn = false
+		}, []string{"provisioning.image"}, []string{"provisioning.state", "poweredOn"}),
+	)
+})
@@ -228,3 +228,12 @@ func testObjectReference(name string) *corev1.ObjectReference {
 		Name: name,
 	}
 }
+
+func m3mConsumerRef() *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Name:       metal3machineName,
+		Namespace:  namespaceName,
+		Kind:       "Metal3Machine",
+		APIVersion: infrav1.GroupVersion.String(),
+	}
+}
@@ -24,9 +24,11 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	"github.com/metal3-io/cluster-api-provider-metal3/baremetal"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -62,6 +64,7 @@ type Metal3ClusterReconciler struct {
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3clusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3clusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch
 
 // Reconcile reads that state of the cluster for a Metal3Cluster object and makes changes based on the state read
 // and what is in the Metal3Cluster.Spec.
@@ -165,6 +168,11 @@ func reconcileNormal(ctx context.Context, clusterMgr baremetal.ClusterManagerInt
 		return ctrl.Result{}, errors.Wrap(err, "failed to get ip for the API endpoint")
 	}
 
+	// Set FailureDomains so the Cluster API Cluster Controller can pull them
+	if err := clusterMgr.UpdateFailureDomains(ctx); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to update the failure domains")
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -237,7 +245,58 @@ func (r *Metal3ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 			// predicates.ClusterUnpaused will handle cluster unpaused logic
 			builder.WithPredicates(predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx))),
 		).
+		Watches(
+			&source.Kind{Type: &bmov1alpha1.BareMetalHost{}},
+			handler.EnqueueRequestsFromMapFunc(r.BareMetalHostToMetal3Clusters),
+			// The failure domains only depend on the labels of the hosts.
+			builder.WithPredicates(bareMetalHostLabelsChanged()),
+		).
 		WithEventFilter(predicates.ResourceIsNotExternallyManaged(mgr.GetLogger())).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 }
+
+// bareMetalHostLabelsChanged filters out the updates of the BareMetalHosts
+// that do not change their labels, such as the status updates.
+func bareMetalHostLabelsChanged() predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}
+}
+
+// BareMetalHostToMetal3Clusters will return a reconcile request for the
+// Metal3Clusters of the namespace of the BareMetalHost whose failure domains
+// are based on a label of the hosts. The host does not need to have the label,
+// since its removal changes the failure domains too.
+func (r *Metal3ClusterReconciler) BareMetalHostToMetal3Clusters(o client.Object) []ctrl.Request {
+	host, ok := o.(*bmov1alpha1.BareMetalHost)
+	if !ok {
+		r.Log.Error(errors.Errorf("expected a BareMetalHost but got a %T", o),
+			"failed to get Metal3Clusters for BareMetalHost",
+		)
+		return nil
+	}
+
+	metal3Clusters := &infrav1.Metal3ClusterList{}
+	if err := r.Client.List(context.TODO(), metal3Clusters, client.InNamespace(host.Namespace)); err != nil {
+		r.Log.Error(err, "failed to list Metal3Clusters")
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for _, metal3Cluster := range metal3Clusters.Items {
+		fdLabel := metal3Cluster.Spec.FailureDomainLabel
+		if fdLabel == "" {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      metal3Cluster.Name,
+				Namespace: metal3Cluster.Namespace,
+			},
+		})
+	}
+	return requests
+}
@@ -19,9 +19,14 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	baremetal_mocks "github.com/metal3-io/cluster-api-provider-metal3/baremetal/mocks"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 var _ = Describe("Metal3Cluster controller", func() {
@@ -68,6 +73,11 @@ var _ = Describe("Metal3Cluster controller", func() {
 					returnedError = nil
 				}
 				m.EXPECT().UpdateClusterStatus().Return(returnedError)
+				if tc.UpdateError {
+					m.EXPECT().UpdateFailureDomains(context.TODO()).MaxTimes(0)
+				} else {
+					m.EXPECT().UpdateFailureDomains(context.TODO()).Return(nil)
+				}
 				returnedError = nil
 			}
 			m.EXPECT().
@@ -181,4 +191,76 @@ var _ = Describe("Metal3Cluster controller", func() {
 			ExpectRequeue:    false,
 		}),
 	)
+
+	type testCaseBMHToMetal3Clusters struct {
+		FailureDomainLabel string
+		HostLabels         map[string]string
+		ExpectRequest      bool
+	}
+
+	DescribeTable("BareMetalHost To Metal3Clusters tests",
+		func(tc testCaseBMHToMetal3Clusters) {
+			spec := bmcSpec()
+			spec.FailureDomainLabel = tc.FailureDomainLabel
+			m3c := newMetal3Cluster(metal3ClusterName, bmcOwnerRef(), spec, nil, nil, false)
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(m3c).Build()
+			r := Metal3ClusterReconciler{
+				Client: fakeClient,
+				Log:    logr.Discard(),
+			}
+			host := &bmov1alpha1.BareMetalHost{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "host1",
+					Namespace: namespaceName,
+					Labels:    tc.HostLabels,
+				},
+			}
+
+			reqs := r.BareMetalHostToMetal3Clusters(host)
+
+			if tc.ExpectRequest {
+				Expect(reqs).To(HaveLen(1))
+				Expect(reqs[0].NamespacedName.Name).To(Equal(metal3ClusterName))
+				Expect(reqs[0].NamespacedName.Namespace).To(Equal(namespaceName))
+			} else {
+				Expect(reqs).To(BeEmpty())
+			}
+		},
+		Entry("Host with the failure domain label", testCaseBMHToMetal3Clusters{
+			FailureDomainLabel: "example.com/rack",
+			HostLabels:         map[string]string{"example.com/rack": "rack-1"},
+			ExpectRequest:      true,
+		}),
+		Entry("Host without the failure domain label", testCaseBMHToMetal3Clusters{
+			FailureDomainLabel: "example.com/rack",
+			HostLabels:         map[string]string{"foo": "bar"},
+			ExpectRequest:      true,
+		}),
+		Entry("Metal3Cluster without failure domains", testCaseBMHToMetal3Clusters{
+			HostLabels:    map[string]string{"example.com/rack": "rack-1"},
+			ExpectRequest: false,
+		}),
+	)
+
+	DescribeTable("BareMetalHost labels changed predicate tests",
+		func(oldLabels, newLabels map[string]string, expected bool) {
+			oldHost := &bmov1alpha1.BareMetalHost{
+				ObjectMeta: metav1.ObjectMeta{Name: "host1", Labels: oldLabels},
+			}
+			newHost := oldHost.DeepCopy()
+			newHost.Labels = newLabels
+			newHost.Status.OperationalStatus = bmov1alpha1.OperationalStatusOK
+			Expect(bareMetalHostLabelsChanged().Update(event.UpdateEvent{
+				ObjectOld: oldHost,
+				ObjectNew: newHost,
+			})).To(Equal(expected))
+		},
+		Entry("Status update", map[string]string{"example.com/rack": "rack-1"},
+			map[string]string{"example.com/rack": "rack-1"}, false,
+		),
+		Entry("Label added", nil, map[string]string{"example.com/rack": "rack-1"}, true),
+		Entry("Label removed", map[string]string{"example.com/rack": "rack-1"},
+			map[string]string{}, true,
+		),
+	)
 })
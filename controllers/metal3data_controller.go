@@ -24,6 +24,7 @@ import (
 	"github.com/metal3-io/cluster-api-provider-metal3/baremetal"
 	ipamv1 "github.com/metal3-io/ip-address-manager/api/v1alpha1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -54,6 +56,7 @@ type Metal3DataReconciler struct {
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3datas/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile handles Metal3Data events.
 func (r *Metal3DataReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, rerr error) {
@@ -144,15 +147,28 @@ func (r *Metal3DataReconciler) reconcileDelete(ctx context.Context,
 	return ctrl.Result{}, nil
 }
 
-// SetupWithManager will add watches for this controller.
+// SetupWithManager will add watches for this controller. The ConfigMaps and
+// the Secrets are not filtered, they do not carry the watch filter label nor
+// the paused annotation of the Metal3Data. Watching them caches all the
+// ConfigMaps and Secrets of the namespaces watched by the manager, which can
+// be restricted with the --namespace flag.
 func (r *Metal3DataReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	notPausedAndFiltered := predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&infrav1.Metal3Data{}).
+		For(&infrav1.Metal3Data{}, builder.WithPredicates(notPausedAndFiltered)).
 		Watches(
 			&source.Kind{Type: &ipamv1.IPClaim{}},
 			handler.EnqueueRequestsFromMapFunc(r.Metal3IPClaimToMetal3Data),
+			builder.WithPredicates(notPausedAndFiltered),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.MetaDataSourceToMetal3Data),
+		).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.MetaDataSourceToMetal3Data),
 		).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 }
 
@@ -183,3 +199,72 @@ func (r *Metal3DataReconciler) Metal3IPClaimToMetal3Data(obj client.Object) []ct
 	}
 	return requests
 }
+
+// MetaDataSourceToMetal3Data will return a reconcile request for each Metal3Data
+// rendered from a Metal3DataTemplate fetching metadata from the ConfigMap or the
+// Secret of the event.
+func (r *Metal3DataReconciler) MetaDataSourceToMetal3Data(obj client.Object) []ctrl.Request {
+	var references func(*infrav1.MetaData) bool
+	switch obj.(type) {
+	case *corev1.ConfigMap:
+		references = func(metaData *infrav1.MetaData) bool {
+			for _, entry := range metaData.FromConfigMaps {
+				if entry.Name == obj.GetName() {
+					return true
+				}
+			}
+			return false
+		}
+	case *corev1.Secret:
+		references = func(metaData *infrav1.MetaData) bool {
+			for _, entry := range metaData.FromSecrets {
+				if entry.Name == obj.GetName() {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		r.Log.Error(errors.Errorf("expected a ConfigMap or a Secret but got a %T", obj),
+			"failed to get Metal3Data for metadata source",
+		)
+		return nil
+	}
+
+	m3dts := &infrav1.Metal3DataTemplateList{}
+	if err := r.Client.List(context.TODO(), m3dts, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list Metal3DataTemplates")
+		return nil
+	}
+	templates := map[string]bool{}
+	for _, m3dt := range m3dts.Items {
+		if m3dt.Spec.MetaData != nil && references(m3dt.Spec.MetaData) {
+			templates[m3dt.Name] = true
+		}
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	m3ds := &infrav1.Metal3DataList{}
+	if err := r.Client.List(context.TODO(), m3ds, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.Log.Error(err, "failed to list Metal3Data")
+		return nil
+	}
+	requests := []ctrl.Request{}
+	for _, m3d := range m3ds.Items {
+		if m3d.Spec.Template.Namespace != "" && m3d.Spec.Template.Namespace != m3d.Namespace {
+			continue
+		}
+		if !templates[m3d.Spec.Template.Name] {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      m3d.Name,
+				Namespace: m3d.Namespace,
+			},
+		})
+	}
+	return requests
+}
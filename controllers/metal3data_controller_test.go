@@ -31,8 +31,10 @@ import (
 	baremetal_mocks "github.com/metal3-io/cluster-api-provider-metal3/baremetal/mocks"
 	ipamv1 "github.com/metal3-io/ip-address-manager/api/v1alpha1"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -286,7 +288,7 @@ var _ = Describe("Metal3Data manager", func() {
 
 				dataReconcile := &Metal3DataReconciler{
 					Client:           fakeClient,
-					ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+					ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 					Log:              logr.Discard(),
 					WatchFilterValue: "",
 				}
@@ -348,7 +350,7 @@ var _ = Describe("Metal3Data manager", func() {
 
 			dataReconcile := &Metal3DataReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				WatchFilterValue: "",
 			}
@@ -446,4 +448,93 @@ var _ = Describe("Metal3Data manager", func() {
 		}),
 	)
 
+	type testCaseMetaDataSourceToMetal3Data struct {
+		obj              client.Object
+		expectedRequests []ctrl.Request
+	}
+
+	DescribeTable("test MetaDataSourceToMetal3Data",
+		func(tc testCaseMetaDataSourceToMetal3Data) {
+			objects := []client.Object{
+				&infrav1.Metal3DataTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "template-1", Namespace: namespaceName},
+					Spec: infrav1.Metal3DataTemplateSpec{
+						MetaData: &infrav1.MetaData{
+							FromConfigMaps: []infrav1.MetaDataFromConfigMap{
+								{Key: "ntp", Name: "site", DataKey: "ntpServers"},
+							},
+						},
+					},
+				},
+				&infrav1.Metal3DataTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "template-2", Namespace: namespaceName},
+					Spec: infrav1.Metal3DataTemplateSpec{
+						MetaData: &infrav1.MetaData{
+							FromSecrets: []infrav1.MetaDataFromSecret{
+								{Key: "token", Name: "site", DataKey: "token"},
+							},
+						},
+					},
+				},
+				&infrav1.Metal3Data{
+					ObjectMeta: metav1.ObjectMeta{Name: "data-1", Namespace: namespaceName},
+					Spec: infrav1.Metal3DataSpec{
+						Template: corev1.ObjectReference{Name: "template-1"},
+					},
+				},
+				&infrav1.Metal3Data{
+					ObjectMeta: metav1.ObjectMeta{Name: "data-2", Namespace: namespaceName},
+					Spec: infrav1.Metal3DataSpec{
+						Template: corev1.ObjectReference{Name: "template-2", Namespace: namespaceName},
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			m3DataReconciler := Metal3DataReconciler{
+				Client: fakeClient,
+				Log:    logr.Discard(),
+			}
+			reqs := m3DataReconciler.MetaDataSourceToMetal3Data(tc.obj)
+			Expect(reqs).To(Equal(tc.expectedRequests))
+		},
+		Entry("ConfigMap", testCaseMetaDataSourceToMetal3Data{
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: namespaceName},
+			},
+			expectedRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "data-1",
+						Namespace: namespaceName,
+					},
+				},
+			},
+		}),
+		Entry("Secret", testCaseMetaDataSourceToMetal3Data{
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: namespaceName},
+			},
+			expectedRequests: []ctrl.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      "data-2",
+						Namespace: namespaceName,
+					},
+				},
+			},
+		}),
+		Entry("Unreferenced ConfigMap", testCaseMetaDataSourceToMetal3Data{
+			obj: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: namespaceName},
+			},
+			expectedRequests: nil,
+		}),
+		Entry("Other namespace", testCaseMetaDataSourceToMetal3Data{
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "site", Namespace: "other"},
+			},
+			expectedRequests: nil,
+		}),
+	)
+
 })
@@ -33,6 +33,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -223,7 +224,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 
 			r := &Metal3DataTemplateReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				WatchFilterValue: "",
 			}
@@ -277,7 +278,7 @@ var _ = Describe("Metal3DataTemplate manager", func() {
 
 			r := &Metal3DataTemplateReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				WatchFilterValue: "",
 			}
@@ -18,8 +18,10 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -27,32 +29,43 @@ import (
 	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	"github.com/metal3-io/cluster-api-provider-metal3/baremetal"
+	"github.com/metal3-io/cluster-api-provider-metal3/baremetal/remote"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	corev1typed "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/tools/cache"
 	k8strings "k8s.io/utils/strings"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 var (
 	bmhSyncInterval = 60 * time.Second
+	// nodeWatchSyncInterval is the interval of the synchronization of the
+	// BareMetalHosts whose Nodes are watched, in case an event is missed.
+	nodeWatchSyncInterval = 10 * time.Minute
 )
 
 const (
 	labelSyncControllerName = "metal3-label-sync-controller"
 	// PrefixAnnotationKey is prefix for annotation key.
 	PrefixAnnotationKey = "metal3.io/metal3-label-sync-prefixes"
+	// LastSyncedAnnotationKey is the BareMetalHost annotation holding the
+	// labels synchronized last with the Both direction, as a JSON object.
+	LastSyncedAnnotationKey = "metal3.io/metal3-label-sync-last-synced"
 	// Metal3Machine is name of the Metal3 CRD.
 	Metal3Machine = "Metal3Machine"
 )
@@ -64,12 +77,21 @@ type Metal3LabelSyncReconciler struct {
 	Log              logr.Logger
 	CapiClientGetter baremetal.ClientGetter
 	WatchFilterValue string
+	// Tracker serves the Nodes of the workload clusters and triggers the
+	// reconciliation of the BareMetalHosts when their Node changes. Without
+	// it, the BareMetalHosts are reconciled every bmhSyncInterval, and with it
+	// every nodeWatchSyncInterval.
+	Tracker    *remote.ClusterClientTracker
+	nodeEvents chan event.GenericEvent
+	// done is closed when the manager stops, so that the Node handlers do not
+	// block on nodeEvents once the controller does not read it anymore.
+	done <-chan struct{}
 }
 
 // +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=metal3.io,resources=baremetalhosts/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3machines,verbs=get;list;watch
-// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3machines/status,verbs=get
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3machines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
@@ -133,6 +155,18 @@ func (r *Metal3LabelSyncReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 	controllerLog.V(5).Info(fmt.Sprintf("Found Metal3Machine %v", capm3MachineKey))
 
+	// The synchronization state is reported on the Metal3Machine.
+	m3mHelper, err := patch.NewHelper(capm3Machine, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	defer func() {
+		err := m3mHelper.Patch(ctx, capm3Machine)
+		if err != nil {
+			controllerLog.Info("Failed to Patch Metal3Machine")
+		}
+	}()
+
 	// Fetch the Machine.
 	capiMachine, err := util.GetOwnerMachine(ctx, r.Client, capm3Machine.ObjectMeta)
 	if err != nil {
@@ -179,50 +213,160 @@ func (r *Metal3LabelSyncReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// Get prefix set
-	annotations := metal3Cluster.ObjectMeta.GetAnnotations()
-	if annotations == nil {
-		return ctrl.Result{}, nil
-	}
-	prefixStr, ok := annotations[PrefixAnnotationKey]
-	if !ok {
-		controllerLog.V(5).Info("No annotation for prefixes found on Metal3Cluster")
+	prefixStr, ok := metal3Cluster.ObjectMeta.GetAnnotations()[PrefixAnnotationKey]
+	if !ok && metal3Cluster.Spec.LabelSync == nil {
+		controllerLog.V(5).Info("No annotation for prefixes nor label sync found on Metal3Cluster")
+		conditions.Delete(capm3Machine, infrav1.LabelSyncedCondition)
 		return ctrl.Result{}, nil
 	}
 
-	prefixSet, err := parsePrefixAnnotation(prefixStr)
+	prefixSet, err := labelSyncPrefixSet(prefixStr, metal3Cluster.Spec.LabelSync)
 	if err != nil {
+		conditions.MarkFalse(capm3Machine, infrav1.LabelSyncedCondition, infrav1.InvalidLabelSyncReason,
+			clusterv1.ConditionSeverityError, err.Error(),
+		)
 		return ctrl.Result{}, err
 	}
-	err = r.reconcileBMHLabels(ctx, host, capiMachine, cluster, prefixSet)
+
+	// Watch the Nodes of the workload clusters using the label
+	// synchronization, falling back to a more frequent synchronization when
+	// the watch cannot be started.
+	watchingNodes := false
+	if r.Tracker != nil {
+		if err := r.Tracker.WatchNodes(ctx, cluster, r.NodeToBareMetalHost); err != nil {
+			controllerLog.Info(fmt.Sprintf("Could not watch the Nodes of the Cluster: %v", err))
+		} else {
+			watchingNodes = true
+		}
+	}
+	err = r.reconcileBMHLabels(ctx, host, capiMachine, cluster, prefixSet, metal3Cluster.Spec.LabelSync)
 	if err != nil {
 		controllerLog.Info(fmt.Sprintf("Error reconciling BMH labels to Node, will retry: %v", err))
+		conditions.MarkFalse(capm3Machine, infrav1.LabelSyncedCondition, infrav1.LabelSyncFailedReason,
+			clusterv1.ConditionSeverityWarning, err.Error(),
+		)
 		return ctrl.Result{RequeueAfter: requeueAfter}, err
 	}
+	// Write the labels synchronized on the BareMetalHost now, so that a failure
+	// is reported in the condition. The deferred patch is then a no-op.
+	if err := helper.Patch(ctx, host); err != nil {
+		controllerLog.Info(fmt.Sprintf("Error patching the BareMetalHost labels, will retry: %v", err))
+		conditions.MarkFalse(capm3Machine, infrav1.LabelSyncedCondition, infrav1.LabelSyncFailedReason,
+			clusterv1.ConditionSeverityWarning, "failed to patch the BareMetalHost: %v", err,
+		)
+		return ctrl.Result{RequeueAfter: requeueAfter}, errors.Wrap(err, "failed to patch the BareMetalHost")
+	}
+	if helper, err = patch.NewHelper(host, r.Client); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to init patch helper")
+	}
+	conditions.MarkTrue(capm3Machine, infrav1.LabelSyncedCondition)
 	controllerLog.Info("Finished synchronizing labels between BaremetalHost and Node")
-	// Always requeue to ensure label sync runs periodically for each BareMetalHost. This is necessary to catch any label updates to the Node that are synchronized through the BareMetalHost.
+	if watchingNodes {
+		// The changes of the Node trigger the reconciliation, the periodic
+		// synchronization only catches the missed events.
+		return ctrl.Result{RequeueAfter: nodeWatchSyncInterval}, nil
+	}
+	// Requeue to ensure label sync runs periodically for each BareMetalHost. This is necessary to catch any label updates to the Node that are synchronized through the BareMetalHost.
 	return ctrl.Result{RequeueAfter: bmhSyncInterval}, nil
 }
 
-func (r *Metal3LabelSyncReconciler) reconcileBMHLabels(ctx context.Context, host *bmov1alpha1.BareMetalHost, machine *clusterv1.Machine, cluster *clusterv1.Cluster, prefixSet map[string]struct{}) error {
-	hostLabelSyncSet := buildLabelSyncSet(prefixSet, host.Labels)
-	// Get the Node from the workload cluster
-	corev1Remote, err := r.CapiClientGetter(ctx, r.Client, cluster)
-	if err != nil {
-		return errors.Wrap(err, "error creating a remote client")
+func (r *Metal3LabelSyncReconciler) reconcileBMHLabels(ctx context.Context, host *bmov1alpha1.BareMetalHost, machine *clusterv1.Machine, cluster *clusterv1.Cluster, prefixSet map[string]struct{}, labelSync *infrav1.LabelSync) error {
+	if labelSync == nil {
+		labelSync = &infrav1.LabelSync{}
+	}
+	annotationPrefixSet := make(map[string]struct{})
+	for _, prefix := range labelSync.AnnotationPrefixes {
+		annotationPrefixSet[prefix] = struct{}{}
+	}
+	hostLabelSyncSet := excludeFromSyncSet(buildLabelSyncSet(prefixSet, host.Labels), labelSync.Exclude)
+	hostAnnotationSyncSet := buildLabelSyncSet(annotationPrefixSet, host.Annotations)
+	hostTaintSyncSet := buildTaintSyncSet(labelSync.Taints, host.Labels)
+	// Get the Node from the cache of the workload cluster, or from the workload
+	// cluster when it is not cached.
+	var node *corev1.Node
+	var corev1Remote corev1typed.CoreV1Interface
+	cached := false
+	var err error
+	if r.Tracker != nil {
+		node, cached, err = r.Tracker.GetNode(cluster, machine.Status.NodeRef.Name)
+		if err != nil {
+			return err
+		}
+	}
+	if !cached {
+		corev1Remote, err = r.CapiClientGetter(ctx, r.Client, cluster)
+		if err != nil {
+			return errors.Wrap(err, "error creating a remote client")
+		}
+		node, err = corev1Remote.Nodes().Get(ctx, machine.Status.NodeRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
 	}
-	node, err := corev1Remote.Nodes().Get(ctx, machine.Status.NodeRef.Name, metav1.GetOptions{})
+	originalNode := node.DeepCopy()
+	nodeLabelSyncSet := excludeFromSyncSet(buildLabelSyncSet(prefixSet, node.Labels), labelSync.Exclude)
+	switch labelSync.Direction {
+	case infrav1.LabelSyncDirectionNodeToHost:
+		synchronizeLabelSyncSetsOnHost(nodeLabelSyncSet, hostLabelSyncSet, host)
+		delete(host.Annotations, LastSyncedAnnotationKey)
+	case infrav1.LabelSyncDirectionBoth:
+		labelSyncSet := mergeLabelSyncSets(hostLabelSyncSet, nodeLabelSyncSet, lastSyncedLabels(host))
+		synchronizeLabelSyncSetsOnHost(labelSyncSet, hostLabelSyncSet, host)
+		synchronizeLabelSyncSetsOnNode(labelSyncSet, nodeLabelSyncSet, node)
+		lastSynced, err := json.Marshal(labelSyncSet)
+		if err != nil {
+			return err
+		}
+		host.Annotations[LastSyncedAnnotationKey] = string(lastSynced)
+	default:
+		synchronizeLabelSyncSetsOnNode(hostLabelSyncSet, nodeLabelSyncSet, node)
+		delete(host.Annotations, LastSyncedAnnotationKey)
+	}
+	nodeAnnotationSyncSet := buildLabelSyncSet(annotationPrefixSet, node.Annotations)
+	synchronizeAnnotationSyncSetsOnNode(hostAnnotationSyncSet, nodeAnnotationSyncSet, node)
+	synchronizeTaintSyncSetOnNode(labelSync.Taints, hostTaintSyncSet, node)
+
+	nodePatch, err := nodePatch(originalNode, node)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "unable to compute the patch of the target node")
+	}
+	if nodePatch == nil {
+		return nil
 	}
-	nodeLabelSyncSet := buildLabelSyncSet(prefixSet, node.Labels)
-	synchronizeLabelSyncSetsOnNode(hostLabelSyncSet, nodeLabelSyncSet, node)
-	_, err = corev1Remote.Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	if corev1Remote == nil {
+		corev1Remote, err = r.CapiClientGetter(ctx, r.Client, cluster)
+		if err != nil {
+			return errors.Wrap(err, "error creating a remote client")
+		}
+	}
+	_, err = corev1Remote.Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, nodePatch, metav1.PatchOptions{})
 	if err != nil {
-		return errors.Wrap(err, "unable to update the target node")
+		return errors.Wrap(err, "unable to patch the target node")
 	}
 	return nil
 }
 
+// nodePatch returns the strategic merge patch from the original Node to the
+// modified Node, or nil if they do not differ.
+func nodePatch(original, modified *corev1.Node) ([]byte, error) {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, err
+	}
+	modifiedJSON, err := json.Marshal(modified)
+	if err != nil {
+		return nil, err
+	}
+	nodePatch, err := strategicpatch.CreateTwoWayMergePatch(originalJSON, modifiedJSON, corev1.Node{})
+	if err != nil {
+		return nil, err
+	}
+	if string(nodePatch) == "{}" {
+		return nil, nil
+	}
+	return nodePatch, nil
+}
+
 func buildLabelSyncSet(prefixSet map[string]struct{}, labels map[string]string) map[string]string {
 	labelSyncSet := make(map[string]string)
 	for labelKey, labelVal := range labels {
@@ -238,36 +382,218 @@ func buildLabelSyncSet(prefixSet map[string]struct{}, labels map[string]string)
 	return labelSyncSet
 }
 
+// excludeFromSyncSet removes the excluded label keys from the sync set.
+func excludeFromSyncSet(syncSet map[string]string, exclude []string) map[string]string {
+	for _, key := range exclude {
+		delete(syncSet, key)
+	}
+	return syncSet
+}
+
 func synchronizeLabelSyncSetsOnNode(hostLabelSyncSet, nodeLabelSyncSet map[string]string, node *corev1.Node) {
 	if node.Labels == nil {
 		node.Labels = map[string]string{}
 	}
-	for labelKey, labelVal := range nodeLabelSyncSet {
-		val, ok := hostLabelSyncSet[labelKey]
-		if !ok || val != labelVal {
-			delete(node.Labels, labelKey)
+	synchronizeSyncSets(hostLabelSyncSet, nodeLabelSyncSet, node.Labels)
+}
+
+// synchronizeLabelSyncSetsOnHost synchronizes the labels of the sync set onto
+// the BareMetalHost.
+func synchronizeLabelSyncSetsOnHost(labelSyncSet, hostLabelSyncSet map[string]string, host *bmov1alpha1.BareMetalHost) {
+	if host.Labels == nil {
+		host.Labels = map[string]string{}
+	}
+	synchronizeSyncSets(labelSyncSet, hostLabelSyncSet, host.Labels)
+}
+
+// lastSyncedLabels returns the labels synchronized last with the Both
+// direction. A missing or invalid annotation is handled as no label being
+// synchronized yet.
+func lastSyncedLabels(host *bmov1alpha1.BareMetalHost) map[string]string {
+	if host.Annotations == nil {
+		host.Annotations = map[string]string{}
+	}
+	lastSynced := map[string]string{}
+	if err := json.Unmarshal([]byte(host.Annotations[LastSyncedAnnotationKey]), &lastSynced); err != nil {
+		return map[string]string{}
+	}
+	return lastSynced
+}
+
+// mergeLabelSyncSets returns the labels to set on both the BareMetalHost and
+// the Node. A label added, modified or removed on one side only since the
+// last synchronization is taken from that side, the BareMetalHost winning when
+// both sides changed.
+func mergeLabelSyncSets(hostLabelSyncSet, nodeLabelSyncSet, lastSynced map[string]string) map[string]string {
+	keys := make(map[string]struct{})
+	for _, labels := range []map[string]string{hostLabelSyncSet, nodeLabelSyncSet, lastSynced} {
+		for key := range labels {
+			keys[key] = struct{}{}
+		}
+	}
+	labelSyncSet := make(map[string]string)
+	for key := range keys {
+		hostVal, onHost := hostLabelSyncSet[key]
+		nodeVal, onNode := nodeLabelSyncSet[key]
+		lastVal, synced := lastSynced[key]
+		value, present := hostVal, onHost
+		if onHost == synced && hostVal == lastVal {
+			// Unchanged on the host, the Node is up to date.
+			value, present = nodeVal, onNode
+		}
+		if present {
+			labelSyncSet[key] = value
+		}
+	}
+	return labelSyncSet
+}
+
+// synchronizeAnnotationSyncSetsOnNode synchronizes the annotations of the
+// BareMetalHost with the prefixes onto the Node.
+func synchronizeAnnotationSyncSetsOnNode(hostAnnotationSyncSet, nodeAnnotationSyncSet map[string]string, node *corev1.Node) {
+	if len(hostAnnotationSyncSet) == 0 && len(nodeAnnotationSyncSet) == 0 {
+		return
+	}
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+	synchronizeSyncSets(hostAnnotationSyncSet, nodeAnnotationSyncSet, node.Annotations)
+}
+
+// synchronizeSyncSets removes from values the entries of the current sync
+// set that differ from the desired sync set, and adds the entries of the
+// desired sync set.
+func synchronizeSyncSets(desiredSyncSet, currentSyncSet, values map[string]string) {
+	for key, value := range currentSyncSet {
+		val, ok := desiredSyncSet[key]
+		if !ok || val != value {
+			delete(values, key)
+		}
+	}
+	for key, value := range desiredSyncSet {
+		val, ok := currentSyncSet[key]
+		if !ok || val != value {
+			values[key] = value
+		}
+	}
+}
+
+// buildTaintSyncSet returns the taints corresponding to the labels with the
+// prefixes of the taint syncs, sorted by key and effect.
+func buildTaintSyncSet(taintSyncs []infrav1.TaintSync, labels map[string]string) []corev1.Taint {
+	taints := []corev1.Taint{}
+	for _, taintSync := range taintSyncs {
+		for labelKey, labelVal := range buildLabelSyncSet(map[string]struct{}{taintSync.Prefix: {}}, labels) {
+			taints = append(taints, corev1.Taint{
+				Key:    labelKey,
+				Value:  labelVal,
+				Effect: taintSync.Effect,
+			})
+		}
+	}
+	sort.Slice(taints, func(i, j int) bool {
+		if taints[i].Key != taints[j].Key {
+			return taints[i].Key < taints[j].Key
+		}
+		return taints[i].Effect < taints[j].Effect
+	})
+	return taints
+}
+
+// synchronizeTaintSyncSetOnNode removes from the Node the taints with the
+// prefix and the effect of a taint sync that the host taint sync set does not
+// contain, and adds the missing taints of the host taint sync set. The other
+// taints of the Node are kept.
+func synchronizeTaintSyncSetOnNode(taintSyncs []infrav1.TaintSync, hostTaintSyncSet []corev1.Taint, node *corev1.Node) {
+	isSynced := func(taint corev1.Taint) bool {
+		p, n := k8strings.SplitQualifiedName(taint.Key)
+		if p == "" || n == "" {
+			return false
+		}
+		for _, taintSync := range taintSyncs {
+			if taintSync.Prefix == p && taintSync.Effect == taint.Effect {
+				return true
+			}
+		}
+		return false
+	}
+	hasTaint := func(taints []corev1.Taint, taint corev1.Taint) bool {
+		for _, t := range taints {
+			if t.Key == taint.Key && t.Value == taint.Value && t.Effect == taint.Effect {
+				return true
+			}
+		}
+		return false
+	}
+
+	taints := []corev1.Taint{}
+	for _, taint := range node.Spec.Taints {
+		if isSynced(taint) && !hasTaint(hostTaintSyncSet, taint) {
+			continue
 		}
+		taints = append(taints, taint)
 	}
-	for labelKey, labelVal := range hostLabelSyncSet {
-		val, ok := nodeLabelSyncSet[labelKey]
-		if !ok || val != labelVal {
-			node.Labels[labelKey] = labelVal
+	for _, taint := range hostTaintSyncSet {
+		if !hasTaint(taints, taint) {
+			taints = append(taints, taint)
 		}
 	}
+	if len(taints) == 0 && len(node.Spec.Taints) == 0 {
+		return
+	}
+	node.Spec.Taints = taints
 }
 
 // SetupWithManager will add watches for this controller.
 func (r *Metal3LabelSyncReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	r.nodeEvents = make(chan event.GenericEvent)
+	r.done = ctx.Done()
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&bmov1alpha1.BareMetalHost{}).
 		Watches(
 			&source.Kind{Type: &infrav1.Metal3Cluster{}},
 			handler.EnqueueRequestsFromMapFunc(r.Metal3ClusterToBareMetalHosts),
 		).
+		Watches(
+			&source.Channel{Source: r.nodeEvents},
+			&handler.EnqueueRequestForObject{},
+		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
 }
 
+// NodeToBareMetalHost is a remote.NodeHandler that enqueues the BareMetalHost
+// of the Node for reconciliation, through the Machine with the Node as NodeRef.
+func (r *Metal3LabelSyncReconciler) NodeToBareMetalHost(cluster types.NamespacedName, node *corev1.Node) {
+	log := r.Log.WithValues("NodeToBareMetalHost", node.Name, "Cluster", cluster)
+	labels := map[string]string{clusterv1.ClusterLabelName: cluster.Name}
+	capiMachineList := &clusterv1.MachineList{}
+	if err := r.Client.List(context.TODO(), capiMachineList, client.InNamespace(cluster.Namespace), client.MatchingLabels(labels)); err != nil {
+		log.Error(err, "failed to list Machines")
+		return
+	}
+	for i := range capiMachineList.Items {
+		m := &capiMachineList.Items[i]
+		if m.Status.NodeRef == nil || m.Status.NodeRef.Name != node.Name {
+			continue
+		}
+		hostObjKey, ok := r.machineToBareMetalHost(m, log)
+		if !ok {
+			return
+		}
+		host := &bmov1alpha1.BareMetalHost{}
+		if err := r.Client.Get(context.TODO(), hostObjKey, host); err != nil {
+			log.Error(err, "failed to get BareMetalHost")
+			return
+		}
+		select {
+		case r.nodeEvents <- event.GenericEvent{Object: host}:
+		case <-r.done:
+		}
+		return
+	}
+}
+
 // Metal3ClusterToBareMetalHosts is a handler.ToRequestsFunc to be used to enqeue
 // requests for reconciliation of BareMetalHosts' label updates.
 func (r *Metal3LabelSyncReconciler) Metal3ClusterToBareMetalHosts(o client.Object) []ctrl.Request {
@@ -295,44 +621,54 @@ func (r *Metal3LabelSyncReconciler) Metal3ClusterToBareMetalHosts(o client.Objec
 		log.Error(err, "failed to list Machines")
 		return nil
 	}
-	for _, m := range capiMachineList.Items {
-		if m.Spec.InfrastructureRef.Name == "" {
-			continue
-		}
-		name := client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.InfrastructureRef.Name}
-		if m.Spec.InfrastructureRef.Namespace != "" {
-			name = client.ObjectKey{Namespace: m.Spec.InfrastructureRef.Namespace, Name: m.Spec.InfrastructureRef.Name}
-		}
-		capm3Machine := &infrav1.Metal3Machine{}
-		if err := r.Client.Get(context.TODO(), name, capm3Machine); err != nil {
-			log.Error(err, "failed to get Metal3Machine")
-			continue
-		}
-		annotations := capm3Machine.ObjectMeta.GetAnnotations()
-		if annotations == nil {
-			log.Error(errors.Errorf("no annotations found on Metal3Machine: %v", name), "failed to get annotations in Metal3Machine")
-			continue
-		}
-		hostKey, ok := annotations[baremetal.HostAnnotation]
+	for i := range capiMachineList.Items {
+		hostObjKey, ok := r.machineToBareMetalHost(&capiMachineList.Items[i], log)
 		if !ok {
-			log.Error(errors.Errorf("no %v annotation on Metal3Machine: %v", baremetal.HostAnnotation, name), "failed to get BareMetalHost annotation in Metal3Machine")
-			continue
-		}
-		hostNamespace, hostName, err := cache.SplitMetaNamespaceKey(hostKey)
-		if err != nil {
-			log.Error(err, "could not parse host annotation")
 			continue
 		}
-		hostObjKey := client.ObjectKey{
-			Name:      hostName,
-			Namespace: hostNamespace,
-		}
-		log.V(5).Info("found BareMetalHost", "name", hostObjKey)
 		result = append(result, ctrl.Request{NamespacedName: hostObjKey})
 	}
 	return result
 }
 
+// machineToBareMetalHost returns the key of the BareMetalHost of the Machine,
+// from the host annotation of its Metal3Machine.
+func (r *Metal3LabelSyncReconciler) machineToBareMetalHost(m *clusterv1.Machine, log logr.Logger) (client.ObjectKey, bool) {
+	if m.Spec.InfrastructureRef.Name == "" {
+		return client.ObjectKey{}, false
+	}
+	name := client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.InfrastructureRef.Name}
+	if m.Spec.InfrastructureRef.Namespace != "" {
+		name = client.ObjectKey{Namespace: m.Spec.InfrastructureRef.Namespace, Name: m.Spec.InfrastructureRef.Name}
+	}
+	capm3Machine := &infrav1.Metal3Machine{}
+	if err := r.Client.Get(context.TODO(), name, capm3Machine); err != nil {
+		log.Error(err, "failed to get Metal3Machine")
+		return client.ObjectKey{}, false
+	}
+	annotations := capm3Machine.ObjectMeta.GetAnnotations()
+	if annotations == nil {
+		log.Error(errors.Errorf("no annotations found on Metal3Machine: %v", name), "failed to get annotations in Metal3Machine")
+		return client.ObjectKey{}, false
+	}
+	hostKey, ok := annotations[baremetal.HostAnnotation]
+	if !ok {
+		log.Error(errors.Errorf("no %v annotation on Metal3Machine: %v", baremetal.HostAnnotation, name), "failed to get BareMetalHost annotation in Metal3Machine")
+		return client.ObjectKey{}, false
+	}
+	hostNamespace, hostName, err := cache.SplitMetaNamespaceKey(hostKey)
+	if err != nil {
+		log.Error(err, "could not parse host annotation")
+		return client.ObjectKey{}, false
+	}
+	hostObjKey := client.ObjectKey{
+		Name:      hostName,
+		Namespace: hostNamespace,
+	}
+	log.V(5).Info("found BareMetalHost", "name", hostObjKey)
+	return hostObjKey, true
+}
+
 // parsePrefixAnnotation parses a string for prefixes. The string must be in the format: `prefix-1,prefix-2,...`
 // and each prefix must conform to the definition of a subdomain in DNS (RFC 1123).
 func parsePrefixAnnotation(prefixStr string) (map[string]struct{}, error) {
@@ -351,6 +687,25 @@ func parsePrefixAnnotation(prefixStr string) (map[string]struct{}, error) {
 	return prefixSet, nil
 }
 
+// labelSyncPrefixSet returns the prefixes of the labels to synchronize, from
+// the prefixes annotation and the label sync of the Metal3Cluster.
+func labelSyncPrefixSet(prefixStr string, labelSync *infrav1.LabelSync) (map[string]struct{}, error) {
+	prefixSet, err := parsePrefixAnnotation(prefixStr)
+	if err != nil {
+		return nil, err
+	}
+	if labelSync == nil {
+		return prefixSet, nil
+	}
+	for _, prefix := range labelSync.Prefixes {
+		if err := IsDNS1123Subdomain(prefix); err != nil {
+			return nil, fmt.Errorf("invalid prefix (%v): %w", prefix, err)
+		}
+		prefixSet[prefix] = struct{}{}
+	}
+	return prefixSet, nil
+}
+
 // The following code is also used by kubectl for label and prefix validation.
 // Reference: https://github.com/kubernetes/apimachinery/blob/master/pkg/util/validation/validation.go
 const dns1123LabelFmt string = "[a-z0-9]([-a-z0-9]*[a-z0-9])?"
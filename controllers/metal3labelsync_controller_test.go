@@ -28,15 +28,19 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clientfake "k8s.io/client-go/kubernetes/fake"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -295,6 +299,161 @@ var _ = Describe("Metal3LabelSync controller", func() {
 			},
 		}),
 	)
+
+	type TestCaseMergeLabelSyncSets struct {
+		HostLabels     map[string]string
+		NodeLabels     map[string]string
+		LastSynced     map[string]string
+		ExpectedResult map[string]string
+	}
+
+	DescribeTable("Merge Label Sync Sets",
+		func(tc TestCaseMergeLabelSyncSets) {
+			Expect(mergeLabelSyncSets(tc.HostLabels, tc.NodeLabels, tc.LastSynced)).To(Equal(tc.ExpectedResult))
+		},
+		Entry("First synchronization, host wins", TestCaseMergeLabelSyncSets{
+			HostLabels:     map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1"},
+			NodeLabels:     map[string]string{"foo.metal3.io/b": "2", "foo.metal3.io/c": "2"},
+			LastSynced:     map[string]string{},
+			ExpectedResult: map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1", "foo.metal3.io/c": "2"},
+		}),
+		Entry("Changes on the node", TestCaseMergeLabelSyncSets{
+			HostLabels:     map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1"},
+			NodeLabels:     map[string]string{"foo.metal3.io/a": "2", "foo.metal3.io/c": "2"},
+			LastSynced:     map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1"},
+			ExpectedResult: map[string]string{"foo.metal3.io/a": "2", "foo.metal3.io/c": "2"},
+		}),
+		Entry("Changes on the host", TestCaseMergeLabelSyncSets{
+			HostLabels:     map[string]string{"foo.metal3.io/a": "2", "foo.metal3.io/c": "2"},
+			NodeLabels:     map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1"},
+			LastSynced:     map[string]string{"foo.metal3.io/a": "1", "foo.metal3.io/b": "1"},
+			ExpectedResult: map[string]string{"foo.metal3.io/a": "2", "foo.metal3.io/c": "2"},
+		}),
+		Entry("Changes on both sides", TestCaseMergeLabelSyncSets{
+			HostLabels:     map[string]string{"foo.metal3.io/a": "2"},
+			NodeLabels:     map[string]string{"foo.metal3.io/a": "3", "foo.metal3.io/b": "1"},
+			LastSynced:     map[string]string{"foo.metal3.io/a": "1"},
+			ExpectedResult: map[string]string{"foo.metal3.io/a": "2", "foo.metal3.io/b": "1"},
+		}),
+	)
+
+	type TestCaseLabelSyncPrefixSet struct {
+		PrefixStr      string
+		LabelSync      *infrav1.LabelSync
+		ExpectedErr    bool
+		ExpectedResult map[string]struct{}
+	}
+
+	DescribeTable("Label Sync Prefix Set",
+		func(tc TestCaseLabelSyncPrefixSet) {
+			prefixSet, err := labelSyncPrefixSet(tc.PrefixStr, tc.LabelSync)
+			if tc.ExpectedErr {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prefixSet).To(Equal(tc.ExpectedResult))
+		},
+		Entry("Annotation only", TestCaseLabelSyncPrefixSet{
+			PrefixStr:      "foo.metal3.io",
+			ExpectedResult: map[string]struct{}{"foo.metal3.io": {}},
+		}),
+		Entry("Annotation and label sync", TestCaseLabelSyncPrefixSet{
+			PrefixStr:      "foo.metal3.io",
+			LabelSync:      &infrav1.LabelSync{Prefixes: []string{"bar.metal3.io"}},
+			ExpectedResult: map[string]struct{}{"foo.metal3.io": {}, "bar.metal3.io": {}},
+		}),
+		Entry("Invalid label sync prefix", TestCaseLabelSyncPrefixSet{
+			LabelSync:   &infrav1.LabelSync{Prefixes: []string{"@bar.metal3.io"}},
+			ExpectedErr: true,
+		}),
+	)
+
+	type TestCaseSynchronizeAnnotationSyncSetsOnNode struct {
+		HostAnnotations map[string]string
+		NodeAnnotations map[string]string
+		ExpectedResult  map[string]string
+	}
+
+	DescribeTable("Synchronize Annotation Sync Sets",
+		func(tc TestCaseSynchronizeAnnotationSyncSetsOnNode) {
+			prefixSet := map[string]struct{}{"hardware.metal3.io": {}}
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: tc.NodeAnnotations}}
+			synchronizeAnnotationSyncSetsOnNode(buildLabelSyncSet(prefixSet, tc.HostAnnotations),
+				buildLabelSyncSet(prefixSet, tc.NodeAnnotations), node,
+			)
+			Expect(node.Annotations).To(Equal(tc.ExpectedResult))
+		},
+		Entry("Nothing to synchronize", TestCaseSynchronizeAnnotationSyncSetsOnNode{
+			HostAnnotations: map[string]string{"other.metal3.io/foo": "bar"},
+		}),
+		Entry("Add, update and remove annotations", TestCaseSynchronizeAnnotationSyncSetsOnNode{
+			HostAnnotations: map[string]string{
+				"hardware.metal3.io/rack": "1",
+				"hardware.metal3.io/pdu":  "a",
+			},
+			NodeAnnotations: map[string]string{
+				"hardware.metal3.io/rack": "2",
+				"hardware.metal3.io/old":  "x",
+				"other.metal3.io/foo":     "bar",
+			},
+			ExpectedResult: map[string]string{
+				"hardware.metal3.io/rack": "1",
+				"hardware.metal3.io/pdu":  "a",
+				"other.metal3.io/foo":     "bar",
+			},
+		}),
+	)
+
+	type TestCaseSynchronizeTaintSyncSetOnNode struct {
+		Labels         map[string]string
+		Taints         []corev1.Taint
+		ExpectedResult []corev1.Taint
+	}
+
+	DescribeTable("Synchronize Taint Sync Set",
+		func(tc TestCaseSynchronizeTaintSyncSetOnNode) {
+			taintSyncs := []infrav1.TaintSync{
+				{Prefix: "hardware.metal3.io", Effect: corev1.TaintEffectNoSchedule},
+				{Prefix: "hardware.metal3.io", Effect: corev1.TaintEffectPreferNoSchedule},
+			}
+			node := &corev1.Node{Spec: corev1.NodeSpec{Taints: tc.Taints}}
+			synchronizeTaintSyncSetOnNode(taintSyncs, buildTaintSyncSet(taintSyncs, tc.Labels), node)
+			Expect(node.Spec.Taints).To(Equal(tc.ExpectedResult))
+		},
+		Entry("No taint", TestCaseSynchronizeTaintSyncSetOnNode{
+			Labels: map[string]string{"other.metal3.io/degraded": "true"},
+		}),
+		Entry("Add taints", TestCaseSynchronizeTaintSyncSetOnNode{
+			Labels: map[string]string{"hardware.metal3.io/degraded": "true"},
+			Taints: []corev1.Taint{{Key: "other", Effect: corev1.TaintEffectNoExecute}},
+			ExpectedResult: []corev1.Taint{
+				{Key: "other", Effect: corev1.TaintEffectNoExecute},
+				{Key: "hardware.metal3.io/degraded", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "hardware.metal3.io/degraded", Value: "true", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+		}),
+		Entry("Update and remove taints", TestCaseSynchronizeTaintSyncSetOnNode{
+			Labels: map[string]string{"hardware.metal3.io/degraded": "false"},
+			Taints: []corev1.Taint{
+				{Key: "hardware.metal3.io/degraded", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "hardware.metal3.io/failed", Value: "true", Effect: corev1.TaintEffectNoExecute},
+				{Key: "hardware.metal3.io/old", Value: "true", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+			ExpectedResult: []corev1.Taint{
+				{Key: "hardware.metal3.io/failed", Value: "true", Effect: corev1.TaintEffectNoExecute},
+				{Key: "hardware.metal3.io/degraded", Value: "false", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "hardware.metal3.io/degraded", Value: "false", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+		}),
+		Entry("Remove all taints", TestCaseSynchronizeTaintSyncSetOnNode{
+			Taints: []corev1.Taint{
+				{Key: "hardware.metal3.io/degraded", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+			},
+			ExpectedResult: []corev1.Taint{},
+		}),
+	)
+
 	type TestCaseMetal3ClusterToBMHs struct {
 		Cluster        *clusterv1.Cluster
 		M3Cluster      *infrav1.Metal3Cluster
@@ -383,6 +542,7 @@ var _ = Describe("Metal3LabelSync controller", func() {
 			expectError     bool
 			expectRequeue   bool
 			expectLabelsync map[string]string
+			expectSynced    bool
 			debug           bool
 		}
 		DescribeTable("Test reconcile",
@@ -412,7 +572,7 @@ var _ = Describe("Metal3LabelSync controller", func() {
 				}}).CoreV1()
 				r := &Metal3LabelSyncReconciler{
 					Client:         fakeClient,
-					ManagerFactory: baremetal.NewManagerFactory(fakeClient),
+					ManagerFactory: baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 					Log:            logr.Discard(),
 					CapiClientGetter: func(ctx context.Context, client client.Client, cluster *clusterv1.Cluster) (
 						clientcorev1.CoreV1Interface, error,
@@ -442,6 +602,11 @@ var _ = Describe("Metal3LabelSync controller", func() {
 
 				node, _ := corev1Client.Nodes().Get(context.TODO(), "testNode", metav1.GetOptions{})
 				Expect(node.Labels).To(Equal(tc.expectLabelsync))
+				if tc.expectSynced {
+					m3m := &infrav1.Metal3Machine{}
+					Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(tc.metal3Machine), m3m)).To(Succeed())
+					Expect(conditions.IsTrue(m3m, infrav1.LabelSyncedCondition)).To(BeTrue())
+				}
 			},
 			Entry("Baremetal host not found", testCaseReconcile{
 				expectError:   false,
@@ -520,14 +685,104 @@ var _ = Describe("Metal3LabelSync controller", func() {
 				expectLabelsync: map[string]string{
 					"foo.metal3.io/bar": "blue",
 				},
+				expectSynced: true,
 			}),
 		)
+
+		It("Reports the failure to write the BareMetalHost labels", func() {
+			spec := bmcSpec()
+			spec.LabelSync = &infrav1.LabelSync{
+				Prefixes:  []string{"foo.metal3.io"},
+				Direction: infrav1.LabelSyncDirectionNodeToHost,
+			}
+			metal3Machine := newMetal3Machine(metal3machineName, m3mObjectMetaWithOwnerRef(), nil, nil, false)
+			objects := []client.Object{
+				newBareMetalHost(baremetalhostName, &metal3MachineSpec, nil, nil, false),
+				newMachine(clusterName, machineName, metal3machineName, nodeName),
+				metal3Machine,
+				newCluster(clusterName, nil, nil),
+				newMetal3Cluster(metal3ClusterName, bmcOwnerRef(), spec, nil, nil, false),
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			corev1Client := clientfake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   nodeName,
+				Labels: Labels,
+			}}).CoreV1()
+			r := &Metal3LabelSyncReconciler{
+				Client:         &hostPatchFailingClient{Client: fakeClient},
+				ManagerFactory: baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
+				Log:            logr.Discard(),
+				CapiClientGetter: func(ctx context.Context, client client.Client, cluster *clusterv1.Cluster) (
+					clientcorev1.CoreV1Interface, error,
+				) {
+					return corev1Client, nil
+				},
+			}
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      baremetalhostName,
+					Namespace: namespaceName,
+				},
+			}
+			result, err := r.Reconcile(context.TODO(), req)
+			Expect(err).To(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(requeueAfter))
+
+			m3m := &infrav1.Metal3Machine{}
+			Expect(fakeClient.Get(context.TODO(), client.ObjectKeyFromObject(metal3Machine), m3m)).To(Succeed())
+			Expect(conditions.IsFalse(m3m, infrav1.LabelSyncedCondition)).To(BeTrue())
+			Expect(conditions.GetReason(m3m, infrav1.LabelSyncedCondition)).To(Equal(infrav1.LabelSyncFailedReason))
+		})
+
+		It("Does not block the Node handler once the manager stops", func() {
+			objects := []client.Object{
+				newBareMetalHost(baremetalhostName, &metal3MachineSpec, nil, nil, false),
+				newMachine(clusterName, machineName, metal3machineName, nodeName),
+				newMetal3Machine(metal3machineName, m3mObjectMeta(), nil, nil, false),
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
+			done := make(chan struct{})
+			r := &Metal3LabelSyncReconciler{
+				Client:     fakeClient,
+				Log:        logr.Discard(),
+				nodeEvents: make(chan event.GenericEvent),
+				done:       done,
+			}
+			handled := make(chan struct{})
+			go func() {
+				defer close(handled)
+				r.NodeToBareMetalHost(types.NamespacedName{Name: clusterName, Namespace: namespaceName},
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+				)
+			}()
+			Eventually(r.nodeEvents).Should(Receive())
+			Eventually(handled).Should(BeClosed())
+
+			handled = make(chan struct{})
+			go func() {
+				defer close(handled)
+				r.NodeToBareMetalHost(types.NamespacedName{Name: clusterName, Namespace: namespaceName},
+					&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}},
+				)
+			}()
+			Consistently(handled).ShouldNot(BeClosed())
+			close(done)
+			Eventually(handled).Should(BeClosed())
+		})
+
 		type TestCaseReconcileBMHLabels struct {
-			PrefixSet   map[string]struct{}
-			Host        *bmov1alpha1.BareMetalHost
-			Machine     *clusterv1.Machine
-			Cluster     *clusterv1.Cluster
-			ExpectError bool
+			PrefixSet      map[string]struct{}
+			LabelSync      *infrav1.LabelSync
+			Host           *bmov1alpha1.BareMetalHost
+			Machine        *clusterv1.Machine
+			Cluster        *clusterv1.Cluster
+			NodeLabels     map[string]string
+			ExpectError    bool
+			ExpectedLabels map[string]string
+			ExpectedTaints []corev1.Taint
+			// ExpectedHostLabels are checked when set.
+			ExpectedHostLabels map[string]string
+			ExpectNoPatch      bool
 		}
 
 		DescribeTable("Test reconcileBMHLabels",
@@ -538,12 +793,14 @@ var _ = Describe("Metal3LabelSync controller", func() {
 					tc.Machine,
 				}
 				fakeClient := fake.NewClientBuilder().WithScheme(setupScheme()).WithObjects(objects...).Build()
-				corev1Client := clientfake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
-					Name: nodeName,
-				}}).CoreV1()
+				clientset := clientfake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: tc.NodeLabels,
+				}})
+				corev1Client := clientset.CoreV1()
 				r := &Metal3LabelSyncReconciler{
 					Client:         fakeClient,
-					ManagerFactory: baremetal.NewManagerFactory(fakeClient),
+					ManagerFactory: baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 					Log:            logr.Discard(),
 					CapiClientGetter: func(ctx context.Context, client client.Client, cluster *clusterv1.Cluster) (
 						clientcorev1.CoreV1Interface, error,
@@ -553,21 +810,99 @@ var _ = Describe("Metal3LabelSync controller", func() {
 					WatchFilterValue: "",
 				}
 				err := r.reconcileBMHLabels(context.TODO(),
-					tc.Host, tc.Machine, tc.Cluster, tc.PrefixSet)
+					tc.Host, tc.Machine, tc.Cluster, tc.PrefixSet, tc.LabelSync)
 
 				if tc.ExpectError {
 					Expect(err).To(HaveOccurred())
 				} else {
 					Expect(err).NotTo(HaveOccurred())
 				}
+				node, err := corev1Client.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(node.Labels).To(Equal(tc.ExpectedLabels))
+				Expect(node.Spec.Taints).To(Equal(tc.ExpectedTaints))
+				if tc.ExpectedHostLabels != nil {
+					Expect(tc.Host.Labels).To(Equal(tc.ExpectedHostLabels))
+				}
+				if tc.ExpectNoPatch {
+					for _, action := range clientset.Actions() {
+						Expect(action.GetVerb()).NotTo(Equal("patch"))
+					}
+				}
 			},
 			Entry("No errors", TestCaseReconcileBMHLabels{
 				PrefixSet: map[string]struct{}{
 					"foo.metal3.io": {},
 				},
+				Host:           newBareMetalHost(baremetalhostName, nil, nil, Labels, false),
+				Machine:        newMachine(clusterName, machineName, metal3machineName, nodeName),
+				Cluster:        newCluster(clusterName, nil, nil),
+				ExpectedLabels: Labels,
+			}),
+			Entry("Node up to date", TestCaseReconcileBMHLabels{
+				PrefixSet: map[string]struct{}{
+					"foo.metal3.io": {},
+				},
+				Host:           newBareMetalHost(baremetalhostName, nil, nil, Labels, false),
+				Machine:        newMachine(clusterName, machineName, metal3machineName, nodeName),
+				Cluster:        newCluster(clusterName, nil, nil),
+				NodeLabels:     map[string]string{"foo.metal3.io/bar": "blue"},
+				ExpectedLabels: Labels,
+				ExpectNoPatch:  true,
+			}),
+			Entry("Labels as taints", TestCaseReconcileBMHLabels{
+				PrefixSet: map[string]struct{}{},
+				LabelSync: &infrav1.LabelSync{
+					Taints: []infrav1.TaintSync{{
+						Prefix: "foo.metal3.io",
+						Effect: corev1.TaintEffectNoSchedule,
+					}},
+				},
 				Host:    newBareMetalHost(baremetalhostName, nil, nil, Labels, false),
 				Machine: newMachine(clusterName, machineName, metal3machineName, nodeName),
 				Cluster: newCluster(clusterName, nil, nil),
+				ExpectedTaints: []corev1.Taint{{
+					Key:    "foo.metal3.io/bar",
+					Value:  "blue",
+					Effect: corev1.TaintEffectNoSchedule,
+				}},
+			}),
+			Entry("Excluded label", TestCaseReconcileBMHLabels{
+				PrefixSet: map[string]struct{}{},
+				LabelSync: &infrav1.LabelSync{
+					Prefixes: []string{"foo.metal3.io"},
+					Exclude:  []string{"foo.metal3.io/bar"},
+				},
+				Host:    newBareMetalHost(baremetalhostName, nil, nil, Labels, false),
+				Machine: newMachine(clusterName, machineName, metal3machineName, nodeName),
+				Cluster: newCluster(clusterName, nil, nil),
+			}),
+			Entry("Node to host", TestCaseReconcileBMHLabels{
+				PrefixSet: map[string]struct{}{
+					"foo.metal3.io": {},
+				},
+				LabelSync: &infrav1.LabelSync{
+					Direction: infrav1.LabelSyncDirectionNodeToHost,
+				},
+				Host: newBareMetalHost(baremetalhostName, nil, nil, map[string]string{
+					"foo.metal3.io/bar": "blue",
+				}, false),
+				Machine:            newMachine(clusterName, machineName, metal3machineName, nodeName),
+				Cluster:            newCluster(clusterName, nil, nil),
+				ExpectedHostLabels: map[string]string{},
+			}),
+			Entry("Both directions", TestCaseReconcileBMHLabels{
+				PrefixSet: map[string]struct{}{
+					"foo.metal3.io": {},
+				},
+				LabelSync: &infrav1.LabelSync{
+					Direction: infrav1.LabelSyncDirectionBoth,
+				},
+				Host:               newBareMetalHost(baremetalhostName, nil, nil, Labels, false),
+				Machine:            newMachine(clusterName, machineName, metal3machineName, nodeName),
+				Cluster:            newCluster(clusterName, nil, nil),
+				ExpectedLabels:     Labels,
+				ExpectedHostLabels: Labels,
 			}),
 		)
 	})
@@ -586,3 +921,15 @@ func m3mObjectMeta() *metav1.ObjectMeta {
 		},
 	}
 }
+
+// hostPatchFailingClient fails to patch the BareMetalHosts.
+type hostPatchFailingClient struct {
+	client.Client
+}
+
+func (c *hostPatchFailingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if _, ok := obj.(*bmov1alpha1.BareMetalHost); ok {
+		return errors.New("failed to patch for some weird reason")
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
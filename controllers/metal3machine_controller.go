@@ -152,6 +152,10 @@ func (r *Metal3MachineReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Check pause annotation on associated bmh (if any)
 	if !cluster.Spec.Paused {
 		err := machineMgr.RemovePauseAnnotation(ctx)
+		if ok := errors.As(err, &hasRequeueAfterError); ok {
+			machineLog.Info("associated bmh is kept paused, will retry")
+			return ctrl.Result{Requeue: true, RequeueAfter: hasRequeueAfterError.GetRequeueAfter()}, nil
+		}
 		if err != nil {
 			machineLog.Info("failed to check pause annotation on associated bmh")
 			return ctrl.Result{}, nil
@@ -182,6 +186,8 @@ func (r *Metal3MachineReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 func patchMetal3Machine(ctx context.Context, patchHelper *patch.Helper, metal3Machine *infrav1.Metal3Machine, options ...patch.Option) error {
 	// Always update the readyCondition by summarizing the state of other conditions.
+	// The ImageUpgradedCondition is left out, an image upgrade in progress
+	// does not make the Metal3Machine not ready.
 	conditions.SetSummary(metal3Machine,
 		conditions.WithConditions(
 			infrav1.AssociateBMHCondition,
@@ -195,6 +201,7 @@ func patchMetal3Machine(ctx context.Context, patchHelper *patch.Helper, metal3Ma
 			clusterv1.ReadyCondition,
 			infrav1.AssociateBMHCondition,
 			infrav1.KubernetesNodeReadyCondition,
+			infrav1.ImageUpgradedCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
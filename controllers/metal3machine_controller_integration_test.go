@@ -41,6 +41,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientfake "k8s.io/client-go/kubernetes/fake"
 	clientcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -196,7 +197,7 @@ var _ = Describe("Reconcile metal3machine", func() {
 
 			r := &Metal3MachineReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				CapiClientGetter: mockCapiClientGetter,
 				WatchFilterValue: "",
@@ -34,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -210,7 +211,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 			bmReconcile = &Metal3MachineReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				CapiClientGetter: nil,
 				WatchFilterValue: "",
@@ -300,7 +301,7 @@ var _ = Describe("Metal3Machine manager", func() {
 
 			bmReconcile = &Metal3MachineReconciler{
 				Client:           fakeClient,
-				ManagerFactory:   baremetal.NewManagerFactory(fakeClient),
+				ManagerFactory:   baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 				Log:              logr.Discard(),
 				CapiClientGetter: nil,
 				WatchFilterValue: "",
@@ -24,6 +24,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 
+	bmov1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
 	infrav1 "github.com/metal3-io/cluster-api-provider-metal3/api/v1beta1"
 	"github.com/metal3-io/cluster-api-provider-metal3/baremetal"
 
@@ -37,6 +38,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// remediationBudgetRequeueAfter is the time to wait before checking again
+// the remediation budget of the cluster.
+const remediationBudgetRequeueAfter = 30 * time.Second
+
 // Metal3RemediationReconciler reconciles a Metal3Remediation object.
 type Metal3RemediationReconciler struct {
 	client.Client
@@ -47,6 +52,8 @@ type Metal3RemediationReconciler struct {
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3remediations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3remediations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=metal3clusters;metal3clusters/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch;delete
 
 // Reconcile handles Metal3Remediation events.
@@ -134,21 +141,45 @@ func (r *Metal3RemediationReconciler) reconcileNormal(ctx context.Context,
 	// do not try to remediate the host
 	if !remediationMgr.OnlineStatus(host) {
 		r.Log.Info("Unable to remediate, Host is powered off (spec.Online is false)")
+		if err := r.releaseRemediationBudget(ctx, remediationMgr); err != nil {
+			return ctrl.Result{}, err
+		}
+		if remediationMgr.GetRemediationPhase() != infrav1.PhaseFailed {
+			baremetal.RecordRemediationOutcome(infrav1.PhaseFailed, baremetal.RemediationOutcomeFailed)
+		}
 		remediationMgr.SetRemediationPhase(infrav1.PhaseFailed)
 		return ctrl.Result{}, nil
 	}
 
 	remediationType := remediationMgr.GetRemediationType()
 
-	if remediationType != infrav1.RebootRemediationStrategy {
+	if remediationType != infrav1.RebootRemediationStrategy &&
+		remediationType != infrav1.SoftRebootRemediationStrategy &&
+		remediationType != infrav1.ReprovisionRemediationStrategy &&
+		remediationType != infrav1.HostSwapRemediationStrategy {
 		r.Log.Info("unsupported remediation strategy")
 		return ctrl.Result{}, nil
 	}
 
-	if remediationType == infrav1.RebootRemediationStrategy {
+	if remediationType == infrav1.RebootRemediationStrategy ||
+		remediationType == infrav1.SoftRebootRemediationStrategy ||
+		remediationType == infrav1.ReprovisionRemediationStrategy ||
+		remediationType == infrav1.HostSwapRemediationStrategy {
 		// If no phase set, default to running and set time and retry count
 		if remediationMgr.GetRemediationPhase() == "" {
+			// Wait if the remediation budget of the cluster is exceeded
+			allowed, err := remediationMgr.AcquireRemediationBudget(ctx)
+			if err != nil {
+				r.Log.Error(err, "error checking the remediation budget of the cluster")
+				return ctrl.Result{}, errors.Wrap(err, "error checking the remediation budget of the cluster")
+			}
+			if !allowed {
+				r.Log.Info("Remediation budget of the cluster exceeded, waiting")
+				return ctrl.Result{RequeueAfter: remediationBudgetRequeueAfter}, nil
+			}
+
 			remediationMgr.SetRemediationPhase(infrav1.PhaseRunning)
+			baremetal.RecordRemediationOutcome(infrav1.PhaseRunning, baremetal.RemediationOutcomeStarted)
 			now := metav1.Now()
 			remediationMgr.SetLastRemediationTime(&now)
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
@@ -177,21 +208,39 @@ func (r *Metal3RemediationReconciler) reconcileNormal(ctx context.Context,
 		switch remediationMgr.GetRemediationPhase() {
 		case infrav1.PhaseRunning:
 
+			switch remediationType {
+			case infrav1.ReprovisionRemediationStrategy:
+				return r.remediateReprovisionStrategy(ctx, remediationMgr, clusterClient, node)
+			case infrav1.HostSwapRemediationStrategy:
+				return r.remediateHostSwapStrategy(ctx, remediationMgr, clusterClient, node)
+			}
 			return r.remediateRebootStrategy(ctx, remediationMgr, clusterClient, node)
 
 		case infrav1.PhaseWaiting:
 
-			// Node is deleted: remove power off annotation
-			ok, err := remediationMgr.IsPowerOffRequested(ctx)
-			if err != nil {
-				r.Log.Error(err, "error getting poweroff annotation status")
-				return ctrl.Result{}, errors.Wrap(err, "error getting poweroff annotation status")
-			} else if ok {
-				r.Log.Info("Powering on the host")
-				err := remediationMgr.RemovePowerOffAnnotation(ctx)
+			if remediationType == infrav1.ReprovisionRemediationStrategy ||
+				remediationType == infrav1.HostSwapRemediationStrategy {
+				// Wait until the host, or the spare host, is provisioned
+				provisioned, err := r.isHostReprovisioned(ctx, remediationMgr)
+				if err != nil {
+					return ctrl.Result{}, err
+				}
+				if !provisioned {
+					return r.retryOrGiveUp(ctx, remediationMgr)
+				}
+			} else {
+				// Node is deleted: remove power off annotation
+				ok, err := remediationMgr.IsPowerOffRequested(ctx)
 				if err != nil {
-					r.Log.Error(err, "error removing poweroff annotation")
-					return ctrl.Result{}, errors.Wrap(err, "error removing poweroff annotation")
+					r.Log.Error(err, "error getting poweroff annotation status")
+					return ctrl.Result{}, errors.Wrap(err, "error getting poweroff annotation status")
+				} else if ok {
+					r.Log.Info("Powering on the host")
+					err := remediationMgr.RemovePowerOffAnnotation(ctx)
+					if err != nil {
+						r.Log.Error(err, "error removing poweroff annotation")
+						return ctrl.Result{}, errors.Wrap(err, "error removing poweroff annotation")
+					}
 				}
 			}
 
@@ -215,12 +264,20 @@ func (r *Metal3RemediationReconciler) reconcileNormal(ctx context.Context,
 
 					// clean up
 					r.Log.Info("Remediation done, cleaning up remediation CR")
+					if err := r.releaseRemediationBudget(ctx, remediationMgr); err != nil {
+						return ctrl.Result{}, err
+					}
 					remediationMgr.RemoveNodeBackupAnnotations()
 					remediationMgr.UnsetFinalizer()
+					baremetal.RecordRemediationOutcome(infrav1.PhaseWaiting, baremetal.RemediationOutcomeSucceeded)
 					return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 				} else if isNodeForbidden {
 					// we don't have a node, just remove finalizer
+					if err := r.releaseRemediationBudget(ctx, remediationMgr); err != nil {
+						return ctrl.Result{}, err
+					}
 					remediationMgr.UnsetFinalizer()
+					baremetal.RecordRemediationOutcome(infrav1.PhaseWaiting, baremetal.RemediationOutcomeSucceeded)
 
 					r.Log.Info("Skipping node restore, remediation done, CR should be deleted soon")
 					return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
@@ -228,45 +285,7 @@ func (r *Metal3RemediationReconciler) reconcileNormal(ctx context.Context,
 			}
 
 			// Check timeout, either node wasn't recreated yet, or CR is not deleted because of still unhealthy node
-			timedOut, _ := remediationMgr.TimeToRemediate(remediationMgr.GetTimeout().Duration)
-			if !timedOut {
-				// Not yet time to retry or stop remediation, requeue
-				r.Log.Info("Waiting for node to get healthy and CR being deleted")
-				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
-			}
-
-			// Try again if limit not reached
-			if remediationMgr.RetryLimitIsSet() && !remediationMgr.HasReachRetryLimit() {
-				r.Log.Info("Remediation timed out, will retry")
-				remediationMgr.SetRemediationPhase(infrav1.PhaseRunning)
-				now := metav1.Now()
-				remediationMgr.SetLastRemediationTime(&now)
-				remediationMgr.IncreaseRetryCount()
-				return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
-			}
-
-			r.Log.Info("Remediation timed out and retry limit reached")
-
-			// When machine is still unhealthy after remediation, setting of OwnerRemediatedCondition
-			// moves control to CAPI machine controller. The owning controller will do
-			// preflight checks and handles the Machine deletion
-			err = remediationMgr.SetOwnerRemediatedConditionNew(ctx)
-			if err != nil {
-				r.Log.Error(err, "error setting cluster api conditions")
-				return ctrl.Result{}, errors.Wrapf(err, "error setting cluster api conditions")
-			}
-
-			// Remediation failed, so set unhealthy annotation on BMH
-			// This prevents BMH to be selected as a host.
-			err = remediationMgr.SetUnhealthyAnnotation(ctx)
-			if err != nil {
-				r.Log.Error(err, "error setting unhealthy annotation")
-				return ctrl.Result{}, errors.Wrapf(err, "error setting unhealthy annotation")
-			}
-
-			remediationMgr.SetRemediationPhase(infrav1.PhaseDeleting)
-			// no requeue, we are done
-			return ctrl.Result{}, nil
+			return r.retryOrGiveUp(ctx, remediationMgr)
 
 		case infrav1.PhaseDeleting:
 			// nothing to do anymore
@@ -300,6 +319,15 @@ func (r *Metal3RemediationReconciler) remediateRebootStrategy(ctx context.Contex
 		r.Log.Error(err, "error getting poweroff annotation status")
 		return ctrl.Result{}, errors.Wrap(err, "error getting poweroff annotation status")
 	} else if !ok {
+		// drain the node first if requested
+		drained, err := r.drainNode(ctx, remediationMgr, clusterClient, node)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !drained {
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
 		r.Log.Info("Powering off the host")
 		err = remediationMgr.SetPowerOffAnnotation(ctx)
 		if err != nil {
@@ -329,28 +357,302 @@ func (r *Metal3RemediationReconciler) remediateRebootStrategy(ctx context.Contex
 			in corruption or other issues for applications with singleton requirement. After the host is powered
 			off we know for sure that it is safe to re-assign that workload to other nodes.
 		*/
-		modified := r.backupNode(remediationMgr, node)
-		if modified {
-			r.Log.Info("Backing up node")
-			// save annotations before deleting node
-			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		return r.backupAndDeleteNode(ctx, remediationMgr, clusterClient, node)
+	}
+
+	// we are done for this phase, switch to waiting for power on and the node restore
+	remediationMgr.SetRemediationPhase(infrav1.PhaseWaiting)
+	r.Log.Info("Switch to waiting phase for power on and node restore")
+	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// remediateReprovisionStrategy executes the remediation using the reprovision
+// strategy: the host is deprovisioned, then provisioned again by the
+// Metal3Machine controller with the same image, user data and network data.
+func (r *Metal3RemediationReconciler) remediateReprovisionStrategy(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface, clusterClient v1.CoreV1Interface,
+	node *corev1.Node) (ctrl.Result, error) {
+	// add finalizer
+	if !remediationMgr.HasFinalizer() {
+		remediationMgr.SetFinalizer()
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
+	// deprovision if needed
+	state, err := remediationMgr.GetProvisioningState(ctx)
+	if err != nil {
+		r.Log.Error(err, "error getting provisioning state")
+		return ctrl.Result{}, errors.Wrap(err, "error getting provisioning state")
+	}
+	if state == bmov1alpha1.StateProvisioned {
+		requested, err := remediationMgr.IsDeprovisionRequested(ctx)
+		if err != nil {
+			r.Log.Error(err, "error getting host image")
+			return ctrl.Result{}, errors.Wrap(err, "error getting host image")
 		}
-		r.Log.Info("Deleting node")
-		err := remediationMgr.DeleteNode(ctx, clusterClient, node)
+		if !requested {
+			// drain the node first if requested
+			drained, err := r.drainNode(ctx, remediationMgr, clusterClient, node)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !drained {
+				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			}
+
+			r.Log.Info("Deprovisioning the host")
+			err = remediationMgr.DeprovisionHost(ctx)
+			if err != nil {
+				r.Log.Error(err, "error deprovisioning host")
+				return ctrl.Result{}, errors.Wrap(err, "error deprovisioning host")
+			}
+		}
+
+		// wait a bit before checking if the host left the provisioned state
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// the operating system of the host is not running anymore, so the
+	// workload can safely be re-assigned to other nodes.
+	if node != nil {
+		return r.backupAndDeleteNode(ctx, remediationMgr, clusterClient, node)
+	}
+
+	// we are done for this phase, switch to waiting for provisioning and the node restore
+	remediationMgr.SetRemediationPhase(infrav1.PhaseWaiting)
+	r.Log.Info("Switch to waiting phase for provisioning and node restore")
+	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// remediateHostSwapStrategy executes the remediation using the host swap
+// strategy: the unhealthy host is released and the Metal3Machine controller
+// associates the Metal3Machine with a spare host.
+func (r *Metal3RemediationReconciler) remediateHostSwapStrategy(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface, clusterClient v1.CoreV1Interface,
+	node *corev1.Node) (ctrl.Result, error) {
+	// add finalizer
+	if !remediationMgr.HasFinalizer() {
+		remediationMgr.SetFinalizer()
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
+	// release the host if needed
+	released, err := remediationMgr.IsHostReleased(ctx)
+	if err != nil {
+		r.Log.Error(err, "error getting host consumer")
+		return ctrl.Result{}, errors.Wrap(err, "error getting host consumer")
+	}
+	if !released {
+		// drain the node first if requested
+		drained, err := r.drainNode(ctx, remediationMgr, clusterClient, node)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !drained {
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+
+		r.Log.Info("Releasing the host")
+		err = remediationMgr.ReleaseUnhealthyHost(ctx)
 		if err != nil {
-			r.Log.Error(err, "error deleting node")
-			return ctrl.Result{}, errors.Wrap(err, "error deleting node")
+			r.Log.Error(err, "error releasing host")
+			return ctrl.Result{}, errors.Wrap(err, "error releasing host")
 		}
-		// wait until node is gone
+		// wait a bit before checking if the host left the provisioned state
 		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 	}
 
-	// we are done for this phase, switch to waiting for power on and the node restore
+	state, err := remediationMgr.GetProvisioningState(ctx)
+	if err != nil {
+		r.Log.Error(err, "error getting provisioning state")
+		return ctrl.Result{}, errors.Wrap(err, "error getting provisioning state")
+	}
+	if state == bmov1alpha1.StateProvisioned {
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	// the operating system of the host is not running anymore, so the
+	// workload can safely be re-assigned to other nodes.
+	if node != nil {
+		return r.backupAndDeleteNode(ctx, remediationMgr, clusterClient, node)
+	}
+
+	r.Log.Info("Dissociating the Metal3Machine from the host")
+	err = remediationMgr.DissociateMetal3Machine(ctx)
+	if err != nil {
+		r.Log.Error(err, "error dissociating metal3machine")
+		return ctrl.Result{}, errors.Wrap(err, "error dissociating metal3machine")
+	}
+
+	// we are done for this phase, switch to waiting for the spare host and the node restore
 	remediationMgr.SetRemediationPhase(infrav1.PhaseWaiting)
-	r.Log.Info("Switch to waiting phase for power on and node restore")
+	r.Log.Info("Switch to waiting phase for spare host provisioning and node restore")
+	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// drainNode cordons the node and evicts its pods when the remediation asks
+// for it. Returns true once the node is drained, or once the drain timed out,
+// so that the host can be powered off, deprovisioned or released.
+func (r *Metal3RemediationReconciler) drainNode(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface, clusterClient v1.CoreV1Interface,
+	node *corev1.Node) (bool, error) {
+	drainTimeout := remediationMgr.GetNodeDrainTimeout()
+	if drainTimeout == nil || node == nil {
+		return true, nil
+	}
+
+	drainStartTime := remediationMgr.GetNodeDrainStartTime()
+	if drainStartTime == nil {
+		now := metav1.Now()
+		drainStartTime = &now
+		remediationMgr.SetNodeDrainStartTime(drainStartTime)
+	}
+
+	if !node.Spec.Unschedulable {
+		r.Log.Info("Cordoning the node")
+		if err := remediationMgr.CordonNode(ctx, clusterClient, node); err != nil {
+			r.Log.Error(err, "error cordoning node")
+			return false, errors.Wrap(err, "error cordoning node")
+		}
+	}
+
+	remaining, err := remediationMgr.EvictPods(ctx, clusterClient, node)
+	if err != nil {
+		r.Log.Error(err, "error evicting pods")
+		return false, errors.Wrap(err, "error evicting pods")
+	}
+	if remaining == 0 {
+		r.Log.Info("Node drained")
+		return true, nil
+	}
+
+	if drainStartTime.Add(drainTimeout.Duration).Before(time.Now()) {
+		r.Log.Info("Node drain timed out, going on with the remediation", "remainingPods", remaining)
+		return true, nil
+	}
+	r.Log.Info("Waiting for the pods to be evicted", "remainingPods", remaining)
+	return false, nil
+}
+
+// isHostReprovisioned returns true once the host deprovisioned by the
+// reprovision strategy is provisioned again, or once the spare host chosen
+// for the host swap strategy is provisioned.
+func (r *Metal3RemediationReconciler) isHostReprovisioned(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface) (bool, error) {
+	state, err := remediationMgr.GetProvisioningState(ctx)
+	if err != nil {
+		r.Log.Error(err, "error getting provisioning state")
+		return false, errors.Wrap(err, "error getting provisioning state")
+	}
+	if state != bmov1alpha1.StateProvisioned {
+		return false, nil
+	}
+	requested, err := remediationMgr.IsDeprovisionRequested(ctx)
+	if err != nil {
+		r.Log.Error(err, "error getting host image")
+		return false, errors.Wrap(err, "error getting host image")
+	}
+	return !requested, nil
+}
+
+// backupAndDeleteNode stores the annotations and labels of the node in the
+// remediation, then deletes it.
+func (r *Metal3RemediationReconciler) backupAndDeleteNode(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface, clusterClient v1.CoreV1Interface,
+	node *corev1.Node) (ctrl.Result, error) {
+	modified := r.backupNode(remediationMgr, node)
+	if modified {
+		r.Log.Info("Backing up node")
+		// save annotations before deleting node
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+	r.Log.Info("Deleting node")
+	err := remediationMgr.DeleteNode(ctx, clusterClient, node)
+	if err != nil {
+		r.Log.Error(err, "error deleting node")
+		return ctrl.Result{}, errors.Wrap(err, "error deleting node")
+	}
+	// wait until node is gone
 	return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 }
 
+// retryOrGiveUp restarts the remediation once its timeout expired, unless the
+// retry limit is reached, in which case the remediation escalates to the next
+// step, or the Machine is marked for deletion when there is none.
+func (r *Metal3RemediationReconciler) retryOrGiveUp(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface) (ctrl.Result, error) {
+	timedOut, _ := remediationMgr.TimeToRemediate(remediationMgr.GetTimeout().Duration)
+	if !timedOut {
+		// Not yet time to retry or stop remediation, requeue
+		r.Log.Info("Waiting for node to get healthy and CR being deleted")
+		return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+
+	now := metav1.Now()
+	remediationMgr.RecordAttempt(&now)
+
+	// Try again if limit not reached
+	if remediationMgr.RetryLimitIsSet() && !remediationMgr.HasReachRetryLimit() {
+		r.Log.Info("Remediation timed out, will retry")
+		remediationMgr.SetRemediationPhase(infrav1.PhaseRunning)
+		remediationMgr.SetLastRemediationTime(&now)
+		remediationMgr.SetNodeDrainStartTime(nil)
+		remediationMgr.IncreaseRetryCount()
+		baremetal.RecordRemediationOutcome(infrav1.PhaseRunning, baremetal.RemediationOutcomeRetried)
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
+	// Escalate to the next step if any
+	if remediationMgr.HasNextStep() {
+		r.Log.Info("Remediation timed out and retry limit reached, will try the next step")
+		remediationMgr.StartNextStep()
+		remediationMgr.SetRemediationPhase(infrav1.PhaseRunning)
+		remediationMgr.SetLastRemediationTime(&now)
+		remediationMgr.SetNodeDrainStartTime(nil)
+		baremetal.RecordRemediationOutcome(infrav1.PhaseRunning, baremetal.RemediationOutcomeEscalated)
+		return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+	}
+
+	r.Log.Info("Remediation timed out and retry limit reached")
+
+	// When machine is still unhealthy after remediation, setting of OwnerRemediatedCondition
+	// moves control to CAPI machine controller. The owning controller will do
+	// preflight checks and handles the Machine deletion
+	err := remediationMgr.SetOwnerRemediatedConditionNew(ctx)
+	if err != nil {
+		r.Log.Error(err, "error setting cluster api conditions")
+		return ctrl.Result{}, errors.Wrapf(err, "error setting cluster api conditions")
+	}
+
+	// Remediation failed, so set unhealthy annotation on BMH
+	// This prevents BMH to be selected as a host.
+	err = remediationMgr.SetUnhealthyAnnotation(ctx)
+	if err != nil {
+		r.Log.Error(err, "error setting unhealthy annotation")
+		return ctrl.Result{}, errors.Wrapf(err, "error setting unhealthy annotation")
+	}
+
+	if err := r.releaseRemediationBudget(ctx, remediationMgr); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	remediationMgr.SetRemediationPhase(infrav1.PhaseDeleting)
+	baremetal.RecordRemediationOutcome(infrav1.PhaseDeleting, baremetal.RemediationOutcomeFailed)
+	// no requeue, we are done
+	return ctrl.Result{}, nil
+}
+
+// releaseRemediationBudget releases the remediation budget of the cluster held
+// by the remediation once it is over.
+func (r *Metal3RemediationReconciler) releaseRemediationBudget(ctx context.Context,
+	remediationMgr baremetal.RemediationManagerInterface) error {
+	if err := remediationMgr.ReleaseRemediationBudget(ctx); err != nil {
+		r.Log.Error(err, "error releasing the remediation budget of the cluster")
+		return errors.Wrap(err, "error releasing the remediation budget of the cluster")
+	}
+	return nil
+}
+
 // Returns whether annotations or labels were set / updated.
 func (r *Metal3RemediationReconciler) backupNode(remediationMgr baremetal.RemediationManagerInterface,
 	node *corev1.Node) bool {
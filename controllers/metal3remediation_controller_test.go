@@ -35,23 +35,33 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type reconcileNormalRemediationTestCase struct {
-	ExpectError           bool
-	ExpectRequeue         bool
-	GetUnhealthyHostFails bool
-	HostStatusOffline     bool
-	RemediationPhase      string
-	IsFinalizerSet        bool
-	IsPowerOffRequested   bool
-	IsPoweredOn           bool
-	IsNodeForbidden       bool
-	IsNodeBackedUp        bool
-	IsNodeDeleted         bool
-	IsTimedOut            bool
-	IsRetryLimitReached   bool
+	ExpectError            bool
+	ExpectRequeue          bool
+	GetUnhealthyHostFails  bool
+	HostStatusOffline      bool
+	RemediationPhase       string
+	IsFinalizerSet         bool
+	IsPowerOffRequested    bool
+	IsPoweredOn            bool
+	IsNodeForbidden        bool
+	IsNodeBackedUp         bool
+	IsNodeDeleted          bool
+	IsTimedOut             bool
+	IsRetryLimitReached    bool
+	RemediationType        infrav1.RemediationType
+	ProvisioningState      bmov1alpha1.ProvisioningState
+	IsDeprovisionRequested bool
+	IsHostReleased         bool
+	HasNextStep            bool
+	IsBudgetExceeded       bool
+	IsDrainRequested       bool
+	IsDrained              bool
+	IsDrainFailing         bool
 }
 
 func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
@@ -68,6 +78,8 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 	// If user has set bmh.Spec.Online to false, do not try to remediate the host and set remediation phase to failed
 	if tc.HostStatusOffline {
 		m.EXPECT().OnlineStatus(bmh).Return(false)
+		m.EXPECT().ReleaseRemediationBudget(context.TODO())
+		m.EXPECT().GetRemediationPhase().Return(tc.RemediationPhase)
 		m.EXPECT().SetRemediationPhase(infrav1.PhaseFailed)
 		return m
 	}
@@ -94,11 +106,75 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 		}
 	}
 
-	m.EXPECT().GetRemediationType().Return(infrav1.RebootRemediationStrategy)
+	// expectDrain returns true if the node is drained, or does not need to be.
+	expectDrain := func() bool {
+		if !tc.IsDrainRequested {
+			m.EXPECT().GetNodeDrainTimeout().Return(nil)
+			return true
+		}
+		m.EXPECT().GetNodeDrainTimeout().Return(&metav1.Duration{Duration: time.Minute})
+		if tc.IsNodeForbidden || tc.IsNodeDeleted {
+			return true
+		}
+		m.EXPECT().GetNodeDrainStartTime().Return(nil)
+		m.EXPECT().SetNodeDrainStartTime(gomock.Any())
+		m.EXPECT().CordonNode(context.TODO(), gomock.Any(), node)
+		if tc.IsDrainFailing {
+			m.EXPECT().EvictPods(context.TODO(), gomock.Any(), node).Return(0, fmt.Errorf("can't evict pods"))
+			return false
+		}
+		if tc.IsDrained {
+			m.EXPECT().EvictPods(context.TODO(), gomock.Any(), node).Return(0, nil)
+			return true
+		}
+		m.EXPECT().EvictPods(context.TODO(), gomock.Any(), node).Return(1, nil)
+		return false
+	}
+
+	expectRetry := func() {
+		m.EXPECT().GetTimeout().Return(&metav1.Duration{Duration: time.Second})
+		m.EXPECT().TimeToRemediate(gomock.Any()).Return(tc.IsTimedOut, time.Second)
+		if tc.IsTimedOut {
+			m.EXPECT().RecordAttempt(gomock.Any())
+			m.EXPECT().RetryLimitIsSet().Return(true)
+			m.EXPECT().HasReachRetryLimit().Return(tc.IsRetryLimitReached)
+			if !tc.IsRetryLimitReached {
+				m.EXPECT().SetRemediationPhase(infrav1.PhaseRunning)
+				m.EXPECT().SetLastRemediationTime(gomock.Any())
+				m.EXPECT().SetNodeDrainStartTime(nil)
+				m.EXPECT().IncreaseRetryCount()
+				return
+			}
+			m.EXPECT().HasNextStep().Return(tc.HasNextStep)
+			if tc.HasNextStep {
+				m.EXPECT().StartNextStep()
+				m.EXPECT().SetRemediationPhase(infrav1.PhaseRunning)
+				m.EXPECT().SetLastRemediationTime(gomock.Any())
+				m.EXPECT().SetNodeDrainStartTime(nil)
+				return
+			}
+			m.EXPECT().SetOwnerRemediatedConditionNew(context.TODO())
+			m.EXPECT().SetUnhealthyAnnotation(context.TODO())
+			m.EXPECT().ReleaseRemediationBudget(context.TODO())
+			m.EXPECT().SetRemediationPhase(infrav1.PhaseDeleting)
+		}
+	}
+
+	hostSwap := tc.RemediationType == infrav1.HostSwapRemediationStrategy
+	reprovision := tc.RemediationType == infrav1.ReprovisionRemediationStrategy || hostSwap
+	if tc.RemediationType != "" {
+		m.EXPECT().GetRemediationType().Return(tc.RemediationType)
+	} else {
+		m.EXPECT().GetRemediationType().Return(infrav1.RebootRemediationStrategy)
+	}
 	m.EXPECT().GetRemediationPhase().Return(tc.RemediationPhase).MinTimes(1)
 
 	switch tc.RemediationPhase {
 	case "":
+		m.EXPECT().AcquireRemediationBudget(context.TODO()).Return(!tc.IsBudgetExceeded, nil)
+		if tc.IsBudgetExceeded {
+			return m
+		}
 		m.EXPECT().SetRemediationPhase(infrav1.PhaseRunning)
 		m.EXPECT().SetLastRemediationTime(gomock.Any())
 
@@ -112,15 +188,45 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 			return m
 		}
 
-		m.EXPECT().IsPowerOffRequested(context.TODO()).Return(tc.IsPowerOffRequested, nil)
-		if !tc.IsPowerOffRequested {
-			m.EXPECT().SetPowerOffAnnotation(context.TODO())
-			return m
-		}
+		if hostSwap {
+			m.EXPECT().IsHostReleased(context.TODO()).Return(tc.IsHostReleased, nil)
+			if !tc.IsHostReleased {
+				if !expectDrain() {
+					return m
+				}
+				m.EXPECT().ReleaseUnhealthyHost(context.TODO())
+				return m
+			}
+			m.EXPECT().GetProvisioningState(context.TODO()).Return(tc.ProvisioningState, nil)
+			if tc.ProvisioningState == bmov1alpha1.StateProvisioned {
+				return m
+			}
+		} else if reprovision {
+			m.EXPECT().GetProvisioningState(context.TODO()).Return(tc.ProvisioningState, nil)
+			if tc.ProvisioningState == bmov1alpha1.StateProvisioned {
+				m.EXPECT().IsDeprovisionRequested(context.TODO()).Return(tc.IsDeprovisionRequested, nil)
+				if !tc.IsDeprovisionRequested {
+					if !expectDrain() {
+						return m
+					}
+					m.EXPECT().DeprovisionHost(context.TODO())
+				}
+				return m
+			}
+		} else {
+			m.EXPECT().IsPowerOffRequested(context.TODO()).Return(tc.IsPowerOffRequested, nil)
+			if !tc.IsPowerOffRequested {
+				if !expectDrain() {
+					return m
+				}
+				m.EXPECT().SetPowerOffAnnotation(context.TODO())
+				return m
+			}
 
-		m.EXPECT().IsPoweredOn(context.TODO()).Return(tc.IsPoweredOn, nil)
-		if tc.IsPoweredOn {
-			return m
+			m.EXPECT().IsPoweredOn(context.TODO()).Return(tc.IsPoweredOn, nil)
+			if tc.IsPoweredOn {
+				return m
+			}
 		}
 
 		if !tc.IsNodeForbidden && !tc.IsNodeDeleted {
@@ -132,6 +238,9 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 			return m
 		}
 
+		if hostSwap {
+			m.EXPECT().DissociateMetal3Machine(context.TODO())
+		}
 		m.EXPECT().SetRemediationPhase(infrav1.PhaseWaiting)
 		return m
 
@@ -139,9 +248,22 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 
 		expectGetNode()
 
-		m.EXPECT().IsPowerOffRequested(context.TODO()).Return(tc.IsPowerOffRequested, nil)
-		if tc.IsPowerOffRequested {
-			m.EXPECT().RemovePowerOffAnnotation(context.TODO())
+		if reprovision {
+			m.EXPECT().GetProvisioningState(context.TODO()).Return(tc.ProvisioningState, nil)
+			if tc.ProvisioningState != bmov1alpha1.StateProvisioned {
+				expectRetry()
+				return m
+			}
+			m.EXPECT().IsDeprovisionRequested(context.TODO()).Return(tc.IsDeprovisionRequested, nil)
+			if tc.IsDeprovisionRequested {
+				expectRetry()
+				return m
+			}
+		} else {
+			m.EXPECT().IsPowerOffRequested(context.TODO()).Return(tc.IsPowerOffRequested, nil)
+			if tc.IsPowerOffRequested {
+				m.EXPECT().RemovePowerOffAnnotation(context.TODO())
+			}
 		}
 
 		m.EXPECT().IsPoweredOn(context.TODO()).Return(tc.IsPoweredOn, nil)
@@ -154,31 +276,19 @@ func setReconcileNormalRemediationExpectations(ctrl *gomock.Controller,
 			if !tc.IsNodeDeleted {
 				m.EXPECT().GetNodeBackupAnnotations().Return("{\"foo\":\"bar\"}", "{\"answer\":\"42\"}")
 				m.EXPECT().UpdateNode(context.TODO(), gomock.Any(), gomock.Any())
+				m.EXPECT().ReleaseRemediationBudget(context.TODO())
 				m.EXPECT().RemoveNodeBackupAnnotations()
 				m.EXPECT().UnsetFinalizer()
 				return m
 			}
 			if tc.IsNodeForbidden {
+				m.EXPECT().ReleaseRemediationBudget(context.TODO())
 				m.EXPECT().UnsetFinalizer()
 				return m
 			}
 		}
 
-		m.EXPECT().GetTimeout().Return(&metav1.Duration{Duration: time.Second})
-		m.EXPECT().TimeToRemediate(gomock.Any()).Return(tc.IsTimedOut, time.Second)
-		if tc.IsTimedOut {
-			m.EXPECT().RetryLimitIsSet().Return(true)
-			m.EXPECT().HasReachRetryLimit().Return(tc.IsRetryLimitReached)
-			if !tc.IsRetryLimitReached {
-				m.EXPECT().SetRemediationPhase(infrav1.PhaseRunning)
-				m.EXPECT().SetLastRemediationTime(gomock.Any())
-				m.EXPECT().IncreaseRetryCount()
-				return m
-			}
-			m.EXPECT().SetOwnerRemediatedConditionNew(context.TODO())
-			m.EXPECT().SetUnhealthyAnnotation(context.TODO())
-			m.EXPECT().SetRemediationPhase(infrav1.PhaseDeleting)
-		}
+		expectRetry()
 
 	case infrav1.PhaseDeleting:
 		expectGetNode()
@@ -202,7 +312,7 @@ var _ = Describe("Metal3Remediation controller", func() {
 
 				remReconcile = &Metal3RemediationReconciler{
 					Client:         fakeClient,
-					ManagerFactory: baremetal.NewManagerFactory(fakeClient),
+					ManagerFactory: baremetal.NewManagerFactory(fakeClient, &record.FakeRecorder{}),
 					Log:            logr.Discard(),
 				}
 			})
@@ -247,6 +357,12 @@ var _ = Describe("Metal3Remediation controller", func() {
 					IsNodeDeleted:       false,
 					IsTimedOut:          false,
 				}),
+				Entry("Should wait if the remediation budget of the cluster is exceeded, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:      false,
+					ExpectRequeue:    true,
+					RemediationPhase: "",
+					IsBudgetExceeded: true,
+				}),
 				Entry("Should set finalizer, last remediation time and retry count, and then requeue", reconcileNormalRemediationTestCase{
 					ExpectError:         false,
 					ExpectRequeue:       true,
@@ -269,6 +385,42 @@ var _ = Describe("Metal3Remediation controller", func() {
 					IsNodeDeleted:       false,
 					IsTimedOut:          false,
 				}),
+				Entry("Should cordon the node and evict its pods before power off, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationPhase:    infrav1.PhaseRunning,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+					IsDrainRequested:    true,
+					IsDrained:           false,
+				}),
+				Entry("Should error without requeue if the node drain fails", reconcileNormalRemediationTestCase{
+					ExpectError:         true,
+					ExpectRequeue:       false,
+					RemediationPhase:    infrav1.PhaseRunning,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+					IsDrainRequested:    true,
+					IsDrainFailing:      true,
+				}),
+				Entry("Should request power off once the node is drained, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationPhase:    infrav1.PhaseRunning,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+					IsDrainRequested:    true,
+					IsDrained:           true,
+				}),
+				Entry("Should request power off without drain if the node is gone, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationPhase:    infrav1.PhaseRunning,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+					IsDrainRequested:    true,
+					IsNodeDeleted:       true,
+				}),
 				Entry("Should requeue while still powered on", reconcileNormalRemediationTestCase{
 					ExpectError:         false,
 					ExpectRequeue:       true,
@@ -404,6 +556,234 @@ var _ = Describe("Metal3Remediation controller", func() {
 					IsTimedOut:          true,
 					IsRetryLimitReached: true,
 				}),
+				Entry("Should escalate to the next step if retry limit is reached, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationPhase:    infrav1.PhaseWaiting,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+					IsPoweredOn:         true,
+					IsNodeBackedUp:      true,
+					IsNodeDeleted:       true,
+					IsTimedOut:          true,
+					IsRetryLimitReached: true,
+					HasNextStep:         true,
+				}),
+				Entry("SoftReboot: should request power off and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationType:     infrav1.SoftRebootRemediationStrategy,
+					RemediationPhase:    infrav1.PhaseRunning,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: false,
+				}),
+				Entry("SoftReboot: should request power on, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationType:     infrav1.SoftRebootRemediationStrategy,
+					RemediationPhase:    infrav1.PhaseWaiting,
+					IsFinalizerSet:      true,
+					IsPowerOffRequested: true,
+					IsPoweredOn:         false,
+				}),
+				Entry("Reprovision: should request deprovisioning and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+				}),
+				Entry("Reprovision: should drain the node before deprovisioning, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsDrainRequested:  true,
+					IsDrained:         false,
+				}),
+				Entry("Reprovision: should error without requeue if the node drain fails", reconcileNormalRemediationTestCase{
+					ExpectError:       true,
+					ExpectRequeue:     false,
+					RemediationType:   infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsDrainRequested:  true,
+					IsDrainFailing:    true,
+				}),
+				Entry("Reprovision: should requeue while still provisioned", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          true,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseRunning,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateProvisioned,
+					IsDeprovisionRequested: true,
+				}),
+				Entry("Reprovision: should backup node when deprovisioning, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          true,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseRunning,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateDeprovisioning,
+					IsDeprovisionRequested: true,
+					IsNodeBackedUp:         false,
+				}),
+				Entry("Reprovision: should delete node when backed up, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          true,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseRunning,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateDeprovisioning,
+					IsDeprovisionRequested: true,
+					IsNodeBackedUp:         true,
+				}),
+				Entry("Reprovision: should update phase when node is deleted", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          true,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseRunning,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateAvailable,
+					IsDeprovisionRequested: true,
+					IsNodeDeleted:          true,
+				}),
+				Entry("Reprovision: should requeue while provisioning if not timed out", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseWaiting,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioning,
+					IsNodeDeleted:     true,
+					IsTimedOut:        false,
+				}),
+				Entry("Reprovision: should restart remediation if provisioning timed out, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:         false,
+					ExpectRequeue:       true,
+					RemediationType:     infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:    infrav1.PhaseWaiting,
+					IsFinalizerSet:      true,
+					ProvisioningState:   bmov1alpha1.StateProvisioning,
+					IsNodeDeleted:       true,
+					IsTimedOut:          true,
+					IsRetryLimitReached: false,
+				}),
+				Entry("Reprovision: should trigger machine deletion if retry limit is reached, and don't requeue", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          false,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseWaiting,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateProvisioned,
+					IsDeprovisionRequested: true,
+					IsNodeDeleted:          true,
+					IsTimedOut:             true,
+					IsRetryLimitReached:    true,
+				}),
+				Entry("Reprovision: should escalate to the next step if retry limit is reached, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:            false,
+					ExpectRequeue:          true,
+					RemediationType:        infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:       infrav1.PhaseWaiting,
+					IsFinalizerSet:         true,
+					ProvisioningState:      bmov1alpha1.StateProvisioned,
+					IsDeprovisionRequested: true,
+					IsNodeDeleted:          true,
+					IsTimedOut:             true,
+					IsRetryLimitReached:    true,
+					HasNextStep:            true,
+				}),
+				Entry("Reprovision: should restore node when provisioned, and clean up and requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.ReprovisionRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseWaiting,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsPoweredOn:       true,
+					IsNodeDeleted:     false,
+				}),
+				Entry("HostSwap: should release the host and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsHostReleased:    false,
+				}),
+				Entry("HostSwap: should drain the node before releasing the host, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:      false,
+					ExpectRequeue:    true,
+					RemediationType:  infrav1.HostSwapRemediationStrategy,
+					RemediationPhase: infrav1.PhaseRunning,
+					IsFinalizerSet:   true,
+					IsDrainRequested: true,
+					IsDrained:        true,
+				}),
+				Entry("HostSwap: should error without requeue if the node drain fails", reconcileNormalRemediationTestCase{
+					ExpectError:      true,
+					ExpectRequeue:    false,
+					RemediationType:  infrav1.HostSwapRemediationStrategy,
+					RemediationPhase: infrav1.PhaseRunning,
+					IsFinalizerSet:   true,
+					IsDrainRequested: true,
+					IsDrainFailing:   true,
+				}),
+				Entry("HostSwap: should requeue while still provisioned", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsHostReleased:    true,
+				}),
+				Entry("HostSwap: should backup node when deprovisioning, and then requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateDeprovisioning,
+					IsHostReleased:    true,
+					IsNodeBackedUp:    false,
+				}),
+				Entry("HostSwap: should dissociate the Metal3Machine and update phase when node is deleted", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseRunning,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateDeprovisioning,
+					IsHostReleased:    true,
+					IsNodeDeleted:     true,
+				}),
+				Entry("HostSwap: should requeue while the spare host is not provisioned", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseWaiting,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioning,
+					IsNodeDeleted:     true,
+				}),
+				Entry("HostSwap: should restore node when the spare host is provisioned, and clean up and requeue", reconcileNormalRemediationTestCase{
+					ExpectError:       false,
+					ExpectRequeue:     true,
+					RemediationType:   infrav1.HostSwapRemediationStrategy,
+					RemediationPhase:  infrav1.PhaseWaiting,
+					IsFinalizerSet:    true,
+					ProvisioningState: bmov1alpha1.StateProvisioned,
+					IsPoweredOn:       true,
+					IsNodeDeleted:     false,
+				}),
 				Entry("Should not requeue for Phase Deleting", reconcileNormalRemediationTestCase{
 					ExpectError:      false,
 					ExpectRequeue:    false,
@@ -44,6 +44,7 @@ import (
 	"k8s.io/klog/v2/klogr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -134,6 +135,7 @@ func main() {
 	}
 
 	setupChecks(mgr)
+	setupMetrics(mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
 
@@ -271,12 +273,26 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics(mgr ctrl.Manager) {
+	if err := metrics.Registry.Register(baremetal.NewHostPoolCollector(mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to register the host pool metrics")
+		os.Exit(1)
+	}
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
+	// The workload cluster clients are shared by the controllers.
+	tracker := infraremote.NewClusterClientTracker(mgr.GetClient())
+	if err := mgr.Add(tracker); err != nil {
+		setupLog.Error(err, "unable to add the cluster client tracker")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.Metal3MachineReconciler{
 		Client:           mgr.GetClient(),
-		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3machine-controller")),
 		Log:              ctrl.Log.WithName("controllers").WithName("Metal3Machine"),
-		CapiClientGetter: infraremote.NewClusterClient,
+		CapiClientGetter: tracker.GetClient,
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Metal3MachineReconciler")
@@ -285,7 +301,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3ClusterReconciler{
 		Client:           mgr.GetClient(),
-		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3cluster-controller")),
 		Log:              ctrl.Log.WithName("controllers").WithName("Metal3Cluster"),
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr); err != nil {
@@ -295,7 +311,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3DataTemplateReconciler{
 		Client:           mgr.GetClient(),
-		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3datatemplate-controller")),
 		Log:              ctrl.Log.WithName("controllers").WithName("Metal3DataTemplate"),
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr); err != nil {
@@ -305,7 +321,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3DataReconciler{
 		Client:           mgr.GetClient(),
-		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3data-controller")),
 		Log:              ctrl.Log.WithName("controllers").WithName("Metal3Data"),
 		WatchFilterValue: watchFilterValue,
 	}).SetupWithManager(ctx, mgr); err != nil {
@@ -315,9 +331,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3LabelSyncReconciler{
 		Client:           mgr.GetClient(),
-		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory:   baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3labelsync-controller")),
 		Log:              ctrl.Log.WithName("controllers").WithName("Metal3LabelSync"),
-		CapiClientGetter: infraremote.NewClusterClient,
+		CapiClientGetter: tracker.GetClient,
+		Tracker:          tracker,
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Metal3LabelSyncReconciler")
 		os.Exit(1)
@@ -325,7 +342,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3MachineTemplateReconciler{
 		Client:         mgr.GetClient(),
-		ManagerFactory: baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory: baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3machinetemplate-controller")),
 		Log:            ctrl.Log.WithName("controllers").WithName("Metal3MachineTemplate"),
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Metal3MachineTemplateReconciler")
@@ -334,7 +351,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if err := (&controllers.Metal3RemediationReconciler{
 		Client:         mgr.GetClient(),
-		ManagerFactory: baremetal.NewManagerFactory(mgr.GetClient()),
+		ManagerFactory: baremetal.NewManagerFactory(mgr.GetClient(), mgr.GetEventRecorderFor("metal3remediation-controller")).WithClientGetter(tracker.GetClient),
 		Log:            ctrl.Log.WithName("controllers").WithName("Metal3Remediation"),
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Metal3Remediation")